@@ -1,6 +1,9 @@
 package main
 
 import (
+	"context"
+	"time"
+
 	"azure-magnetar/config"
 	"azure-magnetar/internal/handler"
 	"azure-magnetar/internal/middleware"
@@ -8,7 +11,10 @@ import (
 	"azure-magnetar/internal/repository"
 	"azure-magnetar/internal/service"
 	"azure-magnetar/pkg/database"
+	"azure-magnetar/pkg/email"
 	"azure-magnetar/pkg/logger"
+	"azure-magnetar/pkg/realtime"
+	"azure-magnetar/pkg/scheduler"
 
 	"github.com/gin-gonic/gin"
 	"github.com/joho/godotenv"
@@ -56,10 +62,13 @@ func main() {
 	services := initServices(repos, cfg)
 	handlers := initHandlers(services)
 
-	// 4. Setup Router
+	// 4. Start Background Jobs
+	startBackgroundJobs(context.Background(), services)
+
+	// 5. Setup Router
 	r := setupRouter(cfg, handlers)
 
-	// 5. Start Server
+	// 6. Start Server
 	logger.Info("server starting", "port", cfg.Port)
 	if err := r.Run("0.0.0.0:" + cfg.Port); err != nil {
 		logger.Error("failed to start server", "error", err)
@@ -77,6 +86,13 @@ type repositories struct {
 	like         repository.LikeRepository
 	rating       repository.RatingRepository
 	notification repository.NotificationRepository
+	bookmark     repository.BookmarkRepository
+	tag          repository.TagRepository
+	insight      repository.InsightRepository
+	chat         repository.ChatRepository
+	agreement    repository.AgreementRepository
+	album        repository.AlbumRepository
+	credit       repository.CreditRepository
 }
 
 type services struct {
@@ -88,6 +104,15 @@ type services struct {
 	like         service.LikeService
 	rating       service.RatingService
 	notification service.NotificationService
+	digest       service.DigestService
+	calendar     service.CalendarService
+	chat         service.ChatService
+	agreement    service.AgreementService
+	album        service.AlbumService
+	bookmark     service.BookmarkService
+	credit       service.CreditService
+	tag          service.TagService
+	insight      service.InsightService
 }
 
 type handlers struct {
@@ -97,11 +122,25 @@ type handlers struct {
 	work         *handler.WorkHandler
 	comment      *handler.CommentHandler
 	notification *handler.NotificationHandler
+	calendar     *handler.CalendarHandler
+	chat         *handler.ChatHandler
+	agreement    *handler.AgreementHandler
+	album        *handler.AlbumHandler
+	bookmark     *handler.BookmarkHandler
+	credit       *handler.CreditHandler
+	tag          *handler.TagHandler
+	insight      *handler.InsightHandler
 }
 
 // --- Initialization ---
 
 func migrateDatabase() {
+	// post_tags carries a creation time for trending tags
+	if err := database.DB.SetupJoinTable(&model.Post{}, "Tags", &model.PostTag{}); err != nil {
+		logger.Error("failed to set up post_tags join table", "error", err)
+		return
+	}
+
 	if err := database.DB.AutoMigrate(
 		&model.User{},
 		&model.UserProfile{},
@@ -109,11 +148,34 @@ func migrateDatabase() {
 		&model.Follow{},
 		&model.Activity{},
 		&model.ActivityParticipant{},
+		&model.ActivityRoleSlot{},
+		&model.ActivityQuestion{},
+		&model.ActivityInviteLink{},
+		&model.ActivityCoHost{},
+		&model.ActivityTemplate{},
+		&model.ActivityAnnouncement{},
+		&model.ChatRoom{},
+		&model.ChatMessage{},
+		&model.ChatReadState{},
+		&model.ActivityAgreement{},
+		&model.AgreementSignature{},
+		&model.ActivitySeries{},
 		&model.Comment{},
 		&model.Like{},
 		&model.Notification{},
 		&model.Rating{},
 		&model.Tag{},
+		&model.TagFollow{},
+		&model.ViewEvent{},
+		&model.DailyInsight{},
+		&model.WorkDailyInsight{},
+		&model.InsightRollupDay{},
+		&model.Album{},
+		&model.AlbumWork{},
+		&model.BookmarkBoard{},
+		&model.Bookmark{},
+		&model.WorkCredit{},
+		&model.NotificationPreference{},
 	); err != nil {
 		logger.Error("failed to migrate database", "error", err)
 		return
@@ -131,33 +193,101 @@ func initRepositories() *repositories {
 		like:         repository.NewLikeRepository(db),
 		rating:       repository.NewRatingRepository(db),
 		notification: repository.NewNotificationRepository(db),
+		bookmark:     repository.NewBookmarkRepository(db),
+		tag:          repository.NewTagRepository(db),
+		insight:      repository.NewInsightRepository(db),
+		chat:         repository.NewChatRepository(db),
+		agreement:    repository.NewAgreementRepository(db),
+		album:        repository.NewAlbumRepository(db),
+		credit:       repository.NewCreditRepository(db),
 	}
 }
 
 func initServices(repos *repositories, cfg *config.Config) *services {
 	return &services{
-		user:         service.NewUserService(repos.user, repos.follow, repos.rating, cfg.APIBaseURL, cfg.FrontendURL, cfg.GCSBucketName),
+		user:         service.NewUserService(repos.user, repos.follow, repos.rating, repos.activity, cfg.APIBaseURL, cfg.FrontendURL, cfg.GCSBucketName),
 		follow:       service.NewFollowService(repos.follow, repos.rating, service.NewNotificationService(repos.notification)),
-		activity:     service.NewActivityService(repos.activity, repos.comment, repos.rating, cfg.APIBaseURL, cfg.GCSBucketName, service.NewNotificationService(repos.notification)),
-		work:         service.NewWorkService(repos.work, cfg.APIBaseURL, cfg.GCSBucketName),
+		activity:     service.NewActivityService(repos.activity, repos.comment, repos.rating, repos.user, repos.chat, repos.follow, repos.work, cfg.APIBaseURL, cfg.GCSBucketName, service.NewNotificationService(repos.notification), cfg.FrontendURL, cfg.LinkSecret),
+		work:         service.NewWorkService(repos.work, repos.activity, cfg.APIBaseURL, cfg.GCSBucketName),
 		comment:      service.NewCommentService(repos.comment, repos.work, repos.activity, repos.rating, service.NewNotificationService(repos.notification)),
 		like:         service.NewLikeService(repos.like, repos.work, service.NewNotificationService(repos.notification)),
 		rating:       service.NewRatingService(repos.rating, repos.activity),
 		notification: service.NewNotificationService(repos.notification),
+		digest:       service.NewDigestService(repos.user, repos.notification, repos.work, repos.activity, cfg.APIBaseURL, cfg.FrontendURL, email.SendWeeklyDigestEmail),
+		calendar:     service.NewCalendarService(repos.activity, repos.user, cfg.APIBaseURL, cfg.FrontendURL),
+		chat:         service.NewChatService(repos.chat, repos.activity, realtime.NewHub(), cfg.APIBaseURL, cfg.GCSBucketName),
+		agreement:    service.NewAgreementService(repos.agreement, repos.activity, repos.user),
+		album:        service.NewAlbumService(repos.album, repos.work),
+		bookmark:     service.NewBookmarkService(repos.bookmark, repos.work, repos.activity),
+		credit:       service.NewCreditService(repos.credit, repos.work, repos.activity, repos.user, service.NewNotificationService(repos.notification)),
+		tag:          service.NewTagService(repos.tag, repos.work),
+		insight:      service.NewInsightService(repos.insight, repos.work, digestLocation()),
 	}
 }
 
 func initHandlers(svc *services) *handlers {
 	return &handlers{
-		user:         handler.NewUserHandler(svc.user, svc.follow, svc.work, svc.activity, svc.rating),
+		user:         handler.NewUserHandler(svc.user, svc.follow, svc.work, svc.activity, svc.rating, svc.insight),
 		follow:       handler.NewFollowHandler(svc.follow),
 		activity:     handler.NewActivityHandler(svc.activity, svc.comment, svc.rating),
-		work:         handler.NewWorkHandler(svc.work, svc.like, svc.comment, svc.rating),
+		work:         handler.NewWorkHandler(svc.work, svc.like, svc.comment, svc.rating, svc.insight),
 		comment:      handler.NewCommentHandler(svc.comment),
 		notification: handler.NewNotificationHandler(svc.notification),
+		calendar:     handler.NewCalendarHandler(svc.calendar),
+		chat:         handler.NewChatHandler(svc.chat),
+		agreement:    handler.NewAgreementHandler(svc.agreement),
+		album:        handler.NewAlbumHandler(svc.album),
+		bookmark:     handler.NewBookmarkHandler(svc.bookmark),
+		credit:       handler.NewCreditHandler(svc.credit),
+		tag:          handler.NewTagHandler(svc.tag, svc.insight),
+		insight:      handler.NewInsightHandler(svc.insight),
 	}
 }
 
+// --- Background Jobs ---
+
+// digestWeekday and digestHour define when the weekly digest goes out (local time in digestLocation).
+const (
+	digestWeekday = time.Monday
+	digestHour    = 9
+)
+
+// seriesExtendInterval is how often recurring series get new occurrences created.
+const seriesExtendInterval = 6 * time.Hour
+
+// attendanceInterval is how often finished activities are checked for no-shows.
+const attendanceInterval = time.Hour
+
+// insightRollupInterval is how often view events are rolled up into creator insights.
+const insightRollupInterval = 15 * time.Minute
+
+// publishInterval is how often scheduled drafts are checked for publication.
+const publishInterval = time.Minute
+
+// reminderInterval is how often upcoming activities are checked for reminders.
+const reminderInterval = 15 * time.Minute
+
+func startBackgroundJobs(ctx context.Context, svc *services) {
+	scheduler.Weekly(ctx, "weekly_digest", digestWeekday, digestHour, digestLocation(), svc.digest.SendWeeklyDigests)
+	scheduler.Every(ctx, "series_occurrences", seriesExtendInterval, svc.activity.ExtendSeries)
+	scheduler.Every(ctx, "scheduled_publish", publishInterval, svc.activity.PublishScheduled)
+	scheduler.Every(ctx, "activity_reminders", reminderInterval, svc.activity.SendReminders)
+	scheduler.Every(ctx, "attendance_close", attendanceInterval, svc.activity.CloseAttendance)
+	scheduler.Every(ctx, "insight_rollup", insightRollupInterval, svc.insight.Rollup)
+}
+
+// digestLocation returns the timezone used for the digest schedule and for
+// the days in creator insights.
+// Falls back to a fixed UTC+8 zone when tzdata is unavailable.
+func digestLocation() *time.Location {
+	loc, err := time.LoadLocation("Asia/Taipei")
+	if err != nil {
+		logger.Warn("failed to load Asia/Taipei timezone, using fixed UTC+8", "error", err)
+		return time.FixedZone("UTC+8", 8*60*60)
+	}
+	return loc
+}
+
 // --- Router Setup ---
 
 func setupRouter(cfg *config.Config, h *handlers) *gin.Engine {
@@ -198,11 +328,30 @@ func setupRouter(cfg *config.Config, h *handlers) *gin.Engine {
 		users.GET("/me", authMiddleware, h.user.GetMe)
 		users.PUT("/me", authMiddleware, h.user.UpdateMe)
 		users.GET("/me/applications", authMiddleware, h.user.GetMyApplications)
+		users.GET("/me/calendar", authMiddleware, h.calendar.GetMyCalendarSubscription)
+		users.GET("/me/activity-templates", authMiddleware, h.activity.ListActivityTemplates)
+		users.GET("/me/chats", authMiddleware, h.chat.ListMyChats)
+		users.PUT("/me/pinned-works", authMiddleware, h.work.SetPinnedWorks)
+		users.PUT("/me/albums/order", authMiddleware, h.album.ReorderMyAlbums)
+		users.GET("/me/bookmarks", authMiddleware, h.bookmark.ListMyBookmarks)
+		users.GET("/me/bookmark-boards", authMiddleware, h.bookmark.ListMyBoards)
+		users.POST("/me/bookmark-boards", authMiddleware, h.bookmark.CreateBoard)
+		users.PUT("/me/bookmark-boards/:boardId", authMiddleware, h.bookmark.RenameBoard)
+		users.DELETE("/me/bookmark-boards/:boardId", authMiddleware, h.bookmark.DeleteBoard)
+		users.GET("/me/credits", authMiddleware, h.credit.ListMyCredits)
+		users.GET("/me/followed-tags", authMiddleware, h.tag.ListMyFollowedTags)
+		users.GET("/me/insights", authMiddleware, h.insight.GetMyInsights)
+		users.PUT("/me/credits/:creditId", authMiddleware, h.credit.RespondCredit)
+		users.DELETE("/me/activity-templates/:templateId", authMiddleware, h.activity.DeleteActivityTemplate)
+		users.POST("/me/calendar/reset", authMiddleware, h.calendar.ResetMyCalendarSubscription)
+		users.GET("/me/calendar.ics", h.calendar.GetMyCalendarFeed) // Authenticated by the secret token
 
 		// Public
-		users.GET("/:id", h.user.GetUser)
-		users.GET("/:id/works", h.user.GetUserWorks)
-		users.GET("/:id/activities", h.user.GetUserActivities)
+		users.GET("/:id", authOptional, h.user.GetUser)
+		users.GET("/:id/works", authOptional, h.user.GetUserWorks)
+		users.GET("/:id/albums", authOptional, h.album.ListUserAlbums)
+		users.GET("/:id/credited-works", h.credit.GetUserCreditedWorks)
+		users.GET("/:id/activities", authOptional, h.user.GetUserActivities)
 		users.GET("/:id/reviews", h.user.GetUserReviews)
 
 		// Follow (Authenticated)
@@ -218,25 +367,61 @@ func setupRouter(cfg *config.Config, h *handlers) *gin.Engine {
 	{
 		// Public
 		activities.GET("", h.activity.ListActivities)
-		activities.GET("/:id", h.activity.GetActivity)
-		activities.GET("/:id/comments", h.activity.GetActivityComments)
+		activities.GET("/:id", authOptional, h.activity.GetActivity)
+		activities.GET("/:id/comments", authOptional, h.activity.GetActivityComments)
+		activities.GET("/:id/series", authOptional, h.activity.GetActivitySeries)
+		activities.GET("/:id/calendar.ics", authOptional, h.calendar.GetActivityCalendar)
 		activities.GET("/:id/participants", h.activity.ListParticipants)
+		activities.GET("/:id/works", authOptional, h.activity.GetActivityWorks)
 
 		// Authenticated
 		activities.POST("", authMiddleware, h.activity.CreateActivity)
 		activities.PUT("/:id", authMiddleware, h.activity.UpdateActivity)
 		activities.DELETE("/:id", authMiddleware, h.activity.DeleteActivity)
 		activities.POST("/:id/cancel", authMiddleware, h.activity.CancelActivity)
+		activities.POST("/:id/publish", authMiddleware, h.activity.PublishActivity)
+		activities.POST("/:id/duplicate", authMiddleware, h.activity.DuplicateActivity)
+		activities.POST("/:id/template", authMiddleware, h.activity.SaveActivityTemplate)
 
 		// Participation
 		activities.POST("/:id/apply", authMiddleware, h.activity.ApplyToActivity)
 		activities.DELETE("/:id/apply", authMiddleware, h.activity.CancelApplication)
 		activities.GET("/:id/status", authMiddleware, h.activity.GetApplicationStatus)
+		activities.POST("/:id/invitation/accept", authMiddleware, h.activity.AcceptInvitation)
+		activities.POST("/:id/invitation/decline", authMiddleware, h.activity.DeclineInvitation)
 
 		// Host Management
 		activities.GET("/:id/applicants", authMiddleware, h.activity.ListApplicants)
 		activities.PUT("/:id/applicants/:userId/status", authMiddleware, h.activity.UpdateApplicantStatus)
+		activities.PUT("/:id/applicants/status", authMiddleware, h.activity.BatchUpdateApplicantStatus)
 		activities.POST("/:id/invite", authMiddleware, h.activity.InviteUser)
+		activities.POST("/:id/invite-links", authMiddleware, h.activity.CreateInviteLink)
+		activities.GET("/:id/invite-links", authMiddleware, h.activity.ListInviteLinks)
+		activities.DELETE("/:id/invite-links/:linkId", authMiddleware, h.activity.RevokeInviteLink)
+		activities.POST("/:id/announcements", authMiddleware, h.activity.PostAnnouncement)
+		activities.GET("/:id/announcements", authMiddleware, h.activity.ListAnnouncements)
+
+		// Group chat (host, co-hosts and accepted participants)
+		activities.GET("/:id/chat/messages", authMiddleware, h.chat.ListMessages)
+		activities.POST("/:id/chat/messages", authMiddleware, h.chat.SendMessage)
+		activities.POST("/:id/chat/read", authMiddleware, h.chat.MarkRead)
+		activities.GET("/:id/chat/stream", authMiddleware, h.chat.StreamMessages)
+
+		// Agreements
+		activities.GET("/:id/agreement", authOptional, h.agreement.GetAgreement)
+		activities.PUT("/:id/agreement", authMiddleware, h.agreement.SetAgreement)
+		activities.POST("/:id/agreement/sign", authMiddleware, h.agreement.SignAgreement)
+		activities.GET("/:id/agreement/signatures", authMiddleware, h.agreement.ListSignatures)
+		activities.GET("/:id/agreement/pdf", authMiddleware, h.agreement.GetAgreementPDF)
+
+		// Co-hosts
+		activities.POST("/:id/cohosts", authMiddleware, h.activity.AddCoHost)
+		activities.PUT("/:id/cohosts/:userId", authMiddleware, h.activity.UpdateCoHost)
+		activities.DELETE("/:id/cohosts/:userId", authMiddleware, h.activity.RemoveCoHost)
+
+		// Check-in
+		activities.GET("/:id/check-in/ticket", authMiddleware, h.activity.GetCheckInTicket)
+		activities.POST("/:id/check-in", authMiddleware, h.activity.CheckIn)
 
 		// Comments
 		activities.POST("/:id/comments", authMiddleware, h.activity.PostActivityComment)
@@ -244,6 +429,10 @@ func setupRouter(cfg *config.Config, h *handlers) *gin.Engine {
 		// Rating
 		activities.POST("/:id/rate", authMiddleware, h.activity.SubmitRating)
 		activities.GET("/:id/ratings", authMiddleware, h.activity.GetActivityRatings)
+
+		// Bookmarks
+		activities.POST("/:id/save", authMiddleware, h.bookmark.SaveActivity)
+		activities.DELETE("/:id/save", authMiddleware, h.bookmark.UnsaveActivity)
 	}
 
 	// --- Works ---
@@ -252,7 +441,8 @@ func setupRouter(cfg *config.Config, h *handlers) *gin.Engine {
 		// Public (with optional auth for following feed)
 		works.GET("", authOptional, h.work.GetWall)
 		works.GET("/:id", authOptional, h.work.GetWork)
-		works.GET("/:id/comments", h.work.GetWorkComments)
+		works.GET("/:id/comments", authOptional, h.work.GetWorkComments)
+		works.GET("/:id/credits", authOptional, h.credit.ListWorkCredits)
 
 		// Authenticated
 		works.POST("", authMiddleware, h.work.CreateWork)
@@ -263,10 +453,42 @@ func setupRouter(cfg *config.Config, h *handlers) *gin.Engine {
 		works.POST("/:id/like", authMiddleware, h.work.LikeWork)
 		works.DELETE("/:id/like", authMiddleware, h.work.UnlikeWork)
 
+		// Bookmarks
+		works.POST("/:id/save", authMiddleware, h.bookmark.SaveWork)
+		works.DELETE("/:id/save", authMiddleware, h.bookmark.UnsaveWork)
+
+		// Credits
+		works.POST("/:id/credits", authMiddleware, h.credit.AddWorkCredit)
+		works.DELETE("/:id/credits/:userId", authMiddleware, h.credit.RemoveWorkCredit)
+
 		// Comments
 		works.POST("/:id/comments", authMiddleware, h.work.PostWorkComment)
 	}
 
+	// --- Albums ---
+	albums := api.Group("/albums")
+	{
+		albums.GET("/:id", authOptional, h.album.GetAlbum)
+		albums.POST("", authMiddleware, h.album.CreateAlbum)
+		albums.PUT("/:id", authMiddleware, h.album.UpdateAlbum)
+		albums.DELETE("/:id", authMiddleware, h.album.DeleteAlbum)
+		albums.PUT("/:id/works", authMiddleware, h.album.SetAlbumWorks)
+	}
+
+	// --- Tags ---
+	tags := api.Group("/tags")
+	{
+		tags.GET("", h.tag.SearchTags)
+		tags.GET("/trending", h.tag.GetTrendingTags)
+		tags.GET("/:name", authOptional, h.tag.GetTag)
+		tags.GET("/:name/works", authOptional, h.tag.GetTagWorks)
+		tags.POST("/:name/follow", authMiddleware, h.tag.FollowTag)
+		tags.DELETE("/:name/follow", authMiddleware, h.tag.UnfollowTag)
+	}
+
+	// --- Agreements ---
+	api.GET("/agreements/templates", h.agreement.ListTemplates)
+
 	// --- Comments ---
 	comments := api.Group("/comments")
 	{
@@ -274,12 +496,19 @@ func setupRouter(cfg *config.Config, h *handlers) *gin.Engine {
 	}
 
 	// --- Notifications ---
+	// Unsubscribe is reached from email links, so it must stay public. The link
+	// opens a confirmation page; only the POST changes anything.
+	api.GET("/notifications/unsubscribe", h.notification.ConfirmUnsubscribe)
+	api.POST("/notifications/unsubscribe", h.notification.Unsubscribe)
+
 	notifications := api.Group("/notifications")
 	notifications.Use(authMiddleware)
 	{
 		notifications.GET("", h.notification.ListNotifications)
 		notifications.GET("/unread-count", h.notification.GetUnreadCount)
 		notifications.POST("/:id/read", h.notification.MarkAsRead)
+		notifications.GET("/preferences", h.notification.GetPreferences)
+		notifications.PUT("/preferences", h.notification.UpdatePreferences)
 	}
 
 	// Swagger
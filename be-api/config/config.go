@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"strings"
 
+	"azure-magnetar/pkg/auth"
 	"azure-magnetar/pkg/logger"
 
 	"github.com/spf13/viper"
@@ -14,6 +15,7 @@ type Config struct {
 	DataSourceName string `mapstructure:"dsn"`
 	Port           string `mapstructure:"port"`
 	JWTSecret      string `mapstructure:"jwt_secret"`
+	LinkSecret     string `mapstructure:"link_secret"` // Signs invite links and check-in codes; derived from JWTSecret when unset
 	FrontendURL    string `mapstructure:"frontend_url"`
 	APIBaseURL     string `mapstructure:"api_base_url"`
 	GCSBucketName  string `mapstructure:"gcs_bucket_name"`
@@ -43,6 +45,7 @@ func LoadConfig() *Config {
 	_ = viper.BindEnv("dsn", "DSN")
 	_ = viper.BindEnv("port", "PORT")
 	_ = viper.BindEnv("jwt_secret", "JWT_SECRET")
+	_ = viper.BindEnv("link_secret", "LINK_SECRET")
 	_ = viper.BindEnv("frontend_url", "FRONTEND_URL")
 	_ = viper.BindEnv("api_base_url", "API_BASE_URL")
 	_ = viper.BindEnv("gcs_bucket_name", "GCS_BUCKET_NAME")
@@ -69,6 +72,10 @@ func LoadConfig() *Config {
 	if cfg.APIBaseURL == "" {
 		cfg.APIBaseURL = fmt.Sprintf("http://localhost:%s", cfg.Port)
 	}
+	if cfg.LinkSecret == "" {
+		// Never sign links with the auth token key itself
+		cfg.LinkSecret = auth.DeriveSecret(cfg.JWTSecret, "activity-links")
+	}
 
 	return &cfg
 }
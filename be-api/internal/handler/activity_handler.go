@@ -43,6 +43,7 @@ func NewActivityHandler(
 // @Param        dateFrom query string false "Filter by start date"
 // @Param        dateTo   query string false "Filter by end date"
 // @Param        tags     query string false "Filter by tags"
+// @Param        status   query string false "Filter by status (open, full, ended, cancelled)"
 // @Param        offset   query int    false "Offset for pagination"
 // @Param        limit    query int    false "Limit per page"
 // @Success      200  {object}  response.Response
@@ -56,6 +57,7 @@ func (h *ActivityHandler) ListActivities(c *gin.Context) {
 		DateFrom: c.Query("dateFrom"),
 		DateTo:   c.Query("dateTo"),
 		Tags:     c.Query("tags"),
+		Status:   c.Query("status"),
 		Offset:   offset,
 		Limit:    limit,
 	}
@@ -74,10 +76,11 @@ func (h *ActivityHandler) ListActivities(c *gin.Context) {
 
 // GetActivity godoc
 // @Summary      Get activity details
-// @Description  Get activity details by ID
+// @Description  Get activity details by ID. Ended activities include a preview gallery of linked works.
 // @Tags         activities
 // @Produce      json
-// @Param        id path int true "Activity ID"
+// @Param        id     path  int    true  "Activity ID"
+// @Param        invite query string false "Signed invite link token (grants access to private activities)"
 // @Success      200  {object}  response.Response
 // @Failure      404  {object}  response.Response
 // @Router       /activities/{id} [get]
@@ -88,9 +91,10 @@ func (h *ActivityHandler) GetActivity(c *gin.Context) {
 		return
 	}
 
-	activity, err := h.activityService.GetByID(id)
+	viewerID := middleware.GetCurrentUserID(c)
+	activity, err := h.activityService.GetByID(id, viewerID, c.Query("invite"))
 	if err != nil {
-		response.Error(c, http.StatusNotFound, "activity not found")
+		HandleServiceError(c, err)
 		return
 	}
 
@@ -99,7 +103,7 @@ func (h *ActivityHandler) GetActivity(c *gin.Context) {
 
 // CreateActivity godoc
 // @Summary      Create a new activity
-// @Description  Create a new activity (authenticated)
+// @Description  Create a new activity (authenticated). Fields left unset are filled from templateId when given.
 // @Tags         activities
 // @Accept       json
 // @Produce      json
@@ -107,6 +111,7 @@ func (h *ActivityHandler) GetActivity(c *gin.Context) {
 // @Param        input body service.CreateActivityInput true "Activity Data"
 // @Success      200  {object}  response.Response
 // @Failure      400  {object}  response.Response
+// @Failure      404  {object}  response.Response
 // @Router       /activities [post]
 func (h *ActivityHandler) CreateActivity(c *gin.Context) {
 	userID := middleware.GetCurrentUserID(c)
@@ -119,7 +124,7 @@ func (h *ActivityHandler) CreateActivity(c *gin.Context) {
 
 	activity, err := h.activityService.Create(userID, input)
 	if err != nil {
-		response.Error(c, http.StatusInternalServerError, err.Error())
+		HandleServiceError(c, err)
 		return
 	}
 
@@ -190,11 +195,12 @@ func (h *ActivityHandler) DeleteActivity(c *gin.Context) {
 // CancelActivityInput represents the reason for cancellation.
 type CancelActivityInput struct {
 	Reason string `json:"reason"`
+	Scope  string `json:"scope"` // occurrence (default) or series
 }
 
 // CancelActivity godoc
 // @Summary      Cancel activity
-// @Description  Cancel activity with reason (host only)
+// @Description  Cancel activity with reason (host only). Scope "series" cancels every upcoming occurrence.
 // @Tags         activities
 // @Security     BearerAuth
 // @Param        id    path int true "Activity ID"
@@ -214,6 +220,15 @@ func (h *ActivityHandler) CancelActivity(c *gin.Context) {
 	// Bind JSON body if present, but it's optional
 	_ = c.ShouldBindJSON(&input)
 
+	if input.Scope == "series" {
+		if err := h.activityService.CancelSeries(userID, activityID, input.Reason); err != nil {
+			HandleServiceError(c, err)
+			return
+		}
+		response.Success(c, "activity series cancelled")
+		return
+	}
+
 	if err := h.activityService.Cancel(userID, activityID, input.Reason); err != nil {
 		response.Error(c, http.StatusForbidden, err.Error())
 		return
@@ -222,6 +237,32 @@ func (h *ActivityHandler) CancelActivity(c *gin.Context) {
 	response.Success(c, "activity cancelled")
 }
 
+// GetActivitySeries godoc
+// @Summary      List the occurrences of an activity's series
+// @Description  Returns every visible occurrence of the recurring series the activity belongs to, ordered by event time
+// @Tags         activities
+// @Produce      json
+// @Param        id path int true "Activity ID"
+// @Success      200  {object}  response.Response
+// @Failure      404  {object}  response.Response
+// @Router       /activities/{id}/series [get]
+func (h *ActivityHandler) GetActivitySeries(c *gin.Context) {
+	activityID, err := parseIDParam(c, "id")
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "invalid activity ID")
+		return
+	}
+
+	viewerID := middleware.GetCurrentUserID(c)
+	occurrences, err := h.activityService.GetSeriesOccurrences(activityID, viewerID)
+	if err != nil {
+		HandleServiceError(c, err)
+		return
+	}
+
+	response.Success(c, occurrences)
+}
+
 // --- Participation ---
 
 // ApplyToActivity godoc
@@ -231,7 +272,7 @@ func (h *ActivityHandler) CancelActivity(c *gin.Context) {
 // @Produce      json
 // @Security     BearerAuth
 // @Param        id path int true "Activity ID"
-// @Param        input body service.ApplyInput false "Application message"
+// @Param        input body service.ApplyInput false "Application message, role and form answers"
 // @Success      200  {object}  response.Response
 // @Failure      400  {object}  response.Response
 // @Router       /activities/{id}/apply [post]
@@ -246,7 +287,7 @@ func (h *ActivityHandler) ApplyToActivity(c *gin.Context) {
 	var input service.ApplyInput
 	_ = c.ShouldBindJSON(&input) // message is optional
 
-	if err := h.activityService.Apply(activityID, userID, input.Message); err != nil {
+	if err := h.activityService.Apply(activityID, userID, input); err != nil {
 		HandleServiceError(c, err)
 		return
 	}
@@ -256,10 +297,13 @@ func (h *ActivityHandler) ApplyToActivity(c *gin.Context) {
 
 // CancelApplication godoc
 // @Summary      Cancel application
+// @Description  Withdraw a pending or accepted application. Accepted participants cancelling inside the activity's free-cancel window are recorded as late cancellations.
 // @Tags         activities
 // @Security     BearerAuth
 // @Param        id path int true "Activity ID"
 // @Success      200  {object}  response.Response
+// @Failure      400  {object}  response.Response
+// @Failure      404  {object}  response.Response
 // @Router       /activities/{id}/apply [delete]
 func (h *ActivityHandler) CancelApplication(c *gin.Context) {
 	userID := middleware.GetCurrentUserID(c)
@@ -270,7 +314,7 @@ func (h *ActivityHandler) CancelApplication(c *gin.Context) {
 	}
 
 	if err := h.activityService.CancelApplication(activityID, userID); err != nil {
-		response.Error(c, http.StatusBadRequest, err.Error())
+		HandleServiceError(c, err)
 		return
 	}
 
@@ -311,6 +355,8 @@ func (h *ActivityHandler) GetApplicationStatus(c *gin.Context) {
 // @Param        input body service.InviteInput true "Invitation Data"
 // @Success      200  {object}  response.Response
 // @Failure      400  {object}  response.Response
+// @Failure      404  {object}  response.Response
+// @Failure      409  {object}  response.Response
 // @Router       /activities/{id}/invite [post]
 func (h *ActivityHandler) InviteUser(c *gin.Context) {
 	hostID := middleware.GetCurrentUserID(c)
@@ -326,22 +372,559 @@ func (h *ActivityHandler) InviteUser(c *gin.Context) {
 		return
 	}
 
-	if err := h.activityService.InviteUser(activityID, hostID, input.UserID, input.Message); err != nil {
-		response.Error(c, http.StatusBadRequest, err.Error())
+	if err := h.activityService.InviteUser(activityID, hostID, input); err != nil {
+		HandleServiceError(c, err)
 		return
 	}
 
 	response.Success(c, "invitation sent")
 }
 
+// AcceptInvitation godoc
+// @Summary      Accept an invitation to an activity
+// @Tags         activities
+// @Security     BearerAuth
+// @Param        id path int true "Activity ID"
+// @Success      200  {object}  response.Response
+// @Failure      404  {object}  response.Response
+// @Failure      409  {object}  response.Response
+// @Router       /activities/{id}/invitation/accept [post]
+func (h *ActivityHandler) AcceptInvitation(c *gin.Context) {
+	userID := middleware.GetCurrentUserID(c)
+	activityID, err := parseIDParam(c, "id")
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "invalid activity ID")
+		return
+	}
+
+	if err := h.activityService.AcceptInvitation(activityID, userID); err != nil {
+		HandleServiceError(c, err)
+		return
+	}
+
+	response.Success(c, "invitation accepted")
+}
+
+// DeclineInvitation godoc
+// @Summary      Decline an invitation to an activity
+// @Tags         activities
+// @Security     BearerAuth
+// @Param        id path int true "Activity ID"
+// @Success      200  {object}  response.Response
+// @Failure      404  {object}  response.Response
+// @Failure      409  {object}  response.Response
+// @Router       /activities/{id}/invitation/decline [post]
+func (h *ActivityHandler) DeclineInvitation(c *gin.Context) {
+	userID := middleware.GetCurrentUserID(c)
+	activityID, err := parseIDParam(c, "id")
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "invalid activity ID")
+		return
+	}
+
+	if err := h.activityService.DeclineInvitation(activityID, userID); err != nil {
+		HandleServiceError(c, err)
+		return
+	}
+
+	response.Success(c, "invitation declined")
+}
+
+// CreateInviteLink godoc
+// @Summary      Create a shareable invite link (host only)
+// @Tags         activities
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id    path int true "Activity ID"
+// @Param        input body service.CreateInviteLinkInput false "Link options"
+// @Success      200  {object}  response.Response
+// @Failure      400  {object}  response.Response
+// @Failure      403  {object}  response.Response
+// @Router       /activities/{id}/invite-links [post]
+func (h *ActivityHandler) CreateInviteLink(c *gin.Context) {
+	hostID := middleware.GetCurrentUserID(c)
+	activityID, err := parseIDParam(c, "id")
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "invalid activity ID")
+		return
+	}
+
+	var input service.CreateInviteLinkInput
+	_ = c.ShouldBindJSON(&input) // all options are optional
+
+	link, err := h.activityService.CreateInviteLink(activityID, hostID, input)
+	if err != nil {
+		HandleServiceError(c, err)
+		return
+	}
+
+	response.Success(c, link)
+}
+
+// ListInviteLinks godoc
+// @Summary      List invite links (host only)
+// @Tags         activities
+// @Security     BearerAuth
+// @Param        id path int true "Activity ID"
+// @Success      200  {object}  response.Response
+// @Failure      403  {object}  response.Response
+// @Router       /activities/{id}/invite-links [get]
+func (h *ActivityHandler) ListInviteLinks(c *gin.Context) {
+	hostID := middleware.GetCurrentUserID(c)
+	activityID, err := parseIDParam(c, "id")
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "invalid activity ID")
+		return
+	}
+
+	links, err := h.activityService.ListInviteLinks(activityID, hostID)
+	if err != nil {
+		HandleServiceError(c, err)
+		return
+	}
+
+	response.Success(c, links)
+}
+
+// RevokeInviteLink godoc
+// @Summary      Revoke an invite link (host only)
+// @Tags         activities
+// @Security     BearerAuth
+// @Param        id     path int true "Activity ID"
+// @Param        linkId path int true "Invite link ID"
+// @Success      200  {object}  response.Response
+// @Failure      403  {object}  response.Response
+// @Failure      404  {object}  response.Response
+// @Router       /activities/{id}/invite-links/{linkId} [delete]
+func (h *ActivityHandler) RevokeInviteLink(c *gin.Context) {
+	hostID := middleware.GetCurrentUserID(c)
+	activityID, err := parseIDParam(c, "id")
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "invalid activity ID")
+		return
+	}
+	linkID, err := parseIDParam(c, "linkId")
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "invalid link ID")
+		return
+	}
+
+	if err := h.activityService.RevokeInviteLink(activityID, hostID, linkID); err != nil {
+		HandleServiceError(c, err)
+		return
+	}
+
+	response.Success(c, "invite link revoked")
+}
+
+// PublishActivity godoc
+// @Summary      Publish a draft activity
+// @Description  Publishes now, or schedules publication when publishAt is set. Requires a future event time, at least one image and a capacity.
+// @Tags         activities
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id    path int true "Activity ID"
+// @Param        input body service.PublishInput false "Optional publish time"
+// @Success      200  {object}  response.Response
+// @Failure      400  {object}  response.Response
+// @Failure      403  {object}  response.Response
+// @Failure      409  {object}  response.Response
+// @Router       /activities/{id}/publish [post]
+func (h *ActivityHandler) PublishActivity(c *gin.Context) {
+	userID := middleware.GetCurrentUserID(c)
+	activityID, err := parseIDParam(c, "id")
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "invalid activity ID")
+		return
+	}
+
+	var input service.PublishInput
+	_ = c.ShouldBindJSON(&input) // publishAt is optional
+
+	activity, err := h.activityService.Publish(activityID, userID, input)
+	if err != nil {
+		HandleServiceError(c, err)
+		return
+	}
+
+	response.Success(c, activity)
+}
+
+// DuplicateActivity godoc
+// @Summary      Duplicate an activity as a new draft
+// @Description  Copies the details, roles, images and settings into a new draft hosted by the caller. Participants and co-hosts are not copied.
+// @Tags         activities
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id    path int true "Activity ID"
+// @Param        input body service.DuplicateInput false "Optional event time for the copy"
+// @Success      200  {object}  response.Response
+// @Failure      400  {object}  response.Response
+// @Failure      403  {object}  response.Response
+// @Failure      404  {object}  response.Response
+// @Router       /activities/{id}/duplicate [post]
+func (h *ActivityHandler) DuplicateActivity(c *gin.Context) {
+	userID := middleware.GetCurrentUserID(c)
+	activityID, err := parseIDParam(c, "id")
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "invalid activity ID")
+		return
+	}
+
+	var input service.DuplicateInput
+	_ = c.ShouldBindJSON(&input) // eventTime is optional
+
+	activity, err := h.activityService.Duplicate(activityID, userID, input)
+	if err != nil {
+		HandleServiceError(c, err)
+		return
+	}
+
+	response.Success(c, activity)
+}
+
+// --- Templates ---
+
+// SaveActivityTemplate godoc
+// @Summary      Save an activity as a personal template
+// @Tags         activities
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id    path int true "Activity ID"
+// @Param        input body service.SaveTemplateInput true "Template name"
+// @Success      200  {object}  response.Response
+// @Failure      400  {object}  response.Response
+// @Failure      403  {object}  response.Response
+// @Failure      409  {object}  response.Response
+// @Router       /activities/{id}/template [post]
+func (h *ActivityHandler) SaveActivityTemplate(c *gin.Context) {
+	userID := middleware.GetCurrentUserID(c)
+	activityID, err := parseIDParam(c, "id")
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "invalid activity ID")
+		return
+	}
+
+	var input service.SaveTemplateInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		response.Error(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	template, err := h.activityService.SaveTemplate(activityID, userID, input)
+	if err != nil {
+		HandleServiceError(c, err)
+		return
+	}
+
+	response.Success(c, template)
+}
+
+// ListActivityTemplates godoc
+// @Summary      List my activity templates
+// @Tags         activities
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200  {object}  response.Response
+// @Router       /users/me/activity-templates [get]
+func (h *ActivityHandler) ListActivityTemplates(c *gin.Context) {
+	userID := middleware.GetCurrentUserID(c)
+
+	templates, err := h.activityService.ListTemplates(userID)
+	if err != nil {
+		HandleServiceError(c, err)
+		return
+	}
+
+	response.Success(c, templates)
+}
+
+// DeleteActivityTemplate godoc
+// @Summary      Delete one of my activity templates
+// @Tags         activities
+// @Produce      json
+// @Security     BearerAuth
+// @Param        templateId path int true "Template ID"
+// @Success      200  {object}  response.Response
+// @Failure      404  {object}  response.Response
+// @Router       /users/me/activity-templates/{templateId} [delete]
+func (h *ActivityHandler) DeleteActivityTemplate(c *gin.Context) {
+	userID := middleware.GetCurrentUserID(c)
+	templateID, err := parseIDParam(c, "templateId")
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "invalid template ID")
+		return
+	}
+
+	if err := h.activityService.DeleteTemplate(userID, templateID); err != nil {
+		HandleServiceError(c, err)
+		return
+	}
+
+	response.Success(c, "template deleted")
+}
+
+// --- Announcements ---
+
+// PostAnnouncement godoc
+// @Summary      Send an announcement to participants (host)
+// @Description  Notifies and emails accepted participants, plus pending applicants when includePending is set.
+// @Tags         activities
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id    path int true "Activity ID"
+// @Param        input body service.AnnounceInput true "Announcement"
+// @Success      200  {object}  response.Response
+// @Failure      400  {object}  response.Response
+// @Failure      403  {object}  response.Response
+// @Failure      404  {object}  response.Response
+// @Router       /activities/{id}/announcements [post]
+func (h *ActivityHandler) PostAnnouncement(c *gin.Context) {
+	userID := middleware.GetCurrentUserID(c)
+	activityID, err := parseIDParam(c, "id")
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "invalid activity ID")
+		return
+	}
+
+	var input service.AnnounceInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		response.Error(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	announcement, err := h.activityService.Announce(activityID, userID, input)
+	if err != nil {
+		HandleServiceError(c, err)
+		return
+	}
+
+	response.Success(c, announcement)
+}
+
+// ListAnnouncements godoc
+// @Summary      List an activity's announcements (participants only)
+// @Tags         activities
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id   path int true "Activity ID"
+// @Success      200  {object}  response.Response
+// @Failure      403  {object}  response.Response
+// @Failure      404  {object}  response.Response
+// @Router       /activities/{id}/announcements [get]
+func (h *ActivityHandler) ListAnnouncements(c *gin.Context) {
+	userID := middleware.GetCurrentUserID(c)
+	activityID, err := parseIDParam(c, "id")
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "invalid activity ID")
+		return
+	}
+
+	announcements, err := h.activityService.ListAnnouncements(activityID, userID)
+	if err != nil {
+		HandleServiceError(c, err)
+		return
+	}
+
+	response.Success(c, announcements)
+}
+
+// --- Co-hosts ---
+
+// AddCoHost godoc
+// @Summary      Add a co-host (host only)
+// @Tags         activities
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id    path int true "Activity ID"
+// @Param        input body service.CoHostInput true "Co-host and permission level"
+// @Success      200  {object}  response.Response
+// @Failure      400  {object}  response.Response
+// @Failure      403  {object}  response.Response
+// @Failure      409  {object}  response.Response
+// @Router       /activities/{id}/cohosts [post]
+func (h *ActivityHandler) AddCoHost(c *gin.Context) {
+	hostID := middleware.GetCurrentUserID(c)
+	activityID, err := parseIDParam(c, "id")
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "invalid activity ID")
+		return
+	}
+
+	var input service.CoHostInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		response.Error(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	coHost, err := h.activityService.AddCoHost(activityID, hostID, input)
+	if err != nil {
+		HandleServiceError(c, err)
+		return
+	}
+
+	response.Success(c, coHost)
+}
+
+// UpdateCoHost godoc
+// @Summary      Change a co-host's permission (host only)
+// @Tags         activities
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id     path int true "Activity ID"
+// @Param        userId path int true "Co-host user ID"
+// @Param        input  body service.UpdateCoHostInput true "Permission level"
+// @Success      200  {object}  response.Response
+// @Failure      400  {object}  response.Response
+// @Failure      403  {object}  response.Response
+// @Failure      404  {object}  response.Response
+// @Router       /activities/{id}/cohosts/{userId} [put]
+func (h *ActivityHandler) UpdateCoHost(c *gin.Context) {
+	hostID := middleware.GetCurrentUserID(c)
+	activityID, err := parseIDParam(c, "id")
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "invalid activity ID")
+		return
+	}
+	userID, err := parseIDParam(c, "userId")
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "invalid user ID")
+		return
+	}
+
+	var input service.UpdateCoHostInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		response.Error(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	coHost, err := h.activityService.UpdateCoHost(activityID, hostID, userID, input.Permission)
+	if err != nil {
+		HandleServiceError(c, err)
+		return
+	}
+
+	response.Success(c, coHost)
+}
+
+// RemoveCoHost godoc
+// @Summary      Remove a co-host
+// @Description  The host can remove any co-host; a co-host can remove themselves
+// @Tags         activities
+// @Security     BearerAuth
+// @Param        id     path int true "Activity ID"
+// @Param        userId path int true "Co-host user ID"
+// @Success      200  {object}  response.Response
+// @Failure      403  {object}  response.Response
+// @Failure      404  {object}  response.Response
+// @Router       /activities/{id}/cohosts/{userId} [delete]
+func (h *ActivityHandler) RemoveCoHost(c *gin.Context) {
+	currentUserID := middleware.GetCurrentUserID(c)
+	activityID, err := parseIDParam(c, "id")
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "invalid activity ID")
+		return
+	}
+	userID, err := parseIDParam(c, "userId")
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "invalid user ID")
+		return
+	}
+
+	if err := h.activityService.RemoveCoHost(activityID, currentUserID, userID); err != nil {
+		HandleServiceError(c, err)
+		return
+	}
+
+	response.Success(c, "co-host removed")
+}
+
+// --- Check-in ---
+
+// GetCheckInTicket godoc
+// @Summary      Get my check-in QR token (accepted participants)
+// @Tags         activities
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id path int true "Activity ID"
+// @Success      200  {object}  response.Response
+// @Failure      403  {object}  response.Response
+// @Router       /activities/{id}/check-in/ticket [get]
+func (h *ActivityHandler) GetCheckInTicket(c *gin.Context) {
+	userID := middleware.GetCurrentUserID(c)
+	activityID, err := parseIDParam(c, "id")
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "invalid activity ID")
+		return
+	}
+
+	ticket, err := h.activityService.GetCheckInTicket(activityID, userID)
+	if err != nil {
+		HandleServiceError(c, err)
+		return
+	}
+
+	response.Success(c, ticket)
+}
+
+// CheckIn godoc
+// @Summary      Check in a participant (host only)
+// @Description  Scan a participant's QR token, or pass userId for manual check-in
+// @Tags         activities
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id    path int true "Activity ID"
+// @Param        input body service.CheckInInput true "QR token or user ID"
+// @Success      200  {object}  response.Response
+// @Failure      400  {object}  response.Response
+// @Failure      403  {object}  response.Response
+// @Failure      409  {object}  response.Response
+// @Router       /activities/{id}/check-in [post]
+func (h *ActivityHandler) CheckIn(c *gin.Context) {
+	hostID := middleware.GetCurrentUserID(c)
+	activityID, err := parseIDParam(c, "id")
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "invalid activity ID")
+		return
+	}
+
+	var input service.CheckInInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		response.Error(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	participant, err := h.activityService.CheckIn(activityID, hostID, input)
+	if err != nil {
+		HandleServiceError(c, err)
+		return
+	}
+
+	response.Success(c, participant)
+}
+
 // --- Host Management ---
 
 // ListApplicants godoc
 // @Summary      List applicants (host only)
 // @Tags         activities
 // @Security     BearerAuth
-// @Param        id path int true "Activity ID"
+// @Param        id   path  int    true  "Activity ID"
+// @Param        role query string false "Filter by applied role"
+// @Param        status query string false "Filter by status (pending, invited, accepted, rejected, declined, no_show)"
+// @Param        followsHost query bool false "Only applicants who do (true) or don't (false) follow the host"
+// @Param        minRating query number false "Only applicants with at least this average rating"
+// @Param        sort query string false "applied (default), applied_asc, rating or followers"
 // @Success      200  {object}  response.Response
+// @Failure      400  {object}  response.Response
 // @Failure      403  {object}  response.Response
 // @Router       /activities/{id}/applicants [get]
 func (h *ActivityHandler) ListApplicants(c *gin.Context) {
@@ -352,9 +935,31 @@ func (h *ActivityHandler) ListApplicants(c *gin.Context) {
 		return
 	}
 
-	applicants, err := h.activityService.ListApplicants(activityID, userID)
+	filter := repository.ApplicantFilter{
+		Role:   c.Query("role"),
+		Status: c.Query("status"),
+		Sort:   c.Query("sort"),
+	}
+	if v := c.Query("followsHost"); v != "" {
+		follows, err := strconv.ParseBool(v)
+		if err != nil {
+			response.Error(c, http.StatusBadRequest, "invalid followsHost")
+			return
+		}
+		filter.FollowsHost = &follows
+	}
+	if v := c.Query("minRating"); v != "" {
+		minRating, err := strconv.ParseFloat(v, 64)
+		if err != nil {
+			response.Error(c, http.StatusBadRequest, "invalid minRating")
+			return
+		}
+		filter.MinRating = minRating
+	}
+
+	applicants, err := h.activityService.ListApplicants(activityID, userID, filter)
 	if err != nil {
-		response.Error(c, http.StatusForbidden, err.Error())
+		HandleServiceError(c, err)
 		return
 	}
 
@@ -373,6 +978,7 @@ func (h *ActivityHandler) ListApplicants(c *gin.Context) {
 // @Success      200  {object}  response.Response
 // @Failure      400  {object}  response.Response
 // @Failure      403  {object}  response.Response
+// @Failure      409  {object}  response.Response
 // @Router       /activities/{id}/applicants/{userId}/status [put]
 func (h *ActivityHandler) UpdateApplicantStatus(c *gin.Context) {
 	hostID := middleware.GetCurrentUserID(c)
@@ -395,13 +1001,51 @@ func (h *ActivityHandler) UpdateApplicantStatus(c *gin.Context) {
 	}
 
 	if err := h.activityService.UpdateApplicantStatus(activityID, hostID, applicantUserID, input.Status); err != nil {
-		response.Error(c, http.StatusForbidden, err.Error())
+		HandleServiceError(c, err)
 		return
 	}
 
 	response.Success(c, "status updated")
 }
 
+// BatchUpdateApplicantStatus godoc
+// @Summary      Accept or reject several applicants at once (host only)
+// @Description  All-or-nothing: fails if any user has no reviewable application or acceptances exceed the remaining capacity.
+// @Tags         activities
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id     path int true "Activity ID"
+// @Param        input  body service.BatchUpdateApplicantsInput true "Applicants, status and optional message"
+// @Success      200  {object}  response.Response{data=service.BatchUpdateResult}
+// @Failure      400  {object}  response.Response
+// @Failure      403  {object}  response.Response
+// @Failure      404  {object}  response.Response
+// @Failure      409  {object}  response.Response
+// @Router       /activities/{id}/applicants/status [put]
+func (h *ActivityHandler) BatchUpdateApplicantStatus(c *gin.Context) {
+	hostID := middleware.GetCurrentUserID(c)
+	activityID, err := parseIDParam(c, "id")
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "invalid activity ID")
+		return
+	}
+
+	var input service.BatchUpdateApplicantsInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		response.Error(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	result, err := h.activityService.BatchUpdateApplicantStatus(activityID, hostID, input)
+	if err != nil {
+		HandleServiceError(c, err)
+		return
+	}
+
+	response.Success(c, result)
+}
+
 // --- Activity Comments ---
 
 // GetActivityComments godoc
@@ -409,6 +1053,7 @@ func (h *ActivityHandler) UpdateApplicantStatus(c *gin.Context) {
 // @Tags         activities
 // @Param        id path int true "Activity ID"
 // @Success      200  {object}  response.Response
+// @Failure      404  {object}  response.Response
 // @Router       /activities/{id}/comments [get]
 func (h *ActivityHandler) GetActivityComments(c *gin.Context) {
 	activityID, err := parseIDParam(c, "id")
@@ -417,9 +1062,10 @@ func (h *ActivityHandler) GetActivityComments(c *gin.Context) {
 		return
 	}
 
-	comments, err := h.commentService.GetByActivityID(activityID)
+	viewerID := middleware.GetCurrentUserID(c)
+	comments, err := h.commentService.GetByActivityID(activityID, viewerID)
 	if err != nil {
-		response.Error(c, http.StatusInternalServerError, err.Error())
+		HandleServiceError(c, err)
 		return
 	}
 
@@ -452,7 +1098,7 @@ func (h *ActivityHandler) PostActivityComment(c *gin.Context) {
 
 	comment, err := h.commentService.CreateForActivity(activityID, userID, input.Content)
 	if err != nil {
-		response.Error(c, http.StatusInternalServerError, err.Error())
+		HandleServiceError(c, err)
 		return
 	}
 
@@ -483,6 +1129,44 @@ func (h *ActivityHandler) ListParticipants(c *gin.Context) {
 	response.Success(c, participants)
 }
 
+// --- Works ---
+
+// GetActivityWorks godoc
+// @Summary      List works from an activity
+// @Description  Public works participants linked to the activity, newest first. Pass nextBefore from the previous page as before to load more.
+// @Tags         activities
+// @Produce      json
+// @Param        id     path  int true  "Activity ID"
+// @Param        before query int false "Cursor: load works older than this work ID"
+// @Param        limit  query int false "Page size (default 20, max 50)"
+// @Success      200  {object}  response.Response{data=service.ActivityWorksPage}
+// @Failure      404  {object}  response.Response
+// @Router       /activities/{id}/works [get]
+func (h *ActivityHandler) GetActivityWorks(c *gin.Context) {
+	activityID, err := parseIDParam(c, "id")
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "invalid activity ID")
+		return
+	}
+
+	before, _ := strconv.Atoi(c.DefaultQuery("before", "0"))
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "0"))
+	if before < 0 {
+		before = 0
+	}
+
+	page, err := h.activityService.ListWorks(activityID, middleware.GetCurrentUserID(c), service.ActivityWorksQuery{
+		BeforeID: uint(before),
+		Limit:    limit,
+	})
+	if err != nil {
+		HandleServiceError(c, err)
+		return
+	}
+
+	response.Success(c, page)
+}
+
 // SubmitRating godoc
 // @Summary      Rate a participant
 // @Tags         activities
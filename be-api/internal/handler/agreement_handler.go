@@ -0,0 +1,198 @@
+package handler
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"azure-magnetar/internal/middleware"
+	"azure-magnetar/internal/service"
+	"azure-magnetar/pkg/response"
+
+	"github.com/gin-gonic/gin"
+)
+
+// AgreementHandler handles activity collaboration agreement requests.
+type AgreementHandler struct {
+	agreementService service.AgreementService
+}
+
+// NewAgreementHandler creates a new AgreementHandler.
+func NewAgreementHandler(agreementService service.AgreementService) *AgreementHandler {
+	return &AgreementHandler{agreementService: agreementService}
+}
+
+// ListTemplates godoc
+// @Summary      List agreement templates
+// @Description  Built-in texts for TFP, paid, commercial and portfolio-only agreements
+// @Tags         agreements
+// @Produce      json
+// @Success      200  {object}  response.Response
+// @Router       /agreements/templates [get]
+func (h *AgreementHandler) ListTemplates(c *gin.Context) {
+	response.Success(c, h.agreementService.ListTemplates())
+}
+
+// SetAgreement godoc
+// @Summary      Attach an agreement to an activity (host)
+// @Description  Title and body default to the type's template. The text is locked once someone signs.
+// @Tags         agreements
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id    path int true "Activity ID"
+// @Param        input body service.SetAgreementInput true "Agreement"
+// @Success      200  {object}  response.Response
+// @Failure      400  {object}  response.Response
+// @Failure      403  {object}  response.Response
+// @Failure      409  {object}  response.Response
+// @Router       /activities/{id}/agreement [put]
+func (h *AgreementHandler) SetAgreement(c *gin.Context) {
+	userID := middleware.GetCurrentUserID(c)
+	activityID, err := parseIDParam(c, "id")
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "invalid activity ID")
+		return
+	}
+
+	var input service.SetAgreementInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		response.Error(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	agreement, err := h.agreementService.SetAgreement(activityID, userID, input)
+	if err != nil {
+		HandleServiceError(c, err)
+		return
+	}
+
+	response.Success(c, agreement)
+}
+
+// GetAgreement godoc
+// @Summary      Get an activity's agreement
+// @Description  Includes the viewer's own signature when signed
+// @Tags         agreements
+// @Produce      json
+// @Param        id   path int true "Activity ID"
+// @Success      200  {object}  response.Response
+// @Failure      404  {object}  response.Response
+// @Router       /activities/{id}/agreement [get]
+func (h *AgreementHandler) GetAgreement(c *gin.Context) {
+	viewerID := middleware.GetCurrentUserID(c)
+	activityID, err := parseIDParam(c, "id")
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "invalid activity ID")
+		return
+	}
+
+	view, err := h.agreementService.GetAgreement(activityID, viewerID)
+	if err != nil {
+		HandleServiceError(c, err)
+		return
+	}
+
+	response.Success(c, view)
+}
+
+// SignAgreement godoc
+// @Summary      Sign an activity's agreement (accepted participants)
+// @Description  Records the time, IP address and a SHA-256 hash of the signed text
+// @Tags         agreements
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id    path int true "Activity ID"
+// @Param        input body service.SignAgreementInput true "Hash of the text being signed"
+// @Success      200  {object}  response.Response
+// @Failure      400  {object}  response.Response
+// @Failure      403  {object}  response.Response
+// @Failure      409  {object}  response.Response
+// @Router       /activities/{id}/agreement/sign [post]
+func (h *AgreementHandler) SignAgreement(c *gin.Context) {
+	userID := middleware.GetCurrentUserID(c)
+	activityID, err := parseIDParam(c, "id")
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "invalid activity ID")
+		return
+	}
+
+	var input service.SignAgreementInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		response.Error(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	signature, err := h.agreementService.Sign(activityID, userID, input, c.ClientIP())
+	if err != nil {
+		HandleServiceError(c, err)
+		return
+	}
+
+	response.Success(c, signature)
+}
+
+// ListSignatures godoc
+// @Summary      See who has signed (host)
+// @Tags         agreements
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id   path int true "Activity ID"
+// @Success      200  {object}  response.Response
+// @Failure      403  {object}  response.Response
+// @Failure      404  {object}  response.Response
+// @Router       /activities/{id}/agreement/signatures [get]
+func (h *AgreementHandler) ListSignatures(c *gin.Context) {
+	hostID := middleware.GetCurrentUserID(c)
+	activityID, err := parseIDParam(c, "id")
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "invalid activity ID")
+		return
+	}
+
+	statuses, err := h.agreementService.ListSignatureStatus(activityID, hostID)
+	if err != nil {
+		HandleServiceError(c, err)
+		return
+	}
+
+	response.Success(c, statuses)
+}
+
+// GetAgreementPDF godoc
+// @Summary      Download a signed agreement as PDF
+// @Description  Signers get their own copy; the host can pass userId for any signer
+// @Tags         agreements
+// @Produce      application/pdf
+// @Security     BearerAuth
+// @Param        id     path  int true  "Activity ID"
+// @Param        userId query int false "Signer (host only; defaults to the caller)"
+// @Success      200  {string}  string  "PDF document"
+// @Failure      403  {object}  response.Response
+// @Failure      404  {object}  response.Response
+// @Router       /activities/{id}/agreement/pdf [get]
+func (h *AgreementHandler) GetAgreementPDF(c *gin.Context) {
+	viewerID := middleware.GetCurrentUserID(c)
+	activityID, err := parseIDParam(c, "id")
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "invalid activity ID")
+		return
+	}
+	signerID, _ := strconv.Atoi(c.DefaultQuery("userId", "0"))
+	if signerID < 0 {
+		signerID = 0
+	}
+
+	body, err := h.agreementService.RenderPDF(activityID, viewerID, uint(signerID))
+	if err != nil {
+		HandleServiceError(c, err)
+		return
+	}
+
+	if signerID == 0 {
+		signerID = int(viewerID)
+	}
+	c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="agreement-%d-%d.pdf"`, activityID, signerID))
+	c.Data(http.StatusOK, "application/pdf", body)
+}
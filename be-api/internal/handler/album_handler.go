@@ -0,0 +1,227 @@
+package handler
+
+import (
+	"net/http"
+
+	"azure-magnetar/internal/middleware"
+	"azure-magnetar/internal/service"
+	"azure-magnetar/pkg/response"
+
+	"github.com/gin-gonic/gin"
+)
+
+// AlbumHandler handles portfolio album requests.
+type AlbumHandler struct {
+	albumService service.AlbumService
+}
+
+// NewAlbumHandler creates a new AlbumHandler.
+func NewAlbumHandler(albumService service.AlbumService) *AlbumHandler {
+	return &AlbumHandler{albumService: albumService}
+}
+
+// ListUserAlbums godoc
+// @Summary      List a user's albums
+// @Description  Albums in profile order, each with its works in album order
+// @Tags         albums
+// @Produce      json
+// @Param        id path int true "User ID"
+// @Success      200  {object}  response.Response
+// @Failure      400  {object}  response.Response
+// @Router       /users/{id}/albums [get]
+func (h *AlbumHandler) ListUserAlbums(c *gin.Context) {
+	userID, err := parseIDParam(c, "id")
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "invalid user ID")
+		return
+	}
+
+	albums, err := h.albumService.ListByUser(userID, middleware.GetCurrentUserID(c))
+	if err != nil {
+		HandleServiceError(c, err)
+		return
+	}
+
+	response.Success(c, albums)
+}
+
+// GetAlbum godoc
+// @Summary      Get an album
+// @Tags         albums
+// @Produce      json
+// @Param        id path int true "Album ID"
+// @Success      200  {object}  response.Response
+// @Failure      404  {object}  response.Response
+// @Router       /albums/{id} [get]
+func (h *AlbumHandler) GetAlbum(c *gin.Context) {
+	albumID, err := parseIDParam(c, "id")
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "invalid album ID")
+		return
+	}
+
+	album, err := h.albumService.Get(albumID, middleware.GetCurrentUserID(c))
+	if err != nil {
+		HandleServiceError(c, err)
+		return
+	}
+
+	response.Success(c, album)
+}
+
+// CreateAlbum godoc
+// @Summary      Create an album
+// @Tags         albums
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        input body service.CreateAlbumInput true "Album"
+// @Success      200  {object}  response.Response
+// @Failure      400  {object}  response.Response
+// @Failure      404  {object}  response.Response
+// @Router       /albums [post]
+func (h *AlbumHandler) CreateAlbum(c *gin.Context) {
+	userID := middleware.GetCurrentUserID(c)
+
+	var input service.CreateAlbumInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		response.Error(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	album, err := h.albumService.Create(userID, input)
+	if err != nil {
+		HandleServiceError(c, err)
+		return
+	}
+
+	response.Success(c, album)
+}
+
+// UpdateAlbum godoc
+// @Summary      Rename an album or change its cover (owner only)
+// @Tags         albums
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id    path int true "Album ID"
+// @Param        input body service.UpdateAlbumInput true "Album"
+// @Success      200  {object}  response.Response
+// @Failure      400  {object}  response.Response
+// @Failure      403  {object}  response.Response
+// @Failure      404  {object}  response.Response
+// @Router       /albums/{id} [put]
+func (h *AlbumHandler) UpdateAlbum(c *gin.Context) {
+	userID := middleware.GetCurrentUserID(c)
+	albumID, err := parseIDParam(c, "id")
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "invalid album ID")
+		return
+	}
+
+	var input service.UpdateAlbumInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		response.Error(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	album, err := h.albumService.Update(userID, albumID, input)
+	if err != nil {
+		HandleServiceError(c, err)
+		return
+	}
+
+	response.Success(c, album)
+}
+
+// DeleteAlbum godoc
+// @Summary      Delete an album (owner only)
+// @Description  The works themselves are kept
+// @Tags         albums
+// @Security     BearerAuth
+// @Param        id path int true "Album ID"
+// @Success      200  {object}  response.Response
+// @Failure      403  {object}  response.Response
+// @Failure      404  {object}  response.Response
+// @Router       /albums/{id} [delete]
+func (h *AlbumHandler) DeleteAlbum(c *gin.Context) {
+	userID := middleware.GetCurrentUserID(c)
+	albumID, err := parseIDParam(c, "id")
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "invalid album ID")
+		return
+	}
+
+	if err := h.albumService.Delete(userID, albumID); err != nil {
+		HandleServiceError(c, err)
+		return
+	}
+
+	response.Success(c, "album deleted")
+}
+
+// SetAlbumWorks godoc
+// @Summary      Set an album's works (owner only)
+// @Description  Replaces the album's works with the given list, in order
+// @Tags         albums
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id    path int true "Album ID"
+// @Param        input body service.AlbumWorksInput true "Ordered work IDs"
+// @Success      200  {object}  response.Response
+// @Failure      400  {object}  response.Response
+// @Failure      403  {object}  response.Response
+// @Failure      404  {object}  response.Response
+// @Router       /albums/{id}/works [put]
+func (h *AlbumHandler) SetAlbumWorks(c *gin.Context) {
+	userID := middleware.GetCurrentUserID(c)
+	albumID, err := parseIDParam(c, "id")
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "invalid album ID")
+		return
+	}
+
+	var input service.AlbumWorksInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		response.Error(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	album, err := h.albumService.SetWorks(userID, albumID, input)
+	if err != nil {
+		HandleServiceError(c, err)
+		return
+	}
+
+	response.Success(c, album)
+}
+
+// ReorderMyAlbums godoc
+// @Summary      Reorder my albums
+// @Tags         albums
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        input body service.ReorderAlbumsInput true "Ordered album IDs"
+// @Success      200  {object}  response.Response
+// @Failure      400  {object}  response.Response
+// @Failure      404  {object}  response.Response
+// @Router       /users/me/albums/order [put]
+func (h *AlbumHandler) ReorderMyAlbums(c *gin.Context) {
+	userID := middleware.GetCurrentUserID(c)
+
+	var input service.ReorderAlbumsInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		response.Error(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	albums, err := h.albumService.Reorder(userID, input)
+	if err != nil {
+		HandleServiceError(c, err)
+		return
+	}
+
+	response.Success(c, albums)
+}
@@ -0,0 +1,273 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"azure-magnetar/internal/middleware"
+	"azure-magnetar/internal/model"
+	"azure-magnetar/internal/service"
+	"azure-magnetar/pkg/response"
+
+	"github.com/gin-gonic/gin"
+)
+
+// BookmarkHandler handles private bookmark requests.
+type BookmarkHandler struct {
+	bookmarkService service.BookmarkService
+}
+
+// NewBookmarkHandler creates a new BookmarkHandler.
+func NewBookmarkHandler(bookmarkService service.BookmarkService) *BookmarkHandler {
+	return &BookmarkHandler{bookmarkService: bookmarkService}
+}
+
+// --- Saving ---
+
+// SaveWork godoc
+// @Summary      Save a work
+// @Description  Saving an already saved work moves it to the given board
+// @Tags         bookmarks
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id    path int true "Work ID"
+// @Param        input body service.SaveBookmarkInput false "Optional board"
+// @Success      200  {object}  response.Response
+// @Failure      404  {object}  response.Response
+// @Router       /works/{id}/save [post]
+func (h *BookmarkHandler) SaveWork(c *gin.Context) {
+	h.save(c, model.BookmarkWork, "invalid work ID")
+}
+
+// UnsaveWork godoc
+// @Summary      Unsave a work
+// @Tags         bookmarks
+// @Security     BearerAuth
+// @Param        id path int true "Work ID"
+// @Success      200  {object}  response.Response
+// @Failure      404  {object}  response.Response
+// @Router       /works/{id}/save [delete]
+func (h *BookmarkHandler) UnsaveWork(c *gin.Context) {
+	h.unsave(c, model.BookmarkWork, "invalid work ID")
+}
+
+// SaveActivity godoc
+// @Summary      Save an activity
+// @Description  Saving an already saved activity moves it to the given board
+// @Tags         bookmarks
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id    path int true "Activity ID"
+// @Param        input body service.SaveBookmarkInput false "Optional board"
+// @Success      200  {object}  response.Response
+// @Failure      404  {object}  response.Response
+// @Router       /activities/{id}/save [post]
+func (h *BookmarkHandler) SaveActivity(c *gin.Context) {
+	h.save(c, model.BookmarkActivity, "invalid activity ID")
+}
+
+// UnsaveActivity godoc
+// @Summary      Unsave an activity
+// @Tags         bookmarks
+// @Security     BearerAuth
+// @Param        id path int true "Activity ID"
+// @Success      200  {object}  response.Response
+// @Failure      404  {object}  response.Response
+// @Router       /activities/{id}/save [delete]
+func (h *BookmarkHandler) UnsaveActivity(c *gin.Context) {
+	h.unsave(c, model.BookmarkActivity, "invalid activity ID")
+}
+
+func (h *BookmarkHandler) save(c *gin.Context, targetType, invalidIDMessage string) {
+	userID := middleware.GetCurrentUserID(c)
+	targetID, err := parseIDParam(c, "id")
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, invalidIDMessage)
+		return
+	}
+
+	var input service.SaveBookmarkInput
+	_ = c.ShouldBindJSON(&input) // boardId is optional
+
+	bookmark, err := h.bookmarkService.Save(userID, targetType, targetID, input)
+	if err != nil {
+		HandleServiceError(c, err)
+		return
+	}
+
+	response.Success(c, bookmark)
+}
+
+func (h *BookmarkHandler) unsave(c *gin.Context, targetType, invalidIDMessage string) {
+	userID := middleware.GetCurrentUserID(c)
+	targetID, err := parseIDParam(c, "id")
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, invalidIDMessage)
+		return
+	}
+
+	if err := h.bookmarkService.Unsave(userID, targetType, targetID); err != nil {
+		HandleServiceError(c, err)
+		return
+	}
+
+	response.Success(c, "unsaved")
+}
+
+// ListMyBookmarks godoc
+// @Summary      List my saved items
+// @Description  Newest first. Pass nextBefore from the previous page as before to load more.
+// @Tags         bookmarks
+// @Produce      json
+// @Security     BearerAuth
+// @Param        type    query string false "work or activity"
+// @Param        boardId query int    false "Only items on this board"
+// @Param        before  query int    false "Cursor: load items older than this bookmark ID"
+// @Param        limit   query int    false "Page size (default 20, max 50)"
+// @Success      200  {object}  response.Response{data=service.BookmarkPage}
+// @Failure      400  {object}  response.Response
+// @Router       /users/me/bookmarks [get]
+func (h *BookmarkHandler) ListMyBookmarks(c *gin.Context) {
+	userID := middleware.GetCurrentUserID(c)
+
+	before, _ := strconv.Atoi(c.DefaultQuery("before", "0"))
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "0"))
+	if before < 0 {
+		before = 0
+	}
+	query := service.BookmarkQuery{
+		Type:     c.Query("type"),
+		BeforeID: uint(before),
+		Limit:    limit,
+	}
+	if v := c.Query("boardId"); v != "" {
+		boardID, err := strconv.ParseUint(v, 10, 64)
+		if err != nil {
+			response.Error(c, http.StatusBadRequest, "invalid board ID")
+			return
+		}
+		id := uint(boardID)
+		query.BoardID = &id
+	}
+
+	page, err := h.bookmarkService.List(userID, query)
+	if err != nil {
+		HandleServiceError(c, err)
+		return
+	}
+
+	response.Success(c, page)
+}
+
+// --- Boards ---
+
+// ListMyBoards godoc
+// @Summary      List my bookmark boards
+// @Tags         bookmarks
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200  {object}  response.Response
+// @Router       /users/me/bookmark-boards [get]
+func (h *BookmarkHandler) ListMyBoards(c *gin.Context) {
+	userID := middleware.GetCurrentUserID(c)
+
+	boards, err := h.bookmarkService.ListBoards(userID)
+	if err != nil {
+		HandleServiceError(c, err)
+		return
+	}
+
+	response.Success(c, boards)
+}
+
+// CreateBoard godoc
+// @Summary      Create a bookmark board
+// @Tags         bookmarks
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        input body service.BookmarkBoardInput true "Board"
+// @Success      200  {object}  response.Response
+// @Failure      400  {object}  response.Response
+// @Failure      409  {object}  response.Response
+// @Router       /users/me/bookmark-boards [post]
+func (h *BookmarkHandler) CreateBoard(c *gin.Context) {
+	userID := middleware.GetCurrentUserID(c)
+
+	var input service.BookmarkBoardInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		response.Error(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	board, err := h.bookmarkService.CreateBoard(userID, input)
+	if err != nil {
+		HandleServiceError(c, err)
+		return
+	}
+
+	response.Success(c, board)
+}
+
+// RenameBoard godoc
+// @Summary      Rename a bookmark board
+// @Tags         bookmarks
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        boardId path int true "Board ID"
+// @Param        input   body service.BookmarkBoardInput true "Board"
+// @Success      200  {object}  response.Response
+// @Failure      400  {object}  response.Response
+// @Failure      404  {object}  response.Response
+// @Failure      409  {object}  response.Response
+// @Router       /users/me/bookmark-boards/{boardId} [put]
+func (h *BookmarkHandler) RenameBoard(c *gin.Context) {
+	userID := middleware.GetCurrentUserID(c)
+	boardID, err := parseIDParam(c, "boardId")
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "invalid board ID")
+		return
+	}
+
+	var input service.BookmarkBoardInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		response.Error(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	board, err := h.bookmarkService.RenameBoard(userID, boardID, input)
+	if err != nil {
+		HandleServiceError(c, err)
+		return
+	}
+
+	response.Success(c, board)
+}
+
+// DeleteBoard godoc
+// @Summary      Delete a bookmark board
+// @Description  Items on the board stay saved
+// @Tags         bookmarks
+// @Security     BearerAuth
+// @Param        boardId path int true "Board ID"
+// @Success      200  {object}  response.Response
+// @Failure      404  {object}  response.Response
+// @Router       /users/me/bookmark-boards/{boardId} [delete]
+func (h *BookmarkHandler) DeleteBoard(c *gin.Context) {
+	userID := middleware.GetCurrentUserID(c)
+	boardID, err := parseIDParam(c, "boardId")
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "invalid board ID")
+		return
+	}
+
+	if err := h.bookmarkService.DeleteBoard(userID, boardID); err != nil {
+		HandleServiceError(c, err)
+		return
+	}
+
+	response.Success(c, "board deleted")
+}
@@ -0,0 +1,106 @@
+package handler
+
+import (
+	"fmt"
+	"net/http"
+
+	"azure-magnetar/internal/middleware"
+	"azure-magnetar/internal/service"
+	"azure-magnetar/pkg/response"
+
+	"github.com/gin-gonic/gin"
+)
+
+const calendarContentType = "text/calendar; charset=utf-8"
+
+// CalendarHandler handles iCalendar export and subscription requests.
+type CalendarHandler struct {
+	calendarService service.CalendarService
+}
+
+// NewCalendarHandler creates a new CalendarHandler.
+func NewCalendarHandler(calendarService service.CalendarService) *CalendarHandler {
+	return &CalendarHandler{calendarService: calendarService}
+}
+
+// GetActivityCalendar godoc
+// @Summary      Export an activity as iCalendar
+// @Tags         activities
+// @Produce      text/calendar
+// @Param        id path int true "Activity ID"
+// @Success      200  {string}  string  "iCalendar document"
+// @Failure      404  {object}  response.Response
+// @Router       /activities/{id}/calendar.ics [get]
+func (h *CalendarHandler) GetActivityCalendar(c *gin.Context) {
+	activityID, err := parseIDParam(c, "id")
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "invalid activity ID")
+		return
+	}
+
+	viewerID := middleware.GetCurrentUserID(c)
+	body, err := h.calendarService.ActivityCalendar(activityID, viewerID)
+	if err != nil {
+		HandleServiceError(c, err)
+		return
+	}
+
+	c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="activity-%d.ics"`, activityID))
+	c.Data(http.StatusOK, calendarContentType, body)
+}
+
+// GetMyCalendarFeed godoc
+// @Summary      Calendar subscription feed
+// @Description  iCalendar feed of activities the token's owner hosts or has been accepted to. Authenticated by the secret token only, so calendar apps can poll it.
+// @Tags         users
+// @Produce      text/calendar
+// @Param        token query string true "Secret calendar token"
+// @Success      200  {string}  string  "iCalendar document"
+// @Failure      404  {object}  response.Response
+// @Router       /users/me/calendar.ics [get]
+func (h *CalendarHandler) GetMyCalendarFeed(c *gin.Context) {
+	body, err := h.calendarService.UserCalendar(c.Query("token"))
+	if err != nil {
+		HandleServiceError(c, err)
+		return
+	}
+
+	c.Data(http.StatusOK, calendarContentType, body)
+}
+
+// GetMyCalendarSubscription godoc
+// @Summary      Get calendar subscription URL
+// @Tags         users
+// @Security     BearerAuth
+// @Success      200  {object}  response.Response{data=service.CalendarSubscription}
+// @Router       /users/me/calendar [get]
+func (h *CalendarHandler) GetMyCalendarSubscription(c *gin.Context) {
+	userID := middleware.GetCurrentUserID(c)
+
+	sub, err := h.calendarService.GetSubscription(userID)
+	if err != nil {
+		HandleServiceError(c, err)
+		return
+	}
+
+	response.Success(c, sub)
+}
+
+// ResetMyCalendarSubscription godoc
+// @Summary      Reset calendar subscription URL
+// @Description  Issues a new secret feed URL; the previous one stops working.
+// @Tags         users
+// @Security     BearerAuth
+// @Success      200  {object}  response.Response{data=service.CalendarSubscription}
+// @Router       /users/me/calendar/reset [post]
+func (h *CalendarHandler) ResetMyCalendarSubscription(c *gin.Context) {
+	userID := middleware.GetCurrentUserID(c)
+
+	sub, err := h.calendarService.ResetSubscription(userID)
+	if err != nil {
+		HandleServiceError(c, err)
+		return
+	}
+
+	response.Success(c, sub)
+}
@@ -0,0 +1,199 @@
+package handler
+
+import (
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"azure-magnetar/internal/middleware"
+	"azure-magnetar/internal/service"
+	"azure-magnetar/pkg/response"
+
+	"github.com/gin-gonic/gin"
+)
+
+// chatHeartbeat is how often an idle chat stream sends a ping and re-checks
+// that the user is still a member.
+const chatHeartbeat = 30 * time.Second
+
+// ChatHandler handles activity group chat requests.
+type ChatHandler struct {
+	chatService service.ChatService
+}
+
+// NewChatHandler creates a new ChatHandler.
+func NewChatHandler(chatService service.ChatService) *ChatHandler {
+	return &ChatHandler{chatService: chatService}
+}
+
+// ListMyChats godoc
+// @Summary      List my activity chats with unread counts
+// @Tags         chat
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200  {object}  response.Response
+// @Router       /users/me/chats [get]
+func (h *ChatHandler) ListMyChats(c *gin.Context) {
+	userID := middleware.GetCurrentUserID(c)
+
+	rooms, err := h.chatService.ListRooms(userID)
+	if err != nil {
+		HandleServiceError(c, err)
+		return
+	}
+
+	response.Success(c, rooms)
+}
+
+// ListMessages godoc
+// @Summary      List chat messages (members only)
+// @Description  Newest first. Pass nextBefore from the previous page as before to load older messages.
+// @Tags         chat
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id     path  int true  "Activity ID"
+// @Param        before query int false "Load messages older than this message ID"
+// @Param        limit  query int false "Page size (default 30, max 100)"
+// @Success      200  {object}  response.Response
+// @Failure      403  {object}  response.Response
+// @Failure      404  {object}  response.Response
+// @Router       /activities/{id}/chat/messages [get]
+func (h *ChatHandler) ListMessages(c *gin.Context) {
+	userID := middleware.GetCurrentUserID(c)
+	activityID, err := parseIDParam(c, "id")
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "invalid activity ID")
+		return
+	}
+	before, _ := strconv.Atoi(c.DefaultQuery("before", "0"))
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "0"))
+	if before < 0 {
+		before = 0
+	}
+
+	page, err := h.chatService.ListMessages(activityID, userID, uint(before), limit)
+	if err != nil {
+		HandleServiceError(c, err)
+		return
+	}
+
+	response.Success(c, page)
+}
+
+// SendMessage godoc
+// @Summary      Send a chat message (members only)
+// @Tags         chat
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id    path int true "Activity ID"
+// @Param        input body service.SendChatMessageInput true "Text and/or base64 images"
+// @Success      200  {object}  response.Response
+// @Failure      400  {object}  response.Response
+// @Failure      403  {object}  response.Response
+// @Router       /activities/{id}/chat/messages [post]
+func (h *ChatHandler) SendMessage(c *gin.Context) {
+	userID := middleware.GetCurrentUserID(c)
+	activityID, err := parseIDParam(c, "id")
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "invalid activity ID")
+		return
+	}
+
+	var input service.SendChatMessageInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		response.Error(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	msg, err := h.chatService.SendMessage(activityID, userID, input)
+	if err != nil {
+		HandleServiceError(c, err)
+		return
+	}
+
+	response.Success(c, msg)
+}
+
+// MarkRead godoc
+// @Summary      Mark a chat read (members only)
+// @Tags         chat
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id    path int true "Activity ID"
+// @Param        input body service.MarkChatReadInput false "Read up to this message (default: newest)"
+// @Success      200  {object}  response.Response
+// @Failure      403  {object}  response.Response
+// @Router       /activities/{id}/chat/read [post]
+func (h *ChatHandler) MarkRead(c *gin.Context) {
+	userID := middleware.GetCurrentUserID(c)
+	activityID, err := parseIDParam(c, "id")
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "invalid activity ID")
+		return
+	}
+
+	var input service.MarkChatReadInput
+	_ = c.ShouldBindJSON(&input) // messageId is optional
+
+	if err := h.chatService.MarkRead(activityID, userID, input.MessageID); err != nil {
+		HandleServiceError(c, err)
+		return
+	}
+
+	response.Success(c, "chat marked as read")
+}
+
+// StreamMessages godoc
+// @Summary      Stream new chat messages (Server-Sent Events, members only)
+// @Description  Emits a "message" event per new message and a "ping" every 30 seconds. The stream ends when the user stops being a member.
+// @Tags         chat
+// @Produce      text/event-stream
+// @Security     BearerAuth
+// @Param        id path int true "Activity ID"
+// @Success      200  {string}  string  "event stream"
+// @Failure      403  {object}  response.Response
+// @Router       /activities/{id}/chat/stream [get]
+func (h *ChatHandler) StreamMessages(c *gin.Context) {
+	userID := middleware.GetCurrentUserID(c)
+	activityID, err := parseIDParam(c, "id")
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "invalid activity ID")
+		return
+	}
+
+	events, cancel, err := h.chatService.Subscribe(activityID, userID)
+	if err != nil {
+		HandleServiceError(c, err)
+		return
+	}
+	defer cancel()
+
+	c.Header("Cache-Control", "no-cache")
+	c.Header("X-Accel-Buffering", "no") // Disable proxy buffering
+
+	heartbeat := time.NewTicker(chatHeartbeat)
+	defer heartbeat.Stop()
+
+	c.Stream(func(w io.Writer) bool {
+		select {
+		case event, ok := <-events:
+			// Re-check so a rejected or cancelled member stops receiving at once
+			if !ok || !h.chatService.IsMember(activityID, userID) {
+				return false
+			}
+			c.SSEvent("message", event)
+			return true
+		case <-heartbeat.C:
+			if !h.chatService.IsMember(activityID, userID) {
+				return false
+			}
+			c.SSEvent("ping", "")
+			return true
+		case <-c.Request.Context().Done():
+			return false
+		}
+	})
+}
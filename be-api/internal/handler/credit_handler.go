@@ -0,0 +1,198 @@
+package handler
+
+import (
+	"net/http"
+
+	"azure-magnetar/internal/middleware"
+	"azure-magnetar/internal/service"
+	"azure-magnetar/pkg/response"
+
+	"github.com/gin-gonic/gin"
+)
+
+// CreditHandler handles collaborator credit requests.
+type CreditHandler struct {
+	creditService service.CreditService
+}
+
+// NewCreditHandler creates a new CreditHandler.
+func NewCreditHandler(creditService service.CreditService) *CreditHandler {
+	return &CreditHandler{creditService: creditService}
+}
+
+// ListWorkCredits godoc
+// @Summary      List a work's credits
+// @Description  Approved credits; the author also sees pending and declined ones
+// @Tags         credits
+// @Produce      json
+// @Param        id path int true "Work ID"
+// @Success      200  {object}  response.Response
+// @Failure      404  {object}  response.Response
+// @Router       /works/{id}/credits [get]
+func (h *CreditHandler) ListWorkCredits(c *gin.Context) {
+	workID, err := parseIDParam(c, "id")
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "invalid work ID")
+		return
+	}
+
+	credits, err := h.creditService.ListForWork(workID, middleware.GetCurrentUserID(c))
+	if err != nil {
+		HandleServiceError(c, err)
+		return
+	}
+
+	response.Success(c, credits)
+}
+
+// AddWorkCredit godoc
+// @Summary      Credit a collaborator (author only)
+// @Description  The tagged user is notified and the credit shows once they approve it
+// @Tags         credits
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id    path int true "Work ID"
+// @Param        input body service.AddCreditInput true "Collaborator, role and optional activity"
+// @Success      200  {object}  response.Response
+// @Failure      400  {object}  response.Response
+// @Failure      403  {object}  response.Response
+// @Failure      404  {object}  response.Response
+// @Failure      409  {object}  response.Response
+// @Router       /works/{id}/credits [post]
+func (h *CreditHandler) AddWorkCredit(c *gin.Context) {
+	userID := middleware.GetCurrentUserID(c)
+	workID, err := parseIDParam(c, "id")
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "invalid work ID")
+		return
+	}
+
+	var input service.AddCreditInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		response.Error(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	credit, err := h.creditService.Add(workID, userID, input)
+	if err != nil {
+		HandleServiceError(c, err)
+		return
+	}
+
+	response.Success(c, credit)
+}
+
+// RemoveWorkCredit godoc
+// @Summary      Remove a credit
+// @Description  The author can remove any credit; collaborators can remove their own
+// @Tags         credits
+// @Security     BearerAuth
+// @Param        id     path int true "Work ID"
+// @Param        userId path int true "Credited user ID"
+// @Success      200  {object}  response.Response
+// @Failure      403  {object}  response.Response
+// @Failure      404  {object}  response.Response
+// @Router       /works/{id}/credits/{userId} [delete]
+func (h *CreditHandler) RemoveWorkCredit(c *gin.Context) {
+	userID := middleware.GetCurrentUserID(c)
+	workID, err := parseIDParam(c, "id")
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "invalid work ID")
+		return
+	}
+	creditedUserID, err := parseIDParam(c, "userId")
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "invalid user ID")
+		return
+	}
+
+	if err := h.creditService.Remove(workID, userID, creditedUserID); err != nil {
+		HandleServiceError(c, err)
+		return
+	}
+
+	response.Success(c, "credit removed")
+}
+
+// ListMyCredits godoc
+// @Summary      List credits I was tagged in
+// @Tags         credits
+// @Produce      json
+// @Security     BearerAuth
+// @Param        status query string false "pending, approved or declined"
+// @Success      200  {object}  response.Response
+// @Failure      400  {object}  response.Response
+// @Router       /users/me/credits [get]
+func (h *CreditHandler) ListMyCredits(c *gin.Context) {
+	userID := middleware.GetCurrentUserID(c)
+
+	credits, err := h.creditService.ListMine(userID, c.Query("status"))
+	if err != nil {
+		HandleServiceError(c, err)
+		return
+	}
+
+	response.Success(c, credits)
+}
+
+// RespondCredit godoc
+// @Summary      Approve or decline a credit
+// @Tags         credits
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        creditId path int true "Credit ID"
+// @Param        input    body service.RespondCreditInput true "approved or declined"
+// @Success      200  {object}  response.Response
+// @Failure      400  {object}  response.Response
+// @Failure      404  {object}  response.Response
+// @Failure      409  {object}  response.Response
+// @Router       /users/me/credits/{creditId} [put]
+func (h *CreditHandler) RespondCredit(c *gin.Context) {
+	userID := middleware.GetCurrentUserID(c)
+	creditID, err := parseIDParam(c, "creditId")
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "invalid credit ID")
+		return
+	}
+
+	var input service.RespondCreditInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		response.Error(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	credit, err := h.creditService.Respond(creditID, userID, input)
+	if err != nil {
+		HandleServiceError(c, err)
+		return
+	}
+
+	response.Success(c, credit)
+}
+
+// GetUserCreditedWorks godoc
+// @Summary      Get works a user is credited on
+// @Description  Other authors' works on which the user has an approved credit
+// @Tags         credits
+// @Produce      json
+// @Param        id path int true "User ID"
+// @Success      200  {object}  response.Response
+// @Failure      400  {object}  response.Response
+// @Router       /users/{id}/credited-works [get]
+func (h *CreditHandler) GetUserCreditedWorks(c *gin.Context) {
+	userID, err := parseIDParam(c, "id")
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "invalid user ID")
+		return
+	}
+
+	works, err := h.creditService.GetCreditedWorks(userID)
+	if err != nil {
+		HandleServiceError(c, err)
+		return
+	}
+
+	response.Success(c, works)
+}
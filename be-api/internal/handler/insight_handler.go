@@ -0,0 +1,49 @@
+package handler
+
+import (
+	"strconv"
+
+	"azure-magnetar/internal/middleware"
+	"azure-magnetar/internal/service"
+	"azure-magnetar/pkg/response"
+
+	"github.com/gin-gonic/gin"
+)
+
+// InsightHandler handles creator insight requests.
+type InsightHandler struct {
+	insightService service.InsightService
+}
+
+// NewInsightHandler creates a new InsightHandler.
+func NewInsightHandler(insightService service.InsightService) *InsightHandler {
+	return &InsightHandler{insightService: insightService}
+}
+
+// GetMyInsights godoc
+// @Summary      Get my insights
+// @Description  Daily profile and work views (signed-in and anonymous viewers apart), impressions, likes, new followers and activity applications, with totals and the top works. Views count once per viewer per day; today's numbers refresh every few minutes.
+// @Tags         users
+// @Produce      json
+// @Security     BearerAuth
+// @Param        days query int false "Days to cover, today included (default 30, max 90)"
+// @Success      200  {object}  response.Response{data=service.Insights}
+// @Failure      400  {object}  response.Response
+// @Router       /users/me/insights [get]
+func (h *InsightHandler) GetMyInsights(c *gin.Context) {
+	days, _ := strconv.Atoi(c.DefaultQuery("days", "0"))
+
+	insights, err := h.insightService.GetInsights(middleware.GetCurrentUserID(c), days)
+	if err != nil {
+		HandleServiceError(c, err)
+		return
+	}
+
+	response.Success(c, insights)
+}
+
+// viewerClient identifies an anonymous viewer for view deduplication. It is
+// hashed before being stored.
+func viewerClient(c *gin.Context) string {
+	return c.ClientIP() + "|" + c.Request.UserAgent()
+}
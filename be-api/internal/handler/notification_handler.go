@@ -1,8 +1,13 @@
 package handler
 
 import (
+	"bytes"
+	"fmt"
+	"html/template"
 	"net/http"
+	"strings"
 
+	"azure-magnetar/config"
 	"azure-magnetar/internal/middleware"
 	"azure-magnetar/internal/service"
 	"azure-magnetar/pkg/response"
@@ -79,3 +84,116 @@ func (h *NotificationHandler) GetUnreadCount(c *gin.Context) {
 
 	response.Success(c, gin.H{"count": count})
 }
+
+// --- Preferences ---
+
+// GetPreferences godoc
+// @Summary      Get notification preferences
+// @Description  Get the current user's notification delivery settings
+// @Tags         notifications
+// @Security     BearerAuth
+// @Success      200  {object}  response.Response
+// @Router       /notifications/preferences [get]
+func (h *NotificationHandler) GetPreferences(c *gin.Context) {
+	userID := middleware.GetCurrentUserID(c)
+
+	pref, err := h.notificationService.GetPreferences(userID)
+	if err != nil {
+		HandleServiceError(c, err)
+		return
+	}
+
+	response.Success(c, pref)
+}
+
+// UpdatePreferences godoc
+// @Summary      Update notification preferences
+// @Description  Update the current user's notification delivery settings
+// @Tags         notifications
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        input body service.UpdateNotificationPreferencesInput true "Preferences"
+// @Success      200  {object}  response.Response
+// @Failure      400  {object}  response.Response
+// @Router       /notifications/preferences [put]
+func (h *NotificationHandler) UpdatePreferences(c *gin.Context) {
+	userID := middleware.GetCurrentUserID(c)
+
+	var input service.UpdateNotificationPreferencesInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		response.Error(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	pref, err := h.notificationService.UpdatePreferences(userID, input)
+	if err != nil {
+		HandleServiceError(c, err)
+		return
+	}
+
+	response.Success(c, pref)
+}
+
+// unsubscribePage asks for confirmation before unsubscribing, so mail scanners
+// and link prefetchers that open the email link do not unsubscribe anyone.
+var unsubscribePage = template.Must(template.New("unsubscribe").Parse(`<!DOCTYPE html>
+<html lang="zh-Hant">
+<head><meta charset="utf-8"><meta name="viewport" content="width=device-width, initial-scale=1"><title>取消訂閱｜拍揪</title></head>
+<body style="font-family:sans-serif;max-width:480px;margin:48px auto;padding:0 16px;color:#333;">
+	<h1 style="font-size:20px;">取消訂閱每週摘要</h1>
+	<p>確定不再收到拍揪的每週摘要信嗎？之後可以在設定中重新開啟。</p>
+	<form method="post" action="{{.Action}}">
+		<input type="hidden" name="token" value="{{.Token}}">
+		<button type="submit" style="padding:8px 20px;">確認取消訂閱</button>
+	</form>
+</body>
+</html>
+`))
+
+// ConfirmUnsubscribe godoc
+// @Summary      Confirm unsubscribing from digest emails
+// @Description  Page opened from the link in digest emails. It only shows a confirmation form that POSTs to the same path.
+// @Tags         notifications
+// @Produce      html
+// @Param        token query string true "Unsubscribe Token"
+// @Success      200  {string}  string  "HTML page"
+// @Failure      400  {object}  response.Response
+// @Router       /notifications/unsubscribe [get]
+func (h *NotificationHandler) ConfirmUnsubscribe(c *gin.Context) {
+	token := c.Query("token")
+	if token == "" {
+		response.Error(c, http.StatusBadRequest, "token is required")
+		return
+	}
+
+	var page bytes.Buffer
+	if err := unsubscribePage.Execute(&page, gin.H{"Action": c.Request.URL.Path, "Token": token}); err != nil {
+		response.Error(c, http.StatusInternalServerError, "failed to render page")
+		return
+	}
+	c.Data(http.StatusOK, "text/html; charset=utf-8", page.Bytes())
+}
+
+// Unsubscribe godoc
+// @Summary      Unsubscribe from digest emails
+// @Description  Submitted by the confirmation page. The token may also be passed in the query.
+// @Tags         notifications
+// @Accept       x-www-form-urlencoded
+// @Param        token formData string true "Unsubscribe Token"
+// @Success      303
+// @Failure      400  {object}  response.Response
+// @Router       /notifications/unsubscribe [post]
+func (h *NotificationHandler) Unsubscribe(c *gin.Context) {
+	token := c.PostForm("token")
+	if token == "" {
+		token = c.Query("token")
+	}
+	if err := h.notificationService.Unsubscribe(token); err != nil {
+		HandleServiceError(c, err)
+		return
+	}
+
+	frontendURL := strings.TrimSuffix(config.LoadConfig().FrontendURL, "/")
+	c.Redirect(http.StatusSeeOther, fmt.Sprintf("%s/settings?unsubscribed=true", frontendURL))
+}
@@ -0,0 +1,171 @@
+package handler
+
+import (
+	"strconv"
+
+	"azure-magnetar/internal/middleware"
+	"azure-magnetar/internal/service"
+	"azure-magnetar/pkg/response"
+
+	"github.com/gin-gonic/gin"
+)
+
+// TagHandler handles tag page, trending and tag follow requests.
+type TagHandler struct {
+	tagService     service.TagService
+	insightService service.InsightService
+}
+
+// NewTagHandler creates a new TagHandler.
+func NewTagHandler(tagService service.TagService, insightService service.InsightService) *TagHandler {
+	return &TagHandler{tagService: tagService, insightService: insightService}
+}
+
+// SearchTags godoc
+// @Summary      Autocomplete tags
+// @Description  Tags starting with q (letters only; a leading # is ignored), most used first
+// @Tags         tags
+// @Produce      json
+// @Param        q     query string true  "Prefix"
+// @Param        limit query int    false "Max suggestions (default 10, max 20)"
+// @Success      200  {object}  response.Response
+// @Failure      400  {object}  response.Response
+// @Router       /tags [get]
+func (h *TagHandler) SearchTags(c *gin.Context) {
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "0"))
+
+	tags, err := h.tagService.Autocomplete(c.Query("q"), limit)
+	if err != nil {
+		HandleServiceError(c, err)
+		return
+	}
+
+	response.Success(c, tags)
+}
+
+// GetTrendingTags godoc
+// @Summary      Trending tags
+// @Description  Tags attached to the most public works within the window
+// @Tags         tags
+// @Produce      json
+// @Param        window query string false "24h, 7d (default) or 30d"
+// @Param        limit  query int    false "Max tags (default 10, max 50)"
+// @Success      200  {object}  response.Response
+// @Failure      400  {object}  response.Response
+// @Router       /tags/trending [get]
+func (h *TagHandler) GetTrendingTags(c *gin.Context) {
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "0"))
+
+	tags, err := h.tagService.Trending(c.Query("window"), limit)
+	if err != nil {
+		HandleServiceError(c, err)
+		return
+	}
+
+	response.Success(c, tags)
+}
+
+// GetTag godoc
+// @Summary      Get a tag
+// @Description  Tag with its public work count, follower count and whether the caller follows it
+// @Tags         tags
+// @Produce      json
+// @Param        name path string true "Tag name"
+// @Success      200  {object}  response.Response
+// @Failure      404  {object}  response.Response
+// @Router       /tags/{name} [get]
+func (h *TagHandler) GetTag(c *gin.Context) {
+	tag, err := h.tagService.Get(c.Param("name"), middleware.GetCurrentUserID(c))
+	if err != nil {
+		HandleServiceError(c, err)
+		return
+	}
+
+	response.Success(c, tag)
+}
+
+// GetTagWorks godoc
+// @Summary      List a tag's works
+// @Description  Public works with the tag, newest first. Pass nextBefore from the previous page as before to load more.
+// @Tags         tags
+// @Produce      json
+// @Param        name   path  string true  "Tag name"
+// @Param        before query int    false "Cursor: load works older than this work ID"
+// @Param        limit  query int    false "Page size (default 20, max 50)"
+// @Success      200  {object}  response.Response{data=service.TagWorksPage}
+// @Failure      404  {object}  response.Response
+// @Router       /tags/{name}/works [get]
+func (h *TagHandler) GetTagWorks(c *gin.Context) {
+	before, _ := strconv.Atoi(c.DefaultQuery("before", "0"))
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "0"))
+	if before < 0 {
+		before = 0
+	}
+
+	viewerID := middleware.GetCurrentUserID(c)
+	page, err := h.tagService.ListWorks(c.Param("name"), viewerID, service.TagWorksQuery{
+		BeforeID: uint(before),
+		Limit:    limit,
+	})
+	if err != nil {
+		HandleServiceError(c, err)
+		return
+	}
+
+	h.insightService.RecordImpressions(page.Items, viewerID, viewerClient(c))
+	response.Success(c, page)
+}
+
+// FollowTag godoc
+// @Summary      Follow a tag
+// @Description  Public works with a followed tag appear in the following feed
+// @Tags         tags
+// @Security     BearerAuth
+// @Param        name path string true "Tag name"
+// @Success      200  {object}  response.Response
+// @Failure      404  {object}  response.Response
+// @Failure      409  {object}  response.Response
+// @Router       /tags/{name}/follow [post]
+func (h *TagHandler) FollowTag(c *gin.Context) {
+	tag, err := h.tagService.Follow(middleware.GetCurrentUserID(c), c.Param("name"))
+	if err != nil {
+		HandleServiceError(c, err)
+		return
+	}
+
+	response.Success(c, tag)
+}
+
+// UnfollowTag godoc
+// @Summary      Unfollow a tag
+// @Tags         tags
+// @Security     BearerAuth
+// @Param        name path string true "Tag name"
+// @Success      200  {object}  response.Response
+// @Failure      404  {object}  response.Response
+// @Router       /tags/{name}/follow [delete]
+func (h *TagHandler) UnfollowTag(c *gin.Context) {
+	if err := h.tagService.Unfollow(middleware.GetCurrentUserID(c), c.Param("name")); err != nil {
+		HandleServiceError(c, err)
+		return
+	}
+
+	response.Success(c, "unfollowed")
+}
+
+// ListMyFollowedTags godoc
+// @Summary      List tags I follow
+// @Tags         tags
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200  {object}  response.Response
+// @Router       /users/me/followed-tags [get]
+func (h *TagHandler) ListMyFollowedTags(c *gin.Context) {
+	tags, err := h.tagService.ListFollowed(middleware.GetCurrentUserID(c))
+	if err != nil {
+		HandleServiceError(c, err)
+		return
+	}
+
+	response.Success(c, tags)
+}
@@ -25,6 +25,7 @@ type UserHandler struct {
 	workService     service.WorkService
 	activityService service.ActivityService
 	ratingService   service.RatingService
+	insightService  service.InsightService
 }
 
 // NewUserHandler creates a new UserHandler with the given user service.
@@ -34,6 +35,7 @@ func NewUserHandler(
 	workService service.WorkService,
 	activityService service.ActivityService,
 	ratingService service.RatingService,
+	insightService service.InsightService,
 ) *UserHandler {
 	return &UserHandler{
 		userService:     userService,
@@ -41,6 +43,7 @@ func NewUserHandler(
 		workService:     workService,
 		activityService: activityService,
 		ratingService:   ratingService,
+		insightService:  insightService,
 	}
 }
 
@@ -215,12 +218,13 @@ func (h *UserHandler) GetUser(c *gin.Context) {
 		return
 	}
 
+	h.insightService.RecordProfileView(id, middleware.GetCurrentUserID(c), viewerClient(c))
 	response.Success(c, user)
 }
 
 // GetUserWorks godoc
 // @Summary      Get user's works
-// @Description  Get portfolio/works by user ID
+// @Description  Get portfolio/works by user ID. Followers-only works are included for followers; unlisted and private works only for the owner.
 // @Tags         users
 // @Produce      json
 // @Param        id path int true "User ID"
@@ -234,12 +238,14 @@ func (h *UserHandler) GetUserWorks(c *gin.Context) {
 		return
 	}
 
-	works, err := h.workService.GetByUserID(id)
+	viewerID := middleware.GetCurrentUserID(c)
+	works, err := h.workService.GetByUserID(id, viewerID)
 	if err != nil {
 		response.Error(c, http.StatusInternalServerError, err.Error())
 		return
 	}
 
+	h.insightService.RecordImpressions(works, viewerID, viewerClient(c))
 	response.Success(c, works)
 }
 
@@ -259,7 +265,8 @@ func (h *UserHandler) GetUserActivities(c *gin.Context) {
 		return
 	}
 
-	activities, err := h.activityService.GetByUserID(id)
+	viewerID := middleware.GetCurrentUserID(c)
+	activities, err := h.activityService.GetByUserID(id, viewerID)
 	if err != nil {
 		response.Error(c, http.StatusInternalServerError, err.Error())
 		return
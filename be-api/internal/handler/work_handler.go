@@ -18,6 +18,7 @@ type WorkHandler struct {
 	likeService    service.LikeService
 	commentService service.CommentService
 	ratingService  service.RatingService
+	insightService service.InsightService
 }
 
 // NewWorkHandler creates a new WorkHandler.
@@ -26,12 +27,14 @@ func NewWorkHandler(
 	likeService service.LikeService,
 	commentService service.CommentService,
 	ratingService service.RatingService,
+	insightService service.InsightService,
 ) *WorkHandler {
 	return &WorkHandler{
 		workService:    workService,
 		likeService:    likeService,
 		commentService: commentService,
 		ratingService:  ratingService,
+		insightService: insightService,
 	}
 }
 
@@ -88,6 +91,7 @@ func (h *WorkHandler) GetWall(c *gin.Context) {
 		return
 	}
 
+	h.insightService.RecordImpressions(resp.Data, currentUserID, viewerClient(c))
 	response.Success(c, resp)
 }
 
@@ -113,7 +117,7 @@ func (h *WorkHandler) CreateWork(c *gin.Context) {
 
 	work, err := h.workService.Create(userID, input)
 	if err != nil {
-		response.Error(c, http.StatusInternalServerError, err.Error())
+		HandleServiceError(c, err)
 		return
 	}
 
@@ -148,11 +152,13 @@ func (h *WorkHandler) GetWork(c *gin.Context) {
 		}
 	}
 
+	h.insightService.RecordWorkView(work, userID, viewerClient(c))
 	response.Success(c, work)
 }
 
 // UpdateWork godoc
-// @Summary      Update work description
+// @Summary      Update a work
+// @Description  Edit text, visibility and the activity link, or replace the image list: keep current image URLs, add base64 images and reorder freely (the first is the cover). Removed images are deleted from storage. aspectRatio is required when the cover changes.
 // @Tags         works
 // @Accept       json
 // @Produce      json
@@ -160,6 +166,7 @@ func (h *WorkHandler) GetWork(c *gin.Context) {
 // @Param        id   path int true "Work ID"
 // @Param        input body service.UpdateWorkInput true "Work Data"
 // @Success      200  {object}  response.Response
+// @Failure      400  {object}  response.Response
 // @Router       /works/{id} [put]
 func (h *WorkHandler) UpdateWork(c *gin.Context) {
 	userID := middleware.GetCurrentUserID(c)
@@ -177,7 +184,7 @@ func (h *WorkHandler) UpdateWork(c *gin.Context) {
 
 	work, err := h.workService.Update(userID, workID, input)
 	if err != nil {
-		response.Error(c, http.StatusForbidden, err.Error())
+		HandleServiceError(c, err)
 		return
 	}
 
@@ -207,6 +214,36 @@ func (h *WorkHandler) DeleteWork(c *gin.Context) {
 	response.Success(c, "work deleted")
 }
 
+// SetPinnedWorks godoc
+// @Summary      Pin works to the top of my profile
+// @Description  Replaces the pinned works with the given list, in order (at most 6). An empty list unpins everything.
+// @Tags         works
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        input body service.PinWorksInput true "Ordered work IDs"
+// @Success      200  {object}  response.Response
+// @Failure      400  {object}  response.Response
+// @Failure      404  {object}  response.Response
+// @Router       /users/me/pinned-works [put]
+func (h *WorkHandler) SetPinnedWorks(c *gin.Context) {
+	userID := middleware.GetCurrentUserID(c)
+
+	var input service.PinWorksInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		response.Error(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	works, err := h.workService.SetPinned(userID, input)
+	if err != nil {
+		HandleServiceError(c, err)
+		return
+	}
+
+	response.Success(c, works)
+}
+
 // --- Like ---
 
 // LikeWork godoc
@@ -225,7 +262,7 @@ func (h *WorkHandler) LikeWork(c *gin.Context) {
 	}
 
 	if err := h.likeService.LikeWork(userID, workID); err != nil {
-		response.Error(c, http.StatusBadRequest, err.Error())
+		HandleServiceError(c, err)
 		return
 	}
 
@@ -248,7 +285,7 @@ func (h *WorkHandler) UnlikeWork(c *gin.Context) {
 	}
 
 	if err := h.likeService.UnlikeWork(userID, workID); err != nil {
-		response.Error(c, http.StatusBadRequest, err.Error())
+		HandleServiceError(c, err)
 		return
 	}
 
@@ -270,9 +307,9 @@ func (h *WorkHandler) GetWorkComments(c *gin.Context) {
 		return
 	}
 
-	comments, err := h.commentService.GetByWorkID(workID)
+	comments, err := h.commentService.GetByWorkID(workID, middleware.GetCurrentUserID(c))
 	if err != nil {
-		response.Error(c, http.StatusInternalServerError, err.Error())
+		HandleServiceError(c, err)
 		return
 	}
 
@@ -312,7 +349,7 @@ func (h *WorkHandler) PostWorkComment(c *gin.Context) {
 
 	comment, err := h.commentService.CreateForWork(workID, userID, input.Content)
 	if err != nil {
-		response.Error(c, http.StatusInternalServerError, err.Error())
+		HandleServiceError(c, err)
 		return
 	}
 
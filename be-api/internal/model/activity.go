@@ -1,29 +1,101 @@
 package model
 
-import "time"
+import (
+	"time"
+	_ "time/tzdata" // Embed the zone database so activity timezones resolve on minimal images
+
+	"gorm.io/gorm"
+)
+
+// DefaultTimezone is used for activities created without an explicit timezone.
+const DefaultTimezone = "Asia/Taipei"
 
 // Activity represents an event created by a host user.
 type Activity struct {
 	ID                  uint      `gorm:"primaryKey" json:"id"`
 	HostID              uint      `gorm:"column:host_id;not null;index" json:"hostId"`
+	SeriesID            *uint     `gorm:"column:series_id;index" json:"seriesId,omitempty"` // Set for occurrences of a recurring series
 	Title               string    `gorm:"column:title;size:255;not null" json:"title"`
 	Description         string    `gorm:"column:description;type:text" json:"description"`
 	Location            string    `gorm:"column:location;size:255" json:"location"`
-	EventTime           time.Time `gorm:"column:event_time" json:"eventTime"`
+	EventTime           time.Time `gorm:"column:event_time" json:"eventTime"`                            // Always UTC
+	Timezone            string    `gorm:"column:timezone;size:64;default:'Asia/Taipei'" json:"timezone"` // IANA zone the event time is entered and shown in
+	LocalEventTime      string    `gorm:"-" json:"localEventTime"`                                       // EventTime in Timezone, RFC 3339 with offset
 	MaxParticipants     int       `gorm:"column:max_participants;default:0" json:"maxParticipants"`
 	CurrentParticipants int64     `gorm:"-" json:"currentParticipants"`
-	Status              string    `gorm:"column:status;size:50;default:'open'" json:"status"` // open, full, ended, cancelled
-	Images              []string  `gorm:"serializer:json" json:"images"`                      // JSON array of image URLs
-	Tags                string    `gorm:"column:tags;type:text" json:"tags"`                  // JSON array of tag strings
-	Roles               []string  `gorm:"serializer:json" json:"roles"`                       // JSON array of required roles
+	FreeCancelHours     int       `gorm:"column:free_cancel_hours;default:24" json:"freeCancelHours"`         // Accepted participants cancelling later than this before the start are late cancels
+	Status              string    `gorm:"column:status;size:50;default:'open'" json:"status"`                 // draft, open, full, ended, cancelled
+	Visibility          string    `gorm:"column:visibility;size:20;default:'public';index" json:"visibility"` // public, unlisted, private
+	Images              []string  `gorm:"serializer:json" json:"images"`                                      // JSON array of image URLs
+	Tags                string    `gorm:"column:tags;type:text" json:"tags"`                                  // JSON array of tag strings
+	Roles               []string  `gorm:"serializer:json" json:"roles"`                                       // JSON array of required roles
+	Sequence            int       `gorm:"column:sequence;default:0" json:"sequence"`                          // Bumped on every host edit; the iCalendar SEQUENCE
 	CreatedAt           time.Time `json:"createdAt"`
 	UpdatedAt           time.Time `json:"updatedAt"`
 
+	// Scheduling
+	PublishAt          *time.Time `gorm:"column:publish_at;index" json:"publishAt,omitempty"` // Scheduled publication time for drafts
+	PublishedAt        *time.Time `gorm:"column:published_at" json:"publishedAt,omitempty"`
+	ReminderSentAt     *time.Time `gorm:"column:reminder_sent_at" json:"-"`                                // Set once the pre-event reminder has gone out
+	AttendanceClosedAt *time.Time `gorm:"column:attendance_closed_at" json:"attendanceClosedAt,omitempty"` // Set once absent participants are marked no_show
+
+	// Gallery of works linked to the activity, filled in on detail once it has ended
+	Gallery      []Post `gorm:"-" json:"gallery,omitempty"`
+	GalleryCount int64  `gorm:"-" json:"galleryCount,omitempty"`
+
 	// Relationships
-	Host User `gorm:"foreignKey:HostID" json:"host,omitempty"`
+	Host      User               `gorm:"foreignKey:HostID" json:"host,omitempty"`
+	RoleSlots []ActivityRoleSlot `gorm:"foreignKey:ActivityID" json:"roleSlots"`           // Per-role capacity; empty means MaxParticipants applies
+	Questions []ActivityQuestion `gorm:"foreignKey:ActivityID" json:"questions,omitempty"` // Application form, ordered by Position
+	CoHosts   []ActivityCoHost   `gorm:"foreignKey:ActivityID" json:"coHosts"`             // Users sharing host rights
 }
 
 // TableName overrides the table name.
 func (Activity) TableName() string {
 	return "activities"
 }
+
+// Zone returns the activity's timezone, falling back to DefaultTimezone
+// (or UTC if the zone database is unavailable).
+func (a *Activity) Zone() *time.Location {
+	return loadZone(a.Timezone)
+}
+
+func loadZone(name string) *time.Location {
+	if name != "" {
+		if loc, err := time.LoadLocation(name); err == nil {
+			return loc
+		}
+	}
+	if loc, err := time.LoadLocation(DefaultTimezone); err == nil {
+		return loc
+	}
+	return time.UTC
+}
+
+// LocalTime converts t to the activity's timezone.
+func (a *Activity) LocalTime(t time.Time) time.Time {
+	return t.In(a.Zone())
+}
+
+// Localize normalizes EventTime to UTC and fills LocalEventTime.
+func (a *Activity) Localize() {
+	if a.EventTime.IsZero() {
+		a.LocalEventTime = ""
+		return
+	}
+	a.EventTime = a.EventTime.UTC()
+	a.LocalEventTime = a.LocalTime(a.EventTime).Format(time.RFC3339)
+}
+
+// AfterFind localizes event times loaded from the database.
+func (a *Activity) AfterFind(tx *gorm.DB) error {
+	a.Localize()
+	return nil
+}
+
+// AfterSave keeps the computed local time in sync after create and update.
+func (a *Activity) AfterSave(tx *gorm.DB) error {
+	a.Localize()
+	return nil
+}
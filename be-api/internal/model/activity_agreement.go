@@ -0,0 +1,47 @@
+package model
+
+import "time"
+
+// Agreement types, describing how images from the shoot may be used.
+const (
+	AgreementTFP           = "tfp"            // Time for prints: no payment, both sides may use the images
+	AgreementPaid          = "paid"           // Participants are paid; the host owns usage rights
+	AgreementCommercial    = "commercial"     // Images may be used commercially, e.g. advertising
+	AgreementPortfolioOnly = "portfolio_only" // Images may only be shown in personal portfolios
+)
+
+// ActivityAgreement is the collaboration agreement accepted participants sign
+// for an activity. Its text is fixed once anyone has signed.
+type ActivityAgreement struct {
+	ID         uint      `gorm:"primaryKey" json:"id"`
+	ActivityID uint      `gorm:"column:activity_id;not null;uniqueIndex" json:"activityId"`
+	Type       string    `gorm:"column:type;size:30;not null" json:"type"` // tfp, paid, commercial, portfolio_only
+	Title      string    `gorm:"column:title;size:255;not null" json:"title"`
+	Body       string    `gorm:"column:body;type:text;not null" json:"body"`
+	TextHash   string    `gorm:"column:text_hash;size:64;not null" json:"textHash"` // SHA-256 of the text participants sign
+	CreatedAt  time.Time `json:"createdAt"`
+	UpdatedAt  time.Time `json:"updatedAt"`
+}
+
+// TableName overrides the table name.
+func (ActivityAgreement) TableName() string {
+	return "activity_agreements"
+}
+
+// AgreementSignature records a participant's electronic signature.
+type AgreementSignature struct {
+	ID          uint      `gorm:"primaryKey" json:"id"`
+	AgreementID uint      `gorm:"column:agreement_id;not null;uniqueIndex:idx_agreement_signer" json:"agreementId"`
+	ActivityID  uint      `gorm:"column:activity_id;not null;index" json:"activityId"`
+	UserID      uint      `gorm:"column:user_id;not null;uniqueIndex:idx_agreement_signer" json:"userId"`
+	SignerName  string    `gorm:"column:signer_name;size:255" json:"signerName"` // Display name at signing time
+	SignedText  string    `gorm:"column:signed_text;type:text;not null" json:"-"`
+	TextHash    string    `gorm:"column:text_hash;size:64;not null" json:"textHash"` // SHA-256 of SignedText
+	IP          string    `gorm:"column:ip;size:45" json:"-"`                        // Shown only in the signed PDF
+	SignedAt    time.Time `gorm:"column:signed_at;not null" json:"signedAt"`
+}
+
+// TableName overrides the table name.
+func (AgreementSignature) TableName() string {
+	return "agreement_signatures"
+}
@@ -0,0 +1,23 @@
+package model
+
+import "time"
+
+// ActivityAnnouncement is a message the host side broadcast to an activity's
+// participants, e.g. a venue or call-time change.
+type ActivityAnnouncement struct {
+	ID             uint      `gorm:"primaryKey" json:"id"`
+	ActivityID     uint      `gorm:"column:activity_id;not null;index" json:"activityId"`
+	AuthorID       uint      `gorm:"column:author_id;not null" json:"authorId"`
+	Message        string    `gorm:"column:message;type:text;not null" json:"message"`
+	IncludePending bool      `gorm:"column:include_pending;default:false" json:"includePending"` // Also sent to pending applicants
+	RecipientCount int       `gorm:"column:recipient_count;default:0" json:"recipientCount"`
+	CreatedAt      time.Time `json:"createdAt"`
+
+	// Relationships
+	Author User `gorm:"foreignKey:AuthorID" json:"author,omitempty"`
+}
+
+// TableName overrides the table name.
+func (ActivityAnnouncement) TableName() string {
+	return "activity_announcements"
+}
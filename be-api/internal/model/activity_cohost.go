@@ -0,0 +1,27 @@
+package model
+
+import "time"
+
+// Co-host permission levels.
+const (
+	CoHostManageApplicants = "manage_applicants" // Review applicants, invite, check in
+	CoHostEditDetails      = "edit_details"      // Edit the activity's details
+	CoHostFull             = "full"              // Everything the host can do except managing co-hosts
+)
+
+// ActivityCoHost grants another user host rights on an activity.
+type ActivityCoHost struct {
+	ID         uint      `gorm:"primaryKey" json:"id"`
+	ActivityID uint      `gorm:"column:activity_id;not null;uniqueIndex:idx_activity_cohost" json:"activityId"`
+	UserID     uint      `gorm:"column:user_id;not null;uniqueIndex:idx_activity_cohost;index" json:"userId"`
+	Permission string    `gorm:"column:permission;size:30;not null" json:"permission"` // manage_applicants, edit_details, full
+	CreatedAt  time.Time `json:"createdAt"`
+
+	// Relationships
+	User User `gorm:"foreignKey:UserID" json:"user,omitempty"`
+}
+
+// TableName overrides the table name.
+func (ActivityCoHost) TableName() string {
+	return "activity_cohosts"
+}
@@ -0,0 +1,26 @@
+package model
+
+import "time"
+
+// ActivityInviteLink is a shareable, signed link that lets its holder apply to
+// (or directly join) an activity, including private ones.
+type ActivityInviteLink struct {
+	ID         uint       `gorm:"primaryKey" json:"id"`
+	ActivityID uint       `gorm:"column:activity_id;not null;index" json:"activityId"`
+	Nonce      string     `gorm:"column:nonce;size:64;not null;uniqueIndex" json:"-"`
+	Mode       string     `gorm:"column:mode;size:20;not null;default:'apply'" json:"mode"` // apply, auto_accept
+	MaxUses    int        `gorm:"column:max_uses;default:0" json:"maxUses"`                 // 0 = unlimited
+	Uses       int        `gorm:"column:uses;default:0" json:"uses"`
+	ExpiresAt  *time.Time `gorm:"column:expires_at" json:"expiresAt"`
+	RevokedAt  *time.Time `gorm:"column:revoked_at" json:"revokedAt,omitempty"`
+	CreatedAt  time.Time  `json:"createdAt"`
+
+	// Computed fields, only returned to the host
+	Token string `gorm:"-" json:"token,omitempty"`
+	URL   string `gorm:"-" json:"url,omitempty"`
+}
+
+// TableName overrides the table name.
+func (ActivityInviteLink) TableName() string {
+	return "activity_invite_links"
+}
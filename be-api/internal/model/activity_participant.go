@@ -4,13 +4,19 @@ import "time"
 
 // ActivityParticipant tracks user applications to activities.
 type ActivityParticipant struct {
-	ID         uint      `gorm:"primaryKey" json:"id"`
-	ActivityID uint      `gorm:"column:activity_id;not null;index" json:"activityId"`
-	UserID     uint      `gorm:"column:user_id;not null;index" json:"userId"`
-	Status     string    `gorm:"column:status;size:50;default:'pending'" json:"status"` // pending, accepted, rejected
-	Message    string    `gorm:"column:message;type:text" json:"message"`
-	AppliedAt  time.Time `gorm:"column:applied_at;autoCreateTime" json:"appliedAt"`
-	UpdatedAt  time.Time `json:"updatedAt"`
+	ID              uint                `gorm:"primaryKey" json:"id"`
+	ActivityID      uint                `gorm:"column:activity_id;not null;index" json:"activityId"`
+	UserID          uint                `gorm:"column:user_id;not null;index" json:"userId"`
+	Status          string              `gorm:"column:status;size:50;default:'pending'" json:"status"` // pending, invited, accepted, rejected, declined, withdrawn, late_cancelled, no_show
+	Role            string              `gorm:"column:role;size:100;index" json:"role"`                // Role slot applied for (optional when the activity has no slots)
+	Message         string              `gorm:"column:message;type:text" json:"message"`
+	Answers         []ApplicationAnswer `gorm:"serializer:json" json:"answers"` // Answers to the activity's application form
+	AppliedAt       time.Time           `gorm:"column:applied_at;autoCreateTime" json:"appliedAt"`
+	InviteExpiresAt *time.Time          `gorm:"column:invite_expires_at" json:"inviteExpiresAt,omitempty"` // Set while Status is "invited"
+	CheckedInAt     *time.Time          `gorm:"column:checked_in_at" json:"checkedInAt,omitempty"`         // Set when the host checks the participant in
+	CancelledAt     *time.Time          `gorm:"column:cancelled_at" json:"cancelledAt,omitempty"`          // Set when the participant withdraws
+	UpdatedAt       time.Time           `json:"updatedAt"`
+	FollowsHost     bool                `gorm:"-" json:"followsHost"` // Whether the applicant follows the host (computed for host views)
 
 	// Relationships
 	Activity Activity `gorm:"foreignKey:ActivityID" json:"activity,omitempty"`
@@ -0,0 +1,26 @@
+package model
+
+// ActivityQuestion is a host-defined question applicants answer when applying.
+type ActivityQuestion struct {
+	ID         uint     `gorm:"primaryKey" json:"id"`
+	ActivityID uint     `gorm:"column:activity_id;not null;index" json:"activityId"`
+	Position   int      `gorm:"column:position;not null;default:0" json:"position"`
+	Type       string   `gorm:"column:type;size:20;not null" json:"type"` // short_text, choice, number, url, yes_no
+	Label      string   `gorm:"column:label;size:255;not null" json:"label"`
+	Options    []string `gorm:"serializer:json" json:"options,omitempty"` // Choices for type "choice"
+	Required   bool     `gorm:"column:required;default:false" json:"required"`
+}
+
+// TableName overrides the table name.
+func (ActivityQuestion) TableName() string {
+	return "activity_questions"
+}
+
+// ApplicationAnswer is an applicant's answer to an ActivityQuestion. The label
+// is snapshotted so answers stay readable if the host edits the form later.
+type ApplicationAnswer struct {
+	QuestionID uint   `json:"questionId"`
+	Label      string `json:"label"`
+	Type       string `json:"type"`
+	Value      string `json:"value"`
+}
@@ -0,0 +1,15 @@
+package model
+
+// ActivityRoleSlot defines how many participants an activity needs for a given role.
+type ActivityRoleSlot struct {
+	ID         uint   `gorm:"primaryKey" json:"id"`
+	ActivityID uint   `gorm:"column:activity_id;not null;index" json:"activityId"`
+	Role       string `gorm:"column:role;size:100;not null" json:"role"` // e.g. model, makeup_artist
+	Count      int    `gorm:"column:count;not null" json:"count"`        // Number of people needed
+	Filled     int64  `gorm:"-" json:"filled"`                           // Accepted participants (computed)
+}
+
+// TableName overrides the table name.
+func (ActivityRoleSlot) TableName() string {
+	return "activity_role_slots"
+}
@@ -0,0 +1,51 @@
+package model
+
+import "time"
+
+// ActivitySeries groups recurring activities. Concrete occurrences are regular
+// Activity rows pointing back to the series via SeriesID.
+//
+// New occurrences are created from the series' own copy of the activity
+// details, so edits to a single occurrence never carry over to later ones.
+type ActivitySeries struct {
+	ID        uint      `gorm:"primaryKey" json:"id"`
+	HostID    uint      `gorm:"column:host_id;not null;index" json:"hostId"`
+	Rule      string    `gorm:"column:rule;size:255;not null" json:"rule"`            // RRULE subset, e.g. FREQ=WEEKLY;INTERVAL=1;COUNT=8
+	StartTime time.Time `gorm:"column:start_time;not null" json:"startTime"`          // First occurrence; later ones follow the rule
+	LastSlot  time.Time `gorm:"column:last_slot" json:"-"`                            // Latest rule slot an occurrence was created for
+	Status    string    `gorm:"column:status;size:20;default:'active'" json:"status"` // active, cancelled
+
+	// Details copied into each new occurrence; updated by series-wide edits
+	Title           string             `gorm:"column:title;size:255" json:"title"`
+	Description     string             `gorm:"column:description;type:text" json:"description"`
+	Location        string             `gorm:"column:location;size:255" json:"location"`
+	Timezone        string             `gorm:"column:timezone;size:64" json:"timezone"`
+	MaxParticipants int                `gorm:"column:max_participants;default:0" json:"maxParticipants"`
+	FreeCancelHours int                `gorm:"column:free_cancel_hours;default:24" json:"freeCancelHours"`
+	Visibility      string             `gorm:"column:visibility;size:20" json:"visibility"`
+	Images          []string           `gorm:"serializer:json" json:"images"`
+	Tags            string             `gorm:"column:tags;type:text" json:"tags"`
+	Roles           []string           `gorm:"serializer:json" json:"roles"`
+	RoleSlots       []TemplateRoleSlot `gorm:"serializer:json" json:"roleSlots"`
+	Questions       []TemplateQuestion `gorm:"serializer:json" json:"questions,omitempty"`
+	CoHosts         []TemplateCoHost   `gorm:"serializer:json" json:"coHosts,omitempty"`
+
+	CreatedAt time.Time `json:"createdAt"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+// TemplateCoHost is a co-host stored in a series template.
+type TemplateCoHost struct {
+	UserID     uint   `json:"userId"`
+	Permission string `json:"permission"`
+}
+
+// TableName overrides the table name.
+func (ActivitySeries) TableName() string {
+	return "activity_series"
+}
+
+// Zone returns the series' timezone, with the same fallbacks as Activity.Zone.
+func (s *ActivitySeries) Zone() *time.Location {
+	return loadZone(s.Timezone)
+}
@@ -0,0 +1,44 @@
+package model
+
+import "time"
+
+// ActivityTemplate is a user's saved set of activity details that new
+// activities can be created from.
+type ActivityTemplate struct {
+	ID              uint               `gorm:"primaryKey" json:"id"`
+	UserID          uint               `gorm:"column:user_id;not null;index" json:"userId"`
+	Name            string             `gorm:"column:name;size:100;not null" json:"name"`
+	Title           string             `gorm:"column:title;size:255" json:"title"`
+	Description     string             `gorm:"column:description;type:text" json:"description"`
+	Location        string             `gorm:"column:location;size:255" json:"location"`
+	Timezone        string             `gorm:"column:timezone;size:64" json:"timezone"`
+	MaxParticipants int                `gorm:"column:max_participants;default:0" json:"maxParticipants"`
+	FreeCancelHours int                `gorm:"column:free_cancel_hours;default:24" json:"freeCancelHours"`
+	Visibility      string             `gorm:"column:visibility;size:20" json:"visibility"`
+	Images          []string           `gorm:"serializer:json" json:"images"` // JSON array of image URLs
+	Tags            string             `gorm:"column:tags;type:text" json:"tags"`
+	Roles           []string           `gorm:"serializer:json" json:"roles"`
+	RoleSlots       []TemplateRoleSlot `gorm:"serializer:json" json:"roleSlots"`
+	Questions       []TemplateQuestion `gorm:"serializer:json" json:"questions,omitempty"`
+	CreatedAt       time.Time          `json:"createdAt"`
+	UpdatedAt       time.Time          `json:"updatedAt"`
+}
+
+// TemplateRoleSlot is a role slot stored in a template.
+type TemplateRoleSlot struct {
+	Role  string `json:"role"`
+	Count int    `json:"count"`
+}
+
+// TemplateQuestion is an application form question stored in a template.
+type TemplateQuestion struct {
+	Type     string   `json:"type"`
+	Label    string   `json:"label"`
+	Options  []string `json:"options,omitempty"`
+	Required bool     `json:"required"`
+}
+
+// TableName overrides the table name.
+func (ActivityTemplate) TableName() string {
+	return "activity_templates"
+}
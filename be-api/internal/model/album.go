@@ -0,0 +1,37 @@
+package model
+
+import "time"
+
+// Album is a titled, ordered collection of a user's works. A work can belong to
+// several albums.
+type Album struct {
+	ID          uint      `gorm:"primaryKey" json:"id"`
+	UserID      uint      `gorm:"column:user_id;not null;index" json:"userId"`
+	Title       string    `gorm:"column:title;size:100;not null" json:"title"`
+	CoverWorkID *uint     `gorm:"column:cover_work_id" json:"coverWorkId,omitempty"`  // Defaults to the first work when unset
+	Position    int       `gorm:"column:position;not null;default:0" json:"position"` // Order on the owner's profile
+	CreatedAt   time.Time `json:"createdAt"`
+	UpdatedAt   time.Time `json:"updatedAt"`
+
+	// Computed fields (not in DB)
+	CoverURL string `gorm:"-" json:"coverUrl"`
+	Works    []Post `gorm:"-" json:"works"`
+}
+
+// TableName overrides the table name.
+func (Album) TableName() string {
+	return "albums"
+}
+
+// AlbumWork places a work in an album at a position.
+type AlbumWork struct {
+	AlbumID   uint      `gorm:"column:album_id;primaryKey" json:"albumId"`
+	WorkID    uint      `gorm:"column:work_id;primaryKey;index" json:"workId"` // references posts.id
+	Position  int       `gorm:"column:position;not null;default:0" json:"position"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+// TableName overrides the table name.
+func (AlbumWork) TableName() string {
+	return "album_works"
+}
@@ -0,0 +1,45 @@
+package model
+
+import "time"
+
+// Bookmark target types.
+const (
+	BookmarkWork     = "work"
+	BookmarkActivity = "activity"
+)
+
+// BookmarkBoard is a user's named, private collection of saved items.
+type BookmarkBoard struct {
+	ID        uint      `gorm:"primaryKey" json:"id"`
+	UserID    uint      `gorm:"column:user_id;not null;index" json:"userId"`
+	Name      string    `gorm:"column:name;size:50;not null" json:"name"`
+	CreatedAt time.Time `json:"createdAt"`
+
+	// Computed fields (not in DB)
+	ItemCount int64 `gorm:"-" json:"itemCount"`
+}
+
+// TableName overrides the table name.
+func (BookmarkBoard) TableName() string {
+	return "bookmark_boards"
+}
+
+// Bookmark is a privately saved work or activity. Each item is saved at most
+// once per user, optionally filed under one of their boards.
+type Bookmark struct {
+	ID         uint      `gorm:"primaryKey" json:"id"`
+	UserID     uint      `gorm:"column:user_id;not null;uniqueIndex:idx_bookmark_target" json:"userId"`
+	TargetType string    `gorm:"column:target_type;size:20;not null;uniqueIndex:idx_bookmark_target" json:"targetType"` // work, activity
+	TargetID   uint      `gorm:"column:target_id;not null;uniqueIndex:idx_bookmark_target" json:"targetId"`
+	BoardID    *uint     `gorm:"column:board_id;index" json:"boardId,omitempty"` // nil = not on a board
+	CreatedAt  time.Time `json:"createdAt"`
+
+	// Loaded targets (not in DB)
+	Work     *Post     `gorm:"-" json:"work,omitempty"`
+	Activity *Activity `gorm:"-" json:"activity,omitempty"`
+}
+
+// TableName overrides the table name.
+func (Bookmark) TableName() string {
+	return "bookmarks"
+}
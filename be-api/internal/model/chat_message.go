@@ -0,0 +1,21 @@
+package model
+
+import "time"
+
+// ChatMessage is a message posted in an activity's chat room.
+type ChatMessage struct {
+	ID        uint      `gorm:"primaryKey" json:"id"`
+	RoomID    uint      `gorm:"column:room_id;not null;index" json:"roomId"`
+	SenderID  uint      `gorm:"column:sender_id;not null" json:"senderId"`
+	Body      string    `gorm:"column:body;type:text" json:"body"`
+	Images    []string  `gorm:"serializer:json" json:"images,omitempty"` // JSON array of image URLs
+	CreatedAt time.Time `json:"createdAt"`
+
+	// Relationships
+	Sender User `gorm:"foreignKey:SenderID" json:"sender,omitempty"`
+}
+
+// TableName overrides the table name.
+func (ChatMessage) TableName() string {
+	return "chat_messages"
+}
@@ -0,0 +1,34 @@
+package model
+
+import "time"
+
+// ChatRoom is an activity's private group chat. Membership is not stored: the
+// host, co-hosts and accepted participants of the activity are the members.
+type ChatRoom struct {
+	ID            uint       `gorm:"primaryKey" json:"id"`
+	ActivityID    uint       `gorm:"column:activity_id;not null;uniqueIndex" json:"activityId"`
+	LastMessageAt *time.Time `gorm:"column:last_message_at;index" json:"lastMessageAt,omitempty"`
+	CreatedAt     time.Time  `json:"createdAt"`
+
+	// Relationships
+	Activity Activity `gorm:"foreignKey:ActivityID" json:"activity,omitempty"`
+}
+
+// TableName overrides the table name.
+func (ChatRoom) TableName() string {
+	return "chat_rooms"
+}
+
+// ChatReadState records the newest message a member has read in a room.
+type ChatReadState struct {
+	ID                uint      `gorm:"primaryKey" json:"id"`
+	RoomID            uint      `gorm:"column:room_id;not null;uniqueIndex:idx_chat_read_state" json:"roomId"`
+	UserID            uint      `gorm:"column:user_id;not null;uniqueIndex:idx_chat_read_state" json:"userId"`
+	LastReadMessageID uint      `gorm:"column:last_read_message_id;default:0" json:"lastReadMessageId"`
+	UpdatedAt         time.Time `json:"updatedAt"`
+}
+
+// TableName overrides the table name.
+func (ChatReadState) TableName() string {
+	return "chat_read_states"
+}
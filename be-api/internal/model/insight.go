@@ -0,0 +1,92 @@
+package model
+
+import "time"
+
+// View event kinds.
+const (
+	ViewProfile        = "profile_view"
+	ViewWork           = "work_view"
+	ViewWorkImpression = "work_impression" // The work was shown in the wall, a tag page or a profile
+)
+
+// ViewEvent records a viewer seeing a profile or work on a given local day.
+// The unique index keeps one event per viewer, target and day.
+type ViewEvent struct {
+	ID        uint      `gorm:"primaryKey" json:"id"`
+	Kind      string    `gorm:"size:20;not null;uniqueIndex:idx_view_dedupe" json:"kind"`
+	TargetID  uint      `gorm:"not null;uniqueIndex:idx_view_dedupe" json:"targetId"`          // User ID for profile views, work ID otherwise
+	ViewerKey string    `gorm:"size:80;not null;uniqueIndex:idx_view_dedupe" json:"-"`         // "u:<userID>", or a daily hash of the client for anonymous viewers
+	Day       string    `gorm:"size:10;not null;uniqueIndex:idx_view_dedupe;index" json:"day"` // YYYY-MM-DD in the insights time zone
+	OwnerID   uint      `gorm:"not null;index" json:"ownerId"`                                 // Creator being viewed
+	Anonymous bool      `gorm:"not null;default:false" json:"anonymous"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+func (ViewEvent) TableName() string {
+	return "view_events"
+}
+
+// InsightCounts are the metrics a creator sees in their insights.
+type InsightCounts struct {
+	ProfileViews          int64 `gorm:"not null;default:0" json:"profileViews"`
+	AnonymousProfileViews int64 `gorm:"not null;default:0" json:"anonymousProfileViews"`
+	WorkViews             int64 `gorm:"not null;default:0" json:"workViews"`
+	AnonymousWorkViews    int64 `gorm:"not null;default:0" json:"anonymousWorkViews"`
+	Impressions           int64 `gorm:"not null;default:0" json:"impressions"`
+	Likes                 int64 `gorm:"not null;default:0" json:"likes"`
+	NewFollowers          int64 `gorm:"not null;default:0" json:"newFollowers"`
+	Applications          int64 `gorm:"not null;default:0" json:"applications"` // Applications to activities the creator hosts
+}
+
+// Add adds other to c.
+func (c *InsightCounts) Add(other InsightCounts) {
+	c.ProfileViews += other.ProfileViews
+	c.AnonymousProfileViews += other.AnonymousProfileViews
+	c.WorkViews += other.WorkViews
+	c.AnonymousWorkViews += other.AnonymousWorkViews
+	c.Impressions += other.Impressions
+	c.Likes += other.Likes
+	c.NewFollowers += other.NewFollowers
+	c.Applications += other.Applications
+}
+
+// DailyInsight is a creator's rolled-up metrics for one local day.
+type DailyInsight struct {
+	ID            uint   `gorm:"primaryKey" json:"-"`
+	OwnerID       uint   `gorm:"not null;uniqueIndex:idx_insight_owner_day" json:"-"`
+	Day           string `gorm:"size:10;not null;uniqueIndex:idx_insight_owner_day;index" json:"date"`
+	InsightCounts `gorm:"embedded"`
+	UpdatedAt     time.Time `json:"-"`
+}
+
+func (DailyInsight) TableName() string {
+	return "daily_insights"
+}
+
+// WorkDailyInsight is one work's rolled-up metrics for one local day.
+type WorkDailyInsight struct {
+	ID             uint      `gorm:"primaryKey" json:"-"`
+	WorkID         uint      `gorm:"not null;uniqueIndex:idx_work_insight_day" json:"workId"`
+	Day            string    `gorm:"size:10;not null;uniqueIndex:idx_work_insight_day;index" json:"date"`
+	OwnerID        uint      `gorm:"not null;index" json:"-"`
+	Views          int64     `gorm:"not null;default:0" json:"views"`
+	AnonymousViews int64     `gorm:"not null;default:0" json:"anonymousViews"`
+	Impressions    int64     `gorm:"not null;default:0" json:"impressions"`
+	Likes          int64     `gorm:"not null;default:0" json:"likes"`
+	UpdatedAt      time.Time `json:"-"`
+}
+
+func (WorkDailyInsight) TableName() string {
+	return "work_daily_insights"
+}
+
+// InsightRollupDay marks a local day whose rollups have been written, so days
+// with no activity at all still count as done.
+type InsightRollupDay struct {
+	Day        string    `gorm:"primaryKey;size:10" json:"day"` // YYYY-MM-DD in the insights time zone
+	RolledUpAt time.Time `json:"rolledUpAt"`
+}
+
+func (InsightRollupDay) TableName() string {
+	return "insight_rollup_days"
+}
@@ -0,0 +1,19 @@
+package model
+
+import "time"
+
+// NotificationPreference stores a user's notification delivery settings.
+type NotificationPreference struct {
+	ID               uint       `gorm:"primaryKey" json:"id"`
+	UserID           uint       `gorm:"column:user_id;not null;uniqueIndex" json:"userId"`
+	EmailDigest      bool       `gorm:"column:email_digest;default:true" json:"emailDigest"`          // Weekly activity digest email
+	UnsubscribeToken string     `gorm:"column:unsubscribe_token;size:255;index" json:"-"`             // Secret used by one-click unsubscribe links
+	LastDigestSentAt *time.Time `gorm:"column:last_digest_sent_at" json:"lastDigestSentAt,omitempty"` // Guards against duplicate sends
+	CreatedAt        time.Time  `json:"createdAt"`
+	UpdatedAt        time.Time  `json:"updatedAt"`
+}
+
+// TableName overrides the table name.
+func (NotificationPreference) TableName() string {
+	return "notification_preferences"
+}
@@ -13,15 +13,21 @@ type Post struct {
 	AspectRatio  float64   `gorm:"column:aspect_ratio;not null;default:1.0" json:"aspectRatio"`
 	LikeCount    int       `gorm:"column:like_count;default:0" json:"likeCount"`
 	CommentCount int       `gorm:"column:comment_count;default:0" json:"commentCount"`
+	PinOrder     int       `gorm:"column:pin_order;not null;default:0" json:"pinOrder"`                         // Position among the author's pinned works; 0 = not pinned
+	ActivityID   *uint     `gorm:"column:activity_id;index" json:"activityId,omitempty"`                        // Shoot the work came from
+	Visibility   string    `gorm:"column:visibility;size:20;not null;default:'public';index" json:"visibility"` // public, followers, unlisted, private
 	CreatedAt    time.Time `json:"createdAt"`
 	UpdatedAt    time.Time `json:"updatedAt"`
 
 	// Computed fields (not in DB)
 	IsLiked bool `gorm:"-" json:"isLiked"`
+	IsSaved bool `gorm:"-" json:"isSaved"`
 
 	// Relationships
-	Author User  `gorm:"foreignKey:UserID" json:"author"`
-	Tags   []Tag `gorm:"many2many:post_tags;" json:"tags"`
+	Author   User         `gorm:"foreignKey:UserID" json:"author"`
+	Tags     []Tag        `gorm:"many2many:post_tags;" json:"tags"`
+	Credits  []WorkCredit `gorm:"foreignKey:WorkID" json:"credits,omitempty"` // Approved credits, loaded on detail
+	Activity *Activity    `gorm:"foreignKey:ActivityID" json:"activity,omitempty"`
 }
 
 func (Post) TableName() string {
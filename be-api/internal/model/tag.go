@@ -6,8 +6,38 @@ type Tag struct {
 	ID        uint      `gorm:"primaryKey" json:"id"`
 	Name      string    `gorm:"uniqueIndex;size:50;not null" json:"name"`
 	CreatedAt time.Time `json:"createdAt"`
+
+	// Computed fields (not in DB)
+	WorkCount     int64 `gorm:"-" json:"workCount,omitempty"`     // Public works using the tag; within the window for trending tags
+	FollowerCount int64 `gorm:"-" json:"followerCount,omitempty"` // Filled on the tag page
+	IsFollowing   bool  `gorm:"-" json:"isFollowing,omitempty"`
 }
 
 func (Tag) TableName() string {
 	return "tags"
 }
+
+// PostTag is the join table between posts and tags. CreatedAt records when
+// the tag was attached, which drives trending tags.
+type PostTag struct {
+	PostID    uint      `gorm:"primaryKey" json:"postId"`
+	TagID     uint      `gorm:"primaryKey;index" json:"tagId"`
+	CreatedAt time.Time `gorm:"index" json:"createdAt"`
+}
+
+func (PostTag) TableName() string {
+	return "post_tags"
+}
+
+// TagFollow is a user following a tag; works with the tag appear in their
+// following feed.
+type TagFollow struct {
+	ID        uint      `gorm:"primaryKey" json:"id"`
+	UserID    uint      `gorm:"not null;uniqueIndex:idx_tag_follow" json:"userId"`
+	TagID     uint      `gorm:"not null;uniqueIndex:idx_tag_follow;index" json:"tagId"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+func (TagFollow) TableName() string {
+	return "tag_follows"
+}
@@ -12,10 +12,12 @@ type User struct {
 	IsPhotographer    bool        `gorm:"-" json:"isPhotographer"` // Derived from Profile, not persisted on User table
 	Profile           UserProfile `gorm:"foreignKey:UserID" json:"profile"`
 	AverageRating     float64     `gorm:"-" json:"averageRating"`
+	LateCancelCount   int64       `gorm:"-" json:"lateCancelCount"` // Populated where hosts review applicants
 	ResetToken        string      `gorm:"column:reset_token;size:255" json:"-"`
 	ResetTokenExpiry  *time.Time  `gorm:"column:reset_token_expiry" json:"-"`
 	IsVerified        bool        `gorm:"column:is_verified;default:false" json:"isVerified"`
 	VerificationToken string      `gorm:"column:verification_token;size:255" json:"-"`
+	CalendarToken     string      `gorm:"column:calendar_token;size:64;index" json:"-"` // Secret for the calendar subscription feed
 	CreatedAt         time.Time   `json:"createdAt"`
 	UpdatedAt         time.Time   `json:"updatedAt"`
 }
@@ -0,0 +1,33 @@
+package model
+
+import "time"
+
+// Work credit statuses.
+const (
+	CreditPending  = "pending"
+	CreditApproved = "approved"
+	CreditDeclined = "declined"
+)
+
+// WorkCredit tags a collaborator on a work with their role. The credit only
+// shows publicly once the tagged user approves it.
+type WorkCredit struct {
+	ID          uint       `gorm:"primaryKey" json:"id"`
+	WorkID      uint       `gorm:"column:work_id;not null;uniqueIndex:idx_work_credit_user" json:"workId"` // references posts.id
+	UserID      uint       `gorm:"column:user_id;not null;uniqueIndex:idx_work_credit_user;index" json:"userId"`
+	Role        string     `gorm:"column:role;size:50;not null" json:"role"`                       // e.g. model, stylist, makeup
+	Status      string     `gorm:"column:status;size:20;not null;default:'pending'" json:"status"` // pending, approved, declined
+	ActivityID  *uint      `gorm:"column:activity_id;index" json:"activityId,omitempty"`           // Shoot the work came from
+	RespondedAt *time.Time `gorm:"column:responded_at" json:"respondedAt,omitempty"`
+	CreatedAt   time.Time  `json:"createdAt"`
+
+	// Relationships
+	User     User      `gorm:"foreignKey:UserID" json:"user,omitempty"`
+	Work     *Post     `gorm:"foreignKey:WorkID" json:"work,omitempty"`
+	Activity *Activity `gorm:"foreignKey:ActivityID" json:"activity,omitempty"`
+}
+
+// TableName overrides the table name.
+func (WorkCredit) TableName() string {
+	return "work_credits"
+}
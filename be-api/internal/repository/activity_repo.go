@@ -1,9 +1,13 @@
 package repository
 
 import (
+	"errors"
+	"time"
+
 	"azure-magnetar/internal/model"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 // ActivityRepository defines the interface for activity-related database operations.
@@ -14,17 +18,67 @@ type ActivityRepository interface {
 	Delete(id uint) error
 	List(filter ActivityFilter) ([]model.Activity, int64, error)
 	GetByUserID(userID uint) ([]model.Activity, error)
+	ReplaceRoleSlots(activityID uint, slots []model.ActivityRoleSlot) error
+	ReplaceQuestions(activityID uint, questions []model.ActivityQuestion) error
 
 	// Participant operations
 	CreateParticipant(p *model.ActivityParticipant) error
-	DeleteParticipant(activityID, userID uint) error
 	GetParticipant(activityID, userID uint) (*model.ActivityParticipant, error)
 	ListParticipants(activityID uint) ([]model.ActivityParticipant, error)
-	ListApplicants(activityID uint) ([]model.ActivityParticipant, error)
+	ListApplicants(activityID uint, filter ApplicantFilter) ([]model.ActivityParticipant, error)
 	UpdateParticipantStatus(id uint, status string) error
+	UpdateParticipantStatuses(activityID uint, participantIDs []uint, status string, check func(acceptedByRole map[string]int64) error) error
+	UpdateParticipant(p *model.ActivityParticipant) error
 	CountAccepted(activityID uint) (int64, error)
+	CountAcceptedByRole(activityID uint) (map[string]int64, error)
 	BatchCountAccepted(activityIDs []uint) (map[uint]int64, error)
 	GetApplicationsByUserID(userID uint) ([]model.ActivityParticipant, error)
+
+	// Series
+	CreateSeries(series *model.ActivitySeries) error
+	GetSeries(id uint) (*model.ActivitySeries, error)
+	UpdateSeries(series *model.ActivitySeries) error
+	UpdateSeriesOccurrences(series *model.ActivitySeries, updates []ActivityUpdate) error
+	ListActiveSeries() ([]model.ActivitySeries, error)
+	ListSeriesOccurrences(seriesID uint) ([]model.Activity, error)
+
+	// Drafts
+	ListScheduledPublishes(before time.Time) ([]model.Activity, error)
+
+	// Reminders
+	ListDueReminders(before time.Time) ([]model.Activity, error)
+	MarkReminderSent(id uint) error
+
+	// Attendance
+	ListAttendanceDue(startedAfter, startedBefore time.Time) ([]model.Activity, error)
+	CloseAttendance(activityID uint) (int64, error)
+	CountAttendance(userID uint) (attended, noShows int64, err error)
+	CountLateCancels(userIDs []uint) (map[uint]int64, error)
+
+	// Invite links
+	CreateInviteLink(link *model.ActivityInviteLink) error
+	GetInviteLinkByNonce(nonce string) (*model.ActivityInviteLink, error)
+	ListInviteLinks(activityID uint) ([]model.ActivityInviteLink, error)
+	ApplyWithInviteLink(linkID uint, p *model.ActivityParticipant, check func(acceptedByRole map[string]int64) error) (bool, error)
+	RevokeInviteLink(activityID, id uint) error
+
+	// Co-hosts
+	AddCoHost(coHost *model.ActivityCoHost) error
+	GetCoHost(activityID, userID uint) (*model.ActivityCoHost, error)
+	UpdateCoHost(coHost *model.ActivityCoHost) error
+	RemoveCoHost(activityID, userID uint) error
+	ListCoHosts(activityID uint) ([]model.ActivityCoHost, error)
+
+	// Templates
+	CreateTemplate(template *model.ActivityTemplate) error
+	GetTemplate(id uint) (*model.ActivityTemplate, error)
+	ListTemplates(userID uint) ([]model.ActivityTemplate, error)
+	CountTemplates(userID uint) (int64, error)
+	DeleteTemplate(userID, id uint) error
+
+	// Announcements
+	CreateAnnouncement(announcement *model.ActivityAnnouncement) error
+	ListAnnouncements(activityID uint) ([]model.ActivityAnnouncement, error)
 }
 
 // ActivityFilter holds query parameters for listing activities.
@@ -33,10 +87,33 @@ type ActivityFilter struct {
 	DateFrom string
 	DateTo   string
 	Tags     string
+	Status   string
 	Offset   int
 	Limit    int
 }
 
+// ApplicantFilter holds query parameters for listing an activity's applicants.
+// Role and Status are applied by the repository; the rest by the service.
+type ApplicantFilter struct {
+	Role        string
+	Status      string
+	Sort        string  // applied (newest first, default), applied_asc, rating, followers
+	FollowsHost *bool   // Only applicants who do (true) or don't (false) follow the host
+	MinRating   float64 // Only applicants with at least this average rating
+}
+
+// ErrParticipantsChanged is returned by UpdateParticipantStatuses when a
+// participant is no longer under review, e.g. withdrew in the meantime.
+var ErrParticipantsChanged = errors.New("participants changed during update")
+
+// ActivityUpdate is an edited activity to save, along with which of its
+// child rows to replace.
+type ActivityUpdate struct {
+	Activity         *model.Activity
+	ReplaceRoleSlots bool // Swap in Activity.RoleSlots
+	ReplaceQuestions bool // Swap in Activity.Questions
+}
+
 type activityRepository struct {
 	db *gorm.DB
 }
@@ -52,29 +129,119 @@ func (r *activityRepository) Create(activity *model.Activity) error {
 
 func (r *activityRepository) GetByID(id uint) (*model.Activity, error) {
 	var activity model.Activity
-	if err := r.db.Preload("Host").Preload("Host.Profile").First(&activity, id).Error; err != nil {
+	err := r.db.Preload("Host").Preload("Host.Profile").Preload("RoleSlots").
+		Preload("Questions", func(db *gorm.DB) *gorm.DB { return db.Order("position ASC") }).
+		Preload("CoHosts").Preload("CoHosts.User").Preload("CoHosts.User.Profile").
+		First(&activity, id).Error
+	if err != nil {
 		return nil, err
 	}
 
 	count, _ := r.CountAccepted(activity.ID)
 	activity.CurrentParticipants = count
 
+	roleCounts, _ := r.CountAcceptedByRole(activity.ID)
+	for i := range activity.RoleSlots {
+		activity.RoleSlots[i].Filled = roleCounts[activity.RoleSlots[i].Role]
+	}
+
 	return &activity, nil
 }
 
+// Update saves the activity's own columns. Role slots, questions and co-hosts
+// are managed separately through ReplaceRoleSlots, ReplaceQuestions and the
+// co-host methods.
 func (r *activityRepository) Update(activity *model.Activity) error {
-	return r.db.Save(activity).Error
+	return r.db.Omit("RoleSlots", "Questions", "CoHosts").Save(activity).Error
 }
 
 func (r *activityRepository) Delete(id uint) error {
-	return r.db.Delete(&model.Activity{}, id).Error
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		rooms := tx.Model(&model.ChatRoom{}).Select("id").Where("activity_id = ?", id)
+		for _, chatChild := range []interface{}{&model.ChatMessage{}, &model.ChatReadState{}} {
+			if err := tx.Where("room_id IN (?)", rooms).Delete(chatChild).Error; err != nil {
+				return err
+			}
+		}
+
+		children := []interface{}{
+			&model.ActivityRoleSlot{},
+			&model.ActivityQuestion{},
+			&model.ActivityInviteLink{},
+			&model.ActivityCoHost{},
+			&model.ActivityAnnouncement{},
+			&model.ChatRoom{},
+			&model.AgreementSignature{},
+			&model.ActivityAgreement{},
+		}
+		for _, child := range children {
+			if err := tx.Where("activity_id = ?", id).Delete(child).Error; err != nil {
+				return err
+			}
+		}
+		if err := tx.Where("target_type = ? AND target_id = ?", model.BookmarkActivity, id).Delete(&model.Bookmark{}).Error; err != nil {
+			return err
+		}
+		for _, linked := range []interface{}{&model.Post{}, &model.WorkCredit{}} {
+			if err := tx.Model(linked).Where("activity_id = ?", id).Update("activity_id", nil).Error; err != nil {
+				return err
+			}
+		}
+		return tx.Delete(&model.Activity{}, id).Error
+	})
+}
+
+// ReplaceRoleSlots swaps an activity's role slots for the given set.
+func (r *activityRepository) ReplaceRoleSlots(activityID uint, slots []model.ActivityRoleSlot) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		return replaceRoleSlots(tx, activityID, slots)
+	})
+}
+
+func replaceRoleSlots(tx *gorm.DB, activityID uint, slots []model.ActivityRoleSlot) error {
+	if err := tx.Where("activity_id = ?", activityID).Delete(&model.ActivityRoleSlot{}).Error; err != nil {
+		return err
+	}
+	if len(slots) == 0 {
+		return nil
+	}
+	for i := range slots {
+		slots[i].ID = 0
+		slots[i].ActivityID = activityID
+	}
+	return tx.Create(&slots).Error
+}
+
+// ReplaceQuestions swaps an activity's application form for the given questions,
+// numbering them in order.
+func (r *activityRepository) ReplaceQuestions(activityID uint, questions []model.ActivityQuestion) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		return replaceQuestions(tx, activityID, questions)
+	})
+}
+
+func replaceQuestions(tx *gorm.DB, activityID uint, questions []model.ActivityQuestion) error {
+	if err := tx.Where("activity_id = ?", activityID).Delete(&model.ActivityQuestion{}).Error; err != nil {
+		return err
+	}
+	if len(questions) == 0 {
+		return nil
+	}
+	for i := range questions {
+		questions[i].ID = 0
+		questions[i].ActivityID = activityID
+		questions[i].Position = i
+	}
+	return tx.Create(&questions).Error
 }
 
 func (r *activityRepository) List(filter ActivityFilter) ([]model.Activity, int64, error) {
 	var activities []model.Activity
 	var total int64
 
-	query := r.db.Model(&model.Activity{}).Preload("Host").Preload("Host.Profile")
+	// Only published public activities are discoverable; unlisted and private ones need a link or invitation
+	query := r.db.Model(&model.Activity{}).Preload("Host").Preload("Host.Profile").Preload("RoleSlots").
+		Where("visibility = ? AND status <> ?", "public", "draft")
 
 	if filter.Location != "" {
 		query = query.Where("location LIKE ?", "%"+filter.Location+"%")
@@ -88,6 +255,9 @@ func (r *activityRepository) List(filter ActivityFilter) ([]model.Activity, int6
 	if filter.Tags != "" {
 		query = query.Where("tags LIKE ?", "%"+filter.Tags+"%")
 	}
+	if filter.Status != "" {
+		query = query.Where("status = ?", filter.Status)
+	}
 
 	if err := query.Count(&total).Error; err != nil {
 		return nil, 0, err
@@ -104,15 +274,7 @@ func (r *activityRepository) List(filter ActivityFilter) ([]model.Activity, int6
 		return nil, 0, err
 	}
 
-	// Batch populate CurrentParticipants in a single query
-	ids := make([]uint, len(activities))
-	for i := range activities {
-		ids[i] = activities[i].ID
-	}
-	countMap, _ := r.BatchCountAccepted(ids)
-	for i := range activities {
-		activities[i].CurrentParticipants = countMap[activities[i].ID]
-	}
+	r.populateCounts(activities)
 
 	return activities, total, nil
 }
@@ -121,7 +283,7 @@ func (r *activityRepository) GetByUserID(userID uint) ([]model.Activity, error)
 	var activities []model.Activity
 
 	// Activities hosted by user OR joined (accepted) by user
-	err := r.db.Preload("Host").Preload("Host.Profile").
+	err := r.db.Preload("Host").Preload("Host.Profile").Preload("RoleSlots").
 		Where("host_id = ? OR id IN (?)",
 			userID,
 			r.db.Model(&model.ActivityParticipant{}).
@@ -131,32 +293,42 @@ func (r *activityRepository) GetByUserID(userID uint) ([]model.Activity, error)
 		Order("created_at DESC").
 		Find(&activities).Error
 
-	if err == nil && len(activities) > 0 {
-		ids := make([]uint, len(activities))
-		for i := range activities {
-			ids[i] = activities[i].ID
-		}
-		countMap, _ := r.BatchCountAccepted(ids)
-		for i := range activities {
-			activities[i].CurrentParticipants = countMap[activities[i].ID]
-		}
+	if err == nil {
+		r.populateCounts(activities)
 	}
 
 	return activities, err
 }
 
+// populateCounts batch-fills CurrentParticipants and per-role filled counts,
+// using one query for each regardless of the number of activities.
+func (r *activityRepository) populateCounts(activities []model.Activity) {
+	if len(activities) == 0 {
+		return
+	}
+
+	ids := make([]uint, len(activities))
+	for i := range activities {
+		ids[i] = activities[i].ID
+	}
+
+	countMap, _ := r.BatchCountAccepted(ids)
+	roleCountMap, _ := r.batchCountAcceptedByRole(ids)
+	for i := range activities {
+		activities[i].CurrentParticipants = countMap[activities[i].ID]
+		for j := range activities[i].RoleSlots {
+			slot := &activities[i].RoleSlots[j]
+			slot.Filled = roleCountMap[activities[i].ID][slot.Role]
+		}
+	}
+}
+
 // --- Participant operations ---
 
 func (r *activityRepository) CreateParticipant(p *model.ActivityParticipant) error {
 	return r.db.Create(p).Error
 }
 
-func (r *activityRepository) DeleteParticipant(activityID, userID uint) error {
-	return r.db.
-		Where("activity_id = ? AND user_id = ?", activityID, userID).
-		Delete(&model.ActivityParticipant{}).Error
-}
-
 func (r *activityRepository) GetParticipant(activityID, userID uint) (*model.ActivityParticipant, error) {
 	var p model.ActivityParticipant
 	if err := r.db.
@@ -175,11 +347,17 @@ func (r *activityRepository) ListParticipants(activityID uint) ([]model.Activity
 	return participants, err
 }
 
-func (r *activityRepository) ListApplicants(activityID uint) ([]model.ActivityParticipant, error) {
+func (r *activityRepository) ListApplicants(activityID uint, filter ApplicantFilter) ([]model.ActivityParticipant, error) {
 	var applicants []model.ActivityParticipant
-	err := r.db.Preload("User").Preload("User.Profile").
-		Where("activity_id = ?", activityID).
-		Order("applied_at DESC").
+	query := r.db.Preload("User").Preload("User.Profile").
+		Where("activity_id = ?", activityID)
+	if filter.Role != "" {
+		query = query.Where("role = ?", filter.Role)
+	}
+	if filter.Status != "" {
+		query = query.Where("status = ?", filter.Status)
+	}
+	err := query.Order("applied_at DESC").
 		Find(&applicants).Error
 	return applicants, err
 }
@@ -190,6 +368,46 @@ func (r *activityRepository) UpdateParticipantStatus(id uint, status string) err
 		Update("status", status).Error
 }
 
+// UpdateParticipantStatuses sets status on several participants of one
+// activity atomically. The activity row is locked first and check, when
+// non-nil, gets the accepted counts per role so capacity can be verified
+// without racing concurrent acceptances. An error from check aborts the update.
+func (r *activityRepository) UpdateParticipantStatuses(activityID uint, participantIDs []uint, status string, check func(acceptedByRole map[string]int64) error) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		var locked model.Activity
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Select("id").First(&locked, activityID).Error; err != nil {
+			return err
+		}
+		if check != nil {
+			counts, err := (&activityRepository{db: tx}).CountAcceptedByRole(activityID)
+			if err != nil {
+				return err
+			}
+			if err := check(counts); err != nil {
+				return err
+			}
+		}
+		// Only applications still under review may change; one withdrawn or
+		// cancelled in the meantime fails the whole batch
+		result := tx.Model(&model.ActivityParticipant{}).
+			Where("activity_id = ? AND id IN ?", activityID, participantIDs).
+			Where("status IN ?", []string{"pending", "accepted", "rejected"}).
+			Update("status", status)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected != int64(len(participantIDs)) {
+			return ErrParticipantsChanged
+		}
+		return nil
+	})
+}
+
+// UpdateParticipant saves all columns of an existing participant record.
+func (r *activityRepository) UpdateParticipant(p *model.ActivityParticipant) error {
+	return r.db.Omit(clause.Associations).Save(p).Error
+}
+
 func (r *activityRepository) CountAccepted(activityID uint) (int64, error) {
 	var count int64
 	err := r.db.Model(&model.ActivityParticipant{}).
@@ -198,6 +416,18 @@ func (r *activityRepository) CountAccepted(activityID uint) (int64, error) {
 	return count, err
 }
 
+// CountAcceptedByRole returns accepted participant counts keyed by role.
+func (r *activityRepository) CountAcceptedByRole(activityID uint) (map[string]int64, error) {
+	byActivity, err := r.batchCountAcceptedByRole([]uint{activityID})
+	if err != nil {
+		return nil, err
+	}
+	if counts, ok := byActivity[activityID]; ok {
+		return counts, nil
+	}
+	return make(map[string]int64), nil
+}
+
 func (r *activityRepository) GetApplicationsByUserID(userID uint) ([]model.ActivityParticipant, error) {
 	var applications []model.ActivityParticipant
 	err := r.db.Preload("Activity").Preload("Activity.Host").
@@ -235,3 +465,380 @@ func (r *activityRepository) BatchCountAccepted(activityIDs []uint) (map[uint]in
 	}
 	return result, nil
 }
+
+// batchCountAcceptedByRole returns accepted participant counts per activity
+// and role in a single query.
+func (r *activityRepository) batchCountAcceptedByRole(activityIDs []uint) (map[uint]map[string]int64, error) {
+	result := make(map[uint]map[string]int64)
+	if len(activityIDs) == 0 {
+		return result, nil
+	}
+
+	type roleCountRow struct {
+		ActivityID uint
+		Role       string
+		Count      int64
+	}
+
+	var rows []roleCountRow
+	err := r.db.Model(&model.ActivityParticipant{}).
+		Select("activity_id, role, COUNT(*) as count").
+		Where("activity_id IN ? AND status = ?", activityIDs, "accepted").
+		Group("activity_id, role").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	for _, row := range rows {
+		if result[row.ActivityID] == nil {
+			result[row.ActivityID] = make(map[string]int64)
+		}
+		result[row.ActivityID][row.Role] = row.Count
+	}
+	return result, nil
+}
+
+// --- Series ---
+
+func (r *activityRepository) CreateSeries(series *model.ActivitySeries) error {
+	return r.db.Create(series).Error
+}
+
+func (r *activityRepository) GetSeries(id uint) (*model.ActivitySeries, error) {
+	var series model.ActivitySeries
+	if err := r.db.First(&series, id).Error; err != nil {
+		return nil, err
+	}
+	return &series, nil
+}
+
+func (r *activityRepository) UpdateSeries(series *model.ActivitySeries) error {
+	return r.db.Save(series).Error
+}
+
+// UpdateSeriesOccurrences saves a series together with edits to its
+// occurrences, all or nothing.
+func (r *activityRepository) UpdateSeriesOccurrences(series *model.ActivitySeries, updates []ActivityUpdate) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		for _, u := range updates {
+			if u.ReplaceRoleSlots {
+				if err := replaceRoleSlots(tx, u.Activity.ID, u.Activity.RoleSlots); err != nil {
+					return err
+				}
+			}
+			if u.ReplaceQuestions {
+				if err := replaceQuestions(tx, u.Activity.ID, u.Activity.Questions); err != nil {
+					return err
+				}
+			}
+			if err := tx.Omit("RoleSlots", "Questions", "CoHosts").Save(u.Activity).Error; err != nil {
+				return err
+			}
+		}
+		return tx.Save(series).Error
+	})
+}
+
+func (r *activityRepository) ListActiveSeries() ([]model.ActivitySeries, error) {
+	var series []model.ActivitySeries
+	err := r.db.Where("status = ?", "active").Find(&series).Error
+	return series, err
+}
+
+// ListSeriesOccurrences returns a series' occurrences ordered by event time.
+func (r *activityRepository) ListSeriesOccurrences(seriesID uint) ([]model.Activity, error) {
+	var activities []model.Activity
+	err := r.db.Preload("Host").Preload("Host.Profile").Preload("RoleSlots").
+		Where("series_id = ?", seriesID).
+		Order("event_time ASC").
+		Find(&activities).Error
+	if err != nil {
+		return nil, err
+	}
+
+	r.populateCounts(activities)
+	return activities, nil
+}
+
+// --- Drafts ---
+
+// ListScheduledPublishes returns drafts whose scheduled publication time is due.
+func (r *activityRepository) ListScheduledPublishes(before time.Time) ([]model.Activity, error) {
+	var activities []model.Activity
+	err := r.db.Where("status = ? AND publish_at IS NOT NULL AND publish_at <= ?", "draft", before.UTC()).
+		Order("publish_at ASC").
+		Find(&activities).Error
+	return activities, err
+}
+
+// --- Reminders ---
+
+// ListDueReminders returns upcoming open or full activities starting before the
+// given time whose reminder has not been sent yet.
+func (r *activityRepository) ListDueReminders(before time.Time) ([]model.Activity, error) {
+	var activities []model.Activity
+	err := r.db.Where("status IN ? AND reminder_sent_at IS NULL", []string{"open", "full"}).
+		Where("event_time > ? AND event_time <= ?", time.Now().UTC(), before.UTC()).
+		Order("event_time ASC").
+		Find(&activities).Error
+	return activities, err
+}
+
+func (r *activityRepository) MarkReminderSent(id uint) error {
+	return r.db.Model(&model.Activity{}).Where("id = ?", id).
+		UpdateColumn("reminder_sent_at", time.Now().UTC()).Error
+}
+
+// --- Attendance ---
+
+// ListAttendanceDue returns activities that started in the given window, were
+// not cancelled, and have not had their attendance closed yet.
+func (r *activityRepository) ListAttendanceDue(startedAfter, startedBefore time.Time) ([]model.Activity, error) {
+	var activities []model.Activity
+	err := r.db.Where("status <> ? AND attendance_closed_at IS NULL", "cancelled").
+		Where("event_time > ? AND event_time <= ?", startedAfter.UTC(), startedBefore.UTC()).
+		Find(&activities).Error
+	return activities, err
+}
+
+// CloseAttendance marks accepted participants who never checked in as no_show
+// and stamps the activity so it is processed only once. Returns the number of
+// participants marked.
+func (r *activityRepository) CloseAttendance(activityID uint) (int64, error) {
+	var marked int64
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&model.ActivityParticipant{}).
+			Where("activity_id = ? AND status = ? AND checked_in_at IS NULL", activityID, "accepted").
+			Update("status", "no_show")
+		if result.Error != nil {
+			return result.Error
+		}
+		marked = result.RowsAffected
+		return tx.Model(&model.Activity{}).Where("id = ?", activityID).
+			UpdateColumn("attendance_closed_at", time.Now().UTC()).Error
+	})
+	return marked, err
+}
+
+// CountAttendance returns how many activities a user checked in to and how
+// many they were marked no_show for.
+func (r *activityRepository) CountAttendance(userID uint) (int64, int64, error) {
+	var attended, noShows int64
+	if err := r.db.Model(&model.ActivityParticipant{}).
+		Where("user_id = ? AND checked_in_at IS NOT NULL", userID).
+		Count(&attended).Error; err != nil {
+		return 0, 0, err
+	}
+	if err := r.db.Model(&model.ActivityParticipant{}).
+		Where("user_id = ? AND status = ?", userID, "no_show").
+		Count(&noShows).Error; err != nil {
+		return 0, 0, err
+	}
+	return attended, noShows, nil
+}
+
+// CountLateCancels returns the number of late cancellations per user.
+func (r *activityRepository) CountLateCancels(userIDs []uint) (map[uint]int64, error) {
+	result := make(map[uint]int64)
+	if len(userIDs) == 0 {
+		return result, nil
+	}
+
+	type countRow struct {
+		UserID uint
+		Count  int64
+	}
+
+	var rows []countRow
+	err := r.db.Model(&model.ActivityParticipant{}).
+		Select("user_id, COUNT(*) as count").
+		Where("user_id IN ? AND status = ?", userIDs, "late_cancelled").
+		Group("user_id").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	for _, row := range rows {
+		result[row.UserID] = row.Count
+	}
+	return result, nil
+}
+
+// --- Invite links ---
+
+func (r *activityRepository) CreateInviteLink(link *model.ActivityInviteLink) error {
+	return r.db.Create(link).Error
+}
+
+func (r *activityRepository) GetInviteLinkByNonce(nonce string) (*model.ActivityInviteLink, error) {
+	var link model.ActivityInviteLink
+	if err := r.db.Where("nonce = ?", nonce).First(&link).Error; err != nil {
+		return nil, err
+	}
+	return &link, nil
+}
+
+func (r *activityRepository) ListInviteLinks(activityID uint) ([]model.ActivityInviteLink, error) {
+	var links []model.ActivityInviteLink
+	err := r.db.Where("activity_id = ?", activityID).
+		Order("created_at DESC").
+		Find(&links).Error
+	return links, err
+}
+
+// ApplyWithInviteLink saves an application made through a link and records
+// one use of the link, in one transaction with the activity and link rows
+// locked. check, when non-nil, gets the accepted counts per role so an
+// auto-accepted application can be verified against capacity without racing
+// concurrent acceptances; an error from check aborts, saving nothing. It
+// reports false, saving nothing, when the link has reached its use limit or
+// was revoked. p is updated in place when it has an ID, created otherwise.
+func (r *activityRepository) ApplyWithInviteLink(linkID uint, p *model.ActivityParticipant, check func(acceptedByRole map[string]int64) error) (bool, error) {
+	used := false
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		var locked model.Activity
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Select("id").First(&locked, p.ActivityID).Error; err != nil {
+			return err
+		}
+		var link model.ActivityInviteLink
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&link, linkID).Error; err != nil {
+			return err
+		}
+		if link.RevokedAt != nil || (link.MaxUses > 0 && link.Uses >= link.MaxUses) {
+			return nil
+		}
+		if check != nil {
+			counts, err := (&activityRepository{db: tx}).CountAcceptedByRole(p.ActivityID)
+			if err != nil {
+				return err
+			}
+			if err := check(counts); err != nil {
+				return err
+			}
+		}
+
+		var err error
+		if p.ID != 0 {
+			err = tx.Omit(clause.Associations).Save(p).Error
+		} else {
+			err = tx.Create(p).Error
+		}
+		if err != nil {
+			return err
+		}
+		if err := tx.Model(&link).Update("uses", gorm.Expr("uses + 1")).Error; err != nil {
+			return err
+		}
+		used = true
+		return nil
+	})
+	return used, err
+}
+
+func (r *activityRepository) RevokeInviteLink(activityID, id uint) error {
+	result := r.db.Model(&model.ActivityInviteLink{}).
+		Where("id = ? AND activity_id = ? AND revoked_at IS NULL", id, activityID).
+		Update("revoked_at", time.Now())
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+// --- Co-hosts ---
+
+func (r *activityRepository) AddCoHost(coHost *model.ActivityCoHost) error {
+	return r.db.Create(coHost).Error
+}
+
+func (r *activityRepository) GetCoHost(activityID, userID uint) (*model.ActivityCoHost, error) {
+	var coHost model.ActivityCoHost
+	if err := r.db.Where("activity_id = ? AND user_id = ?", activityID, userID).First(&coHost).Error; err != nil {
+		return nil, err
+	}
+	return &coHost, nil
+}
+
+func (r *activityRepository) UpdateCoHost(coHost *model.ActivityCoHost) error {
+	return r.db.Omit(clause.Associations).Save(coHost).Error
+}
+
+func (r *activityRepository) RemoveCoHost(activityID, userID uint) error {
+	result := r.db.Where("activity_id = ? AND user_id = ?", activityID, userID).Delete(&model.ActivityCoHost{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+func (r *activityRepository) ListCoHosts(activityID uint) ([]model.ActivityCoHost, error) {
+	var coHosts []model.ActivityCoHost
+	err := r.db.Preload("User").Preload("User.Profile").
+		Where("activity_id = ?", activityID).
+		Order("created_at ASC").
+		Find(&coHosts).Error
+	return coHosts, err
+}
+
+// --- Templates ---
+
+func (r *activityRepository) CreateTemplate(template *model.ActivityTemplate) error {
+	return r.db.Create(template).Error
+}
+
+func (r *activityRepository) GetTemplate(id uint) (*model.ActivityTemplate, error) {
+	var template model.ActivityTemplate
+	if err := r.db.First(&template, id).Error; err != nil {
+		return nil, err
+	}
+	return &template, nil
+}
+
+func (r *activityRepository) ListTemplates(userID uint) ([]model.ActivityTemplate, error) {
+	var templates []model.ActivityTemplate
+	err := r.db.Where("user_id = ?", userID).
+		Order("created_at DESC").
+		Find(&templates).Error
+	return templates, err
+}
+
+func (r *activityRepository) CountTemplates(userID uint) (int64, error) {
+	var count int64
+	err := r.db.Model(&model.ActivityTemplate{}).Where("user_id = ?", userID).Count(&count).Error
+	return count, err
+}
+
+func (r *activityRepository) DeleteTemplate(userID, id uint) error {
+	result := r.db.Where("id = ? AND user_id = ?", id, userID).Delete(&model.ActivityTemplate{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+// --- Announcements ---
+
+func (r *activityRepository) CreateAnnouncement(announcement *model.ActivityAnnouncement) error {
+	return r.db.Create(announcement).Error
+}
+
+// ListAnnouncements returns an activity's announcements, newest first.
+func (r *activityRepository) ListAnnouncements(activityID uint) ([]model.ActivityAnnouncement, error) {
+	var announcements []model.ActivityAnnouncement
+	err := r.db.Preload("Author").Preload("Author.Profile").
+		Where("activity_id = ?", activityID).
+		Order("created_at DESC").
+		Find(&announcements).Error
+	return announcements, err
+}
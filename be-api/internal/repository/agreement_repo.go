@@ -0,0 +1,63 @@
+package repository
+
+import (
+	"azure-magnetar/internal/model"
+
+	"gorm.io/gorm"
+)
+
+// AgreementRepository defines the interface for activity agreement database operations.
+type AgreementRepository interface {
+	Get(activityID uint) (*model.ActivityAgreement, error)
+	Save(agreement *model.ActivityAgreement) error
+
+	CreateSignature(signature *model.AgreementSignature) error
+	GetSignature(agreementID, userID uint) (*model.AgreementSignature, error)
+	ListSignatures(agreementID uint) ([]model.AgreementSignature, error)
+}
+
+type agreementRepository struct {
+	db *gorm.DB
+}
+
+// NewAgreementRepository creates a new AgreementRepository.
+func NewAgreementRepository(db *gorm.DB) AgreementRepository {
+	return &agreementRepository{db: db}
+}
+
+// --- Agreements ---
+
+func (r *agreementRepository) Get(activityID uint) (*model.ActivityAgreement, error) {
+	var agreement model.ActivityAgreement
+	if err := r.db.Where("activity_id = ?", activityID).First(&agreement).Error; err != nil {
+		return nil, err
+	}
+	return &agreement, nil
+}
+
+// Save creates the agreement or replaces an existing one's text.
+func (r *agreementRepository) Save(agreement *model.ActivityAgreement) error {
+	return r.db.Save(agreement).Error
+}
+
+// --- Signatures ---
+
+func (r *agreementRepository) CreateSignature(signature *model.AgreementSignature) error {
+	return r.db.Create(signature).Error
+}
+
+func (r *agreementRepository) GetSignature(agreementID, userID uint) (*model.AgreementSignature, error) {
+	var signature model.AgreementSignature
+	if err := r.db.Where("agreement_id = ? AND user_id = ?", agreementID, userID).First(&signature).Error; err != nil {
+		return nil, err
+	}
+	return &signature, nil
+}
+
+func (r *agreementRepository) ListSignatures(agreementID uint) ([]model.AgreementSignature, error) {
+	var signatures []model.AgreementSignature
+	err := r.db.Where("agreement_id = ?", agreementID).
+		Order("signed_at ASC").
+		Find(&signatures).Error
+	return signatures, err
+}
@@ -0,0 +1,173 @@
+package repository
+
+import (
+	"azure-magnetar/internal/model"
+
+	"gorm.io/gorm"
+)
+
+// AlbumRepository defines the interface for album-related database operations.
+type AlbumRepository interface {
+	Create(album *model.Album, workIDs []uint) error
+	Get(id uint) (*model.Album, error)
+	List(userID uint) ([]model.Album, error)
+	Count(userID uint) (int64, error)
+	Update(album *model.Album) error
+	Delete(id uint) error
+	SetWorks(albumID uint, workIDs []uint) error
+	Reorder(userID uint, albumIDs []uint) error
+}
+
+type albumRepository struct {
+	db *gorm.DB
+}
+
+// NewAlbumRepository creates a new AlbumRepository.
+func NewAlbumRepository(db *gorm.DB) AlbumRepository {
+	return &albumRepository{db: db}
+}
+
+// Create saves an album with its initial works, in order, appending it
+// after the owner's existing albums.
+func (r *albumRepository) Create(album *model.Album, workIDs []uint) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		var last int
+		if err := tx.Model(&model.Album{}).Where("user_id = ?", album.UserID).
+			Select("COALESCE(MAX(position), 0)").Scan(&last).Error; err != nil {
+			return err
+		}
+		album.Position = last + 1
+		if err := tx.Create(album).Error; err != nil {
+			return err
+		}
+		return insertAlbumWorks(tx, album.ID, workIDs)
+	})
+}
+
+// Get returns an album with its works in order.
+func (r *albumRepository) Get(id uint) (*model.Album, error) {
+	var album model.Album
+	if err := r.db.First(&album, id).Error; err != nil {
+		return nil, err
+	}
+	albums := []model.Album{album}
+	if err := r.loadWorks(albums); err != nil {
+		return nil, err
+	}
+	return &albums[0], nil
+}
+
+// List returns a user's albums in profile order, each with its works.
+func (r *albumRepository) List(userID uint) ([]model.Album, error) {
+	var albums []model.Album
+	if err := r.db.Where("user_id = ?", userID).
+		Order("position ASC, id ASC").
+		Find(&albums).Error; err != nil {
+		return nil, err
+	}
+	if err := r.loadWorks(albums); err != nil {
+		return nil, err
+	}
+	return albums, nil
+}
+
+// loadWorks fills each album's Works in album order with one query.
+func (r *albumRepository) loadWorks(albums []model.Album) error {
+	if len(albums) == 0 {
+		return nil
+	}
+	albumIDs := make([]uint, len(albums))
+	for i := range albums {
+		albumIDs[i] = albums[i].ID
+	}
+
+	var entries []model.AlbumWork
+	if err := r.db.Where("album_id IN ?", albumIDs).
+		Order("position ASC").
+		Find(&entries).Error; err != nil {
+		return err
+	}
+
+	workIDs := make([]uint, 0, len(entries))
+	for _, e := range entries {
+		workIDs = append(workIDs, e.WorkID)
+	}
+	var posts []model.Post
+	if len(workIDs) > 0 {
+		if err := r.db.Preload("Tags").Where("id IN ?", workIDs).Find(&posts).Error; err != nil {
+			return err
+		}
+	}
+	byID := make(map[uint]model.Post, len(posts))
+	for _, p := range posts {
+		byID[p.ID] = p
+	}
+
+	index := make(map[uint]int, len(albums))
+	for i := range albums {
+		index[albums[i].ID] = i
+		albums[i].Works = []model.Post{}
+	}
+	for _, e := range entries {
+		if p, ok := byID[e.WorkID]; ok {
+			a := &albums[index[e.AlbumID]]
+			a.Works = append(a.Works, p)
+		}
+	}
+	return nil
+}
+
+func (r *albumRepository) Count(userID uint) (int64, error) {
+	var count int64
+	err := r.db.Model(&model.Album{}).Where("user_id = ?", userID).Count(&count).Error
+	return count, err
+}
+
+// Update saves an album's title and cover.
+func (r *albumRepository) Update(album *model.Album) error {
+	return r.db.Model(album).Select("title", "cover_work_id").Updates(album).Error
+}
+
+func (r *albumRepository) Delete(id uint) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("album_id = ?", id).Delete(&model.AlbumWork{}).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&model.Album{}, id).Error
+	})
+}
+
+// SetWorks replaces an album's works with workIDs, in order.
+func (r *albumRepository) SetWorks(albumID uint, workIDs []uint) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("album_id = ?", albumID).Delete(&model.AlbumWork{}).Error; err != nil {
+			return err
+		}
+		return insertAlbumWorks(tx, albumID, workIDs)
+	})
+}
+
+func insertAlbumWorks(tx *gorm.DB, albumID uint, workIDs []uint) error {
+	if len(workIDs) == 0 {
+		return nil
+	}
+	entries := make([]model.AlbumWork, len(workIDs))
+	for i, id := range workIDs {
+		entries[i] = model.AlbumWork{AlbumID: albumID, WorkID: id, Position: i + 1}
+	}
+	return tx.Create(&entries).Error
+}
+
+// Reorder sets the profile order of a user's albums to albumIDs.
+func (r *albumRepository) Reorder(userID uint, albumIDs []uint) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		for i, id := range albumIDs {
+			if err := tx.Model(&model.Album{}).
+				Where("id = ? AND user_id = ?", id, userID).
+				UpdateColumn("position", i+1).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
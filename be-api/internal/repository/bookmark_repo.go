@@ -0,0 +1,180 @@
+package repository
+
+import (
+	"azure-magnetar/internal/model"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// BookmarkFilter selects a page of a user's bookmarks, newest first.
+type BookmarkFilter struct {
+	TargetType string // work, activity; empty for both
+	BoardID    *uint  // Only items on this board
+	BeforeID   uint   // Only bookmarks older than this ID (cursor)
+	Limit      int
+}
+
+// BookmarkRepository defines the interface for bookmark-related database operations.
+type BookmarkRepository interface {
+	CreateBoard(board *model.BookmarkBoard) error
+	GetBoard(id uint) (*model.BookmarkBoard, error)
+	ListBoards(userID uint) ([]model.BookmarkBoard, error)
+	UpdateBoard(board *model.BookmarkBoard) error
+	DeleteBoard(id uint) error
+
+	Save(bookmark *model.Bookmark) error
+	Delete(userID uint, targetType string, targetID uint) (bool, error)
+	List(userID uint, filter BookmarkFilter) ([]model.Bookmark, error)
+}
+
+type bookmarkRepository struct {
+	db *gorm.DB
+}
+
+// NewBookmarkRepository creates a new BookmarkRepository.
+func NewBookmarkRepository(db *gorm.DB) BookmarkRepository {
+	return &bookmarkRepository{db: db}
+}
+
+// --- Boards ---
+
+func (r *bookmarkRepository) CreateBoard(board *model.BookmarkBoard) error {
+	return r.db.Create(board).Error
+}
+
+func (r *bookmarkRepository) GetBoard(id uint) (*model.BookmarkBoard, error) {
+	var board model.BookmarkBoard
+	if err := r.db.First(&board, id).Error; err != nil {
+		return nil, err
+	}
+	return &board, nil
+}
+
+// ListBoards returns a user's boards, oldest first, with their item counts.
+func (r *bookmarkRepository) ListBoards(userID uint) ([]model.BookmarkBoard, error) {
+	var boards []model.BookmarkBoard
+	if err := r.db.Where("user_id = ?", userID).Order("id ASC").Find(&boards).Error; err != nil {
+		return nil, err
+	}
+	if len(boards) == 0 {
+		return boards, nil
+	}
+
+	var counts []struct {
+		BoardID uint
+		Count   int64
+	}
+	if err := r.db.Model(&model.Bookmark{}).
+		Select("board_id, COUNT(*) AS count").
+		Where("user_id = ? AND board_id IS NOT NULL", userID).
+		Group("board_id").
+		Scan(&counts).Error; err != nil {
+		return nil, err
+	}
+	byBoard := make(map[uint]int64, len(counts))
+	for _, c := range counts {
+		byBoard[c.BoardID] = c.Count
+	}
+	for i := range boards {
+		boards[i].ItemCount = byBoard[boards[i].ID]
+	}
+	return boards, nil
+}
+
+func (r *bookmarkRepository) UpdateBoard(board *model.BookmarkBoard) error {
+	return r.db.Model(board).Update("name", board.Name).Error
+}
+
+// DeleteBoard removes a board. Its items stay saved, off any board.
+func (r *bookmarkRepository) DeleteBoard(id uint) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&model.Bookmark{}).Where("board_id = ?", id).
+			Update("board_id", nil).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&model.BookmarkBoard{}, id).Error
+	})
+}
+
+// --- Bookmarks ---
+
+// Save bookmarks an item, or moves an already saved item to bookmark.BoardID.
+func (r *bookmarkRepository) Save(bookmark *model.Bookmark) error {
+	return r.db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "user_id"}, {Name: "target_type"}, {Name: "target_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"board_id"}),
+	}).Create(bookmark).Error
+}
+
+// Delete removes a bookmark and reports whether one existed.
+func (r *bookmarkRepository) Delete(userID uint, targetType string, targetID uint) (bool, error) {
+	result := r.db.
+		Where("user_id = ? AND target_type = ? AND target_id = ?", userID, targetType, targetID).
+		Delete(&model.Bookmark{})
+	return result.RowsAffected > 0, result.Error
+}
+
+// List returns a page of bookmarks, newest first, with their works or
+// activities loaded. Items whose target no longer exists are left without one.
+func (r *bookmarkRepository) List(userID uint, filter BookmarkFilter) ([]model.Bookmark, error) {
+	var bookmarks []model.Bookmark
+	query := r.db.Where("user_id = ?", userID)
+	if filter.TargetType != "" {
+		query = query.Where("target_type = ?", filter.TargetType)
+	}
+	if filter.BoardID != nil {
+		query = query.Where("board_id = ?", *filter.BoardID)
+	}
+	if filter.BeforeID > 0 {
+		query = query.Where("id < ?", filter.BeforeID)
+	}
+	if err := query.Order("id DESC").Limit(filter.Limit).Find(&bookmarks).Error; err != nil {
+		return nil, err
+	}
+
+	var workIDs, activityIDs []uint
+	for _, b := range bookmarks {
+		switch b.TargetType {
+		case model.BookmarkWork:
+			workIDs = append(workIDs, b.TargetID)
+		case model.BookmarkActivity:
+			activityIDs = append(activityIDs, b.TargetID)
+		}
+	}
+
+	works := make(map[uint]*model.Post)
+	if len(workIDs) > 0 {
+		var posts []model.Post
+		if err := r.db.Preload("Author").Preload("Author.Profile").Preload("Tags").
+			Where("id IN ?", workIDs).Find(&posts).Error; err != nil {
+			return nil, err
+		}
+		for i := range posts {
+			posts[i].IsSaved = true
+			works[posts[i].ID] = &posts[i]
+		}
+	}
+
+	activities := make(map[uint]*model.Activity)
+	if len(activityIDs) > 0 {
+		var list []model.Activity
+		if err := r.db.Preload("Host").Preload("Host.Profile").
+			Where("id IN ?", activityIDs).Find(&list).Error; err != nil {
+			return nil, err
+		}
+		for i := range list {
+			activities[list[i].ID] = &list[i]
+		}
+	}
+
+	for i := range bookmarks {
+		switch bookmarks[i].TargetType {
+		case model.BookmarkWork:
+			bookmarks[i].Work = works[bookmarks[i].TargetID]
+		case model.BookmarkActivity:
+			bookmarks[i].Activity = activities[bookmarks[i].TargetID]
+		}
+	}
+	return bookmarks, nil
+}
@@ -0,0 +1,137 @@
+package repository
+
+import (
+	"time"
+
+	"azure-magnetar/internal/model"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ChatRepository defines the interface for activity group chat database operations.
+type ChatRepository interface {
+	EnsureRoom(activityID uint) (*model.ChatRoom, error)
+	GetRoom(activityID uint) (*model.ChatRoom, error)
+	ListRooms(userID uint) ([]model.ChatRoom, error)
+
+	CreateMessage(msg *model.ChatMessage) error
+	ListMessages(roomID, beforeID uint, limit int) ([]model.ChatMessage, error)
+	MarkRead(roomID, userID, messageID uint) error
+	CountUnread(userID uint, roomIDs []uint) (map[uint]int64, error)
+}
+
+type chatRepository struct {
+	db *gorm.DB
+}
+
+// NewChatRepository creates a new ChatRepository.
+func NewChatRepository(db *gorm.DB) ChatRepository {
+	return &chatRepository{db: db}
+}
+
+// --- Rooms ---
+
+// EnsureRoom returns the activity's chat room, creating it if needed.
+func (r *chatRepository) EnsureRoom(activityID uint) (*model.ChatRoom, error) {
+	room := model.ChatRoom{ActivityID: activityID}
+	err := r.db.Where("activity_id = ?", activityID).FirstOrCreate(&room).Error
+	if err != nil {
+		return nil, err
+	}
+	return &room, nil
+}
+
+func (r *chatRepository) GetRoom(activityID uint) (*model.ChatRoom, error) {
+	var room model.ChatRoom
+	if err := r.db.Where("activity_id = ?", activityID).First(&room).Error; err != nil {
+		return nil, err
+	}
+	return &room, nil
+}
+
+// ListRooms returns the rooms userID belongs to as host, co-host or
+// accepted participant, most recently active first.
+func (r *chatRepository) ListRooms(userID uint) ([]model.ChatRoom, error) {
+	var rooms []model.ChatRoom
+	err := r.db.Preload("Activity").
+		Joins("JOIN activities ON activities.id = chat_rooms.activity_id").
+		Where("activities.host_id = ? OR EXISTS (?) OR EXISTS (?)", userID,
+			r.db.Model(&model.ActivityCoHost{}).Select("1").
+				Where("activity_cohosts.activity_id = chat_rooms.activity_id AND activity_cohosts.user_id = ?", userID),
+			r.db.Model(&model.ActivityParticipant{}).Select("1").
+				Where("activity_participants.activity_id = chat_rooms.activity_id AND activity_participants.user_id = ? AND activity_participants.status = ?", userID, "accepted"),
+		).
+		Order("chat_rooms.last_message_at IS NULL, chat_rooms.last_message_at DESC").
+		Find(&rooms).Error
+	return rooms, err
+}
+
+// --- Messages ---
+
+// CreateMessage stores a message and bumps the room's activity time.
+func (r *chatRepository) CreateMessage(msg *model.ChatMessage) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit(clause.Associations).Create(msg).Error; err != nil {
+			return err
+		}
+		return tx.Model(&model.ChatRoom{}).Where("id = ?", msg.RoomID).
+			UpdateColumn("last_message_at", msg.CreatedAt).Error
+	})
+}
+
+// ListMessages returns up to limit messages older than beforeID (or the
+// newest ones when beforeID is 0), newest first.
+func (r *chatRepository) ListMessages(roomID, beforeID uint, limit int) ([]model.ChatMessage, error) {
+	var messages []model.ChatMessage
+	query := r.db.Preload("Sender").Preload("Sender.Profile").Where("room_id = ?", roomID)
+	if beforeID > 0 {
+		query = query.Where("id < ?", beforeID)
+	}
+	err := query.Order("id DESC").Limit(limit).Find(&messages).Error
+	return messages, err
+}
+
+// MarkRead moves a member's read position forward to messageID. It never
+// moves it backwards.
+func (r *chatRepository) MarkRead(roomID, userID, messageID uint) error {
+	state := model.ChatReadState{RoomID: roomID, UserID: userID, LastReadMessageID: messageID}
+	return r.db.Clauses(clause.OnConflict{
+		Columns: []clause.Column{{Name: "room_id"}, {Name: "user_id"}},
+		DoUpdates: clause.Assignments(map[string]interface{}{
+			"last_read_message_id": gorm.Expr("GREATEST(last_read_message_id, ?)", messageID),
+			"updated_at":           time.Now(),
+		}),
+	}).Create(&state).Error
+}
+
+// CountUnread returns, per room, how many messages from others userID has
+// not read yet.
+func (r *chatRepository) CountUnread(userID uint, roomIDs []uint) (map[uint]int64, error) {
+	result := make(map[uint]int64)
+	if len(roomIDs) == 0 {
+		return result, nil
+	}
+
+	type countRow struct {
+		RoomID uint
+		Count  int64
+	}
+
+	var rows []countRow
+	err := r.db.Model(&model.ChatMessage{}).
+		Select("chat_messages.room_id, COUNT(*) as count").
+		Joins("LEFT JOIN chat_read_states ON chat_read_states.room_id = chat_messages.room_id AND chat_read_states.user_id = ?", userID).
+		Where("chat_messages.room_id IN ? AND chat_messages.sender_id <> ?", roomIDs, userID).
+		Where("chat_messages.id > COALESCE(chat_read_states.last_read_message_id, 0)").
+		Group("chat_messages.room_id").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	for _, row := range rows {
+		result[row.RoomID] = row.Count
+	}
+	return result, nil
+}
@@ -0,0 +1,105 @@
+package repository
+
+import (
+	"azure-magnetar/internal/model"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// CreditRepository defines the interface for work credit database operations.
+type CreditRepository interface {
+	Create(credit *model.WorkCredit) error
+	Get(id uint) (*model.WorkCredit, error)
+	GetByUser(workID, userID uint) (*model.WorkCredit, error)
+	ListByWork(workID uint, status string) ([]model.WorkCredit, error)
+	CountByWork(workID uint) (int64, error)
+	Update(credit *model.WorkCredit) error
+	Delete(id uint) error
+	ListByUser(userID uint, status string) ([]model.WorkCredit, error)
+	ListCreditedWorks(userID uint) ([]model.Post, error)
+}
+
+type creditRepository struct {
+	db *gorm.DB
+}
+
+// NewCreditRepository creates a new CreditRepository.
+func NewCreditRepository(db *gorm.DB) CreditRepository {
+	return &creditRepository{db: db}
+}
+
+func (r *creditRepository) Create(credit *model.WorkCredit) error {
+	return r.db.Omit(clause.Associations).Create(credit).Error
+}
+
+func (r *creditRepository) Get(id uint) (*model.WorkCredit, error) {
+	var credit model.WorkCredit
+	if err := r.db.Preload("Work").First(&credit, id).Error; err != nil {
+		return nil, err
+	}
+	return &credit, nil
+}
+
+func (r *creditRepository) GetByUser(workID, userID uint) (*model.WorkCredit, error) {
+	var credit model.WorkCredit
+	if err := r.db.Where("work_id = ? AND user_id = ?", workID, userID).First(&credit).Error; err != nil {
+		return nil, err
+	}
+	return &credit, nil
+}
+
+// ListByWork returns a work's credits in the order they were added, optionally
+// only those with status.
+func (r *creditRepository) ListByWork(workID uint, status string) ([]model.WorkCredit, error) {
+	var credits []model.WorkCredit
+	query := r.db.Preload("User").Preload("User.Profile").Preload("Activity").
+		Where("work_id = ?", workID)
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+	err := query.Order("id ASC").Find(&credits).Error
+	return credits, err
+}
+
+func (r *creditRepository) CountByWork(workID uint) (int64, error) {
+	var count int64
+	err := r.db.Model(&model.WorkCredit{}).Where("work_id = ?", workID).Count(&count).Error
+	return count, err
+}
+
+// Update saves a credit's status and response time.
+func (r *creditRepository) Update(credit *model.WorkCredit) error {
+	return r.db.Model(credit).Select("status", "responded_at").Updates(credit).Error
+}
+
+func (r *creditRepository) Delete(id uint) error {
+	return r.db.Delete(&model.WorkCredit{}, id).Error
+}
+
+// ListByUser returns the credits a user was tagged in, newest first,
+// optionally only those with status.
+func (r *creditRepository) ListByUser(userID uint, status string) ([]model.WorkCredit, error) {
+	var credits []model.WorkCredit
+	query := r.db.Preload("Work").Preload("Work.Author").Preload("Work.Author.Profile").Preload("Activity").
+		Where("user_id = ?", userID)
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+	err := query.Order("id DESC").Find(&credits).Error
+	return credits, err
+}
+
+// ListCreditedWorks returns works on which userID has an approved credit,
+// newest first.
+func (r *creditRepository) ListCreditedWorks(userID uint) ([]model.Post, error) {
+	var posts []model.Post
+	err := r.db.Preload("Author").Preload("Author.Profile").Preload("Tags").
+		Preload("Credits", "status = ?", model.CreditApproved).Preload("Credits.User").
+		Joins("JOIN work_credits ON work_credits.work_id = posts.id").
+		Where("work_credits.user_id = ? AND work_credits.status = ?", userID, model.CreditApproved).
+		Where("posts.visibility = ?", "public").
+		Order("posts.created_at DESC").
+		Find(&posts).Error
+	return posts, err
+}
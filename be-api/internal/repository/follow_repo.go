@@ -15,6 +15,7 @@ type FollowRepository interface {
 	CountFollowing(userID uint) (int64, error)
 	GetFollowers(userID uint) ([]*model.User, error)
 	GetFollowing(userID uint) ([]*model.User, error)
+	FollowersAmong(userID uint, candidateIDs []uint) (map[uint]bool, error)
 }
 
 type followRepository struct {
@@ -79,3 +80,24 @@ func (r *followRepository) GetFollowing(userID uint) ([]*model.User, error) {
 		Find(&users).Error
 	return users, err
 }
+
+// FollowersAmong reports which of candidateIDs follow userID.
+func (r *followRepository) FollowersAmong(userID uint, candidateIDs []uint) (map[uint]bool, error) {
+	result := make(map[uint]bool)
+	if len(candidateIDs) == 0 {
+		return result, nil
+	}
+
+	var followerIDs []uint
+	err := r.db.Model(&model.Follow{}).
+		Where("following_id = ? AND follower_id IN ?", userID, candidateIDs).
+		Pluck("follower_id", &followerIDs).Error
+	if err != nil {
+		return nil, err
+	}
+
+	for _, id := range followerIDs {
+		result[id] = true
+	}
+	return result, nil
+}
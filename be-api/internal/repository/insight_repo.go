@@ -0,0 +1,178 @@
+package repository
+
+import (
+	"time"
+
+	"azure-magnetar/internal/model"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// InsightCount is one grouped count feeding a daily rollup. Which fields are
+// set depends on the query.
+type InsightCount struct {
+	OwnerID   uint
+	TargetID  uint   // Work ID for work views, impressions and likes
+	Kind      string // View event kind; empty for other counts
+	Anonymous bool
+	Total     int64
+}
+
+// InsightRepository defines the interface for view tracking and creator insights.
+type InsightRepository interface {
+	RecordViews(events []model.ViewEvent) error
+	PruneViews(beforeDay string) (int64, error)
+
+	CountViews(day string) ([]InsightCount, error)
+	CountLikes(start, end time.Time) ([]InsightCount, error)
+	CountNewFollowers(start, end time.Time) ([]InsightCount, error)
+	CountApplications(start, end time.Time) ([]InsightCount, error)
+	ReplaceDay(day string, owners []model.DailyInsight, works []model.WorkDailyInsight) error
+	LastRolledUpDay() (string, error)
+
+	ListDaily(ownerID uint, fromDay, toDay string) ([]model.DailyInsight, error)
+	TopWorks(ownerID uint, fromDay, toDay string, limit int) ([]model.WorkDailyInsight, error)
+}
+
+type insightRepository struct {
+	db *gorm.DB
+}
+
+// NewInsightRepository creates a new InsightRepository.
+func NewInsightRepository(db *gorm.DB) InsightRepository {
+	return &insightRepository{db: db}
+}
+
+// --- Views ---
+
+// RecordViews stores view events, skipping any the viewer already has for
+// that target and day.
+func (r *insightRepository) RecordViews(events []model.ViewEvent) error {
+	if len(events) == 0 {
+		return nil
+	}
+	return r.db.Clauses(clause.OnConflict{DoNothing: true}).Create(&events).Error
+}
+
+// PruneViews deletes view events from days before beforeDay. Their counts
+// live on in the rollups.
+func (r *insightRepository) PruneViews(beforeDay string) (int64, error) {
+	result := r.db.Where("day < ?", beforeDay).Delete(&model.ViewEvent{})
+	return result.RowsAffected, result.Error
+}
+
+// --- Rollups ---
+
+func (r *insightRepository) CountViews(day string) ([]InsightCount, error) {
+	var counts []InsightCount
+	err := r.db.Model(&model.ViewEvent{}).
+		Select("owner_id, target_id, kind, anonymous, COUNT(*) AS total").
+		Where("day = ?", day).
+		Group("owner_id, target_id, kind, anonymous").
+		Scan(&counts).Error
+	return counts, err
+}
+
+// CountLikes counts likes per work created in [start, end), leaving out
+// authors liking their own works.
+func (r *insightRepository) CountLikes(start, end time.Time) ([]InsightCount, error) {
+	var counts []InsightCount
+	err := r.db.Model(&model.Like{}).
+		Select("posts.user_id AS owner_id, likes.work_id AS target_id, COUNT(*) AS total").
+		Joins("JOIN posts ON posts.id = likes.work_id").
+		Where("likes.created_at >= ? AND likes.created_at < ? AND likes.user_id <> posts.user_id", start, end).
+		Group("posts.user_id, likes.work_id").
+		Scan(&counts).Error
+	return counts, err
+}
+
+func (r *insightRepository) CountNewFollowers(start, end time.Time) ([]InsightCount, error) {
+	var counts []InsightCount
+	err := r.db.Model(&model.Follow{}).
+		Select("following_id AS owner_id, COUNT(*) AS total").
+		Where("created_at >= ? AND created_at < ?", start, end).
+		Group("following_id").
+		Scan(&counts).Error
+	return counts, err
+}
+
+// CountApplications counts applications per host made in [start, end).
+// Invitations the host sent are not applications.
+func (r *insightRepository) CountApplications(start, end time.Time) ([]InsightCount, error) {
+	var counts []InsightCount
+	err := r.db.Model(&model.ActivityParticipant{}).
+		Select("activities.host_id AS owner_id, COUNT(*) AS total").
+		Joins("JOIN activities ON activities.id = activity_participants.activity_id").
+		Where("activity_participants.applied_at >= ? AND activity_participants.applied_at < ?", start, end).
+		Where("activity_participants.status <> ?", "invited").
+		Group("activities.host_id").
+		Scan(&counts).Error
+	return counts, err
+}
+
+// ReplaceDay swaps in the rollups for day, so counts that dropped (an
+// unfollow, a deleted like) are not left behind, and marks day as rolled up.
+func (r *insightRepository) ReplaceDay(day string, owners []model.DailyInsight, works []model.WorkDailyInsight) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("day = ?", day).Delete(&model.DailyInsight{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("day = ?", day).Delete(&model.WorkDailyInsight{}).Error; err != nil {
+			return err
+		}
+		if len(owners) > 0 {
+			if err := tx.CreateInBatches(&owners, 500).Error; err != nil {
+				return err
+			}
+		}
+		if len(works) > 0 {
+			if err := tx.CreateInBatches(&works, 500).Error; err != nil {
+				return err
+			}
+		}
+		return tx.Clauses(clause.OnConflict{UpdateAll: true}).
+			Create(&model.InsightRollupDay{Day: day, RolledUpAt: time.Now().UTC()}).Error
+	})
+}
+
+// LastRolledUpDay returns the latest day ReplaceDay has written, or "" if
+// none has been rolled up yet.
+func (r *insightRepository) LastRolledUpDay() (string, error) {
+	var days []string
+	err := r.db.Model(&model.InsightRollupDay{}).
+		Order("day DESC").
+		Limit(1).
+		Pluck("day", &days).Error
+	if err != nil || len(days) == 0 {
+		return "", err
+	}
+	return days[0], nil
+}
+
+// --- Reading ---
+
+// ListDaily returns a creator's rollups for days in [fromDay, toDay], oldest
+// first. Days without activity have no row.
+func (r *insightRepository) ListDaily(ownerID uint, fromDay, toDay string) ([]model.DailyInsight, error) {
+	var days []model.DailyInsight
+	err := r.db.Where("owner_id = ? AND day >= ? AND day <= ?", ownerID, fromDay, toDay).
+		Order("day ASC").
+		Find(&days).Error
+	return days, err
+}
+
+// TopWorks sums each of a creator's works over [fromDay, toDay] and returns
+// the most viewed, with Day left empty.
+func (r *insightRepository) TopWorks(ownerID uint, fromDay, toDay string, limit int) ([]model.WorkDailyInsight, error) {
+	var works []model.WorkDailyInsight
+	err := r.db.Model(&model.WorkDailyInsight{}).
+		Select("work_id, owner_id, SUM(views) AS views, SUM(anonymous_views) AS anonymous_views, "+
+			"SUM(impressions) AS impressions, SUM(likes) AS likes").
+		Where("owner_id = ? AND day >= ? AND day <= ?", ownerID, fromDay, toDay).
+		Group("work_id, owner_id").
+		Order("SUM(views) + SUM(anonymous_views) DESC, SUM(likes) DESC, work_id DESC").
+		Limit(limit).
+		Scan(&works).Error
+	return works, err
+}
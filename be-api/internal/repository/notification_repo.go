@@ -12,6 +12,11 @@ type NotificationRepository interface {
 	GetByUserID(userID uint) ([]model.Notification, error)
 	MarkAsRead(id uint) error
 	GetUnreadCount(userID uint) (int64, error)
+
+	// Preference operations
+	GetPreference(userID uint) (*model.NotificationPreference, error)
+	GetPreferenceByToken(token string) (*model.NotificationPreference, error)
+	SavePreference(pref *model.NotificationPreference) error
 }
 
 type notificationRepository struct {
@@ -50,3 +55,28 @@ func (r *notificationRepository) GetUnreadCount(userID uint) (int64, error) {
 		Count(&count).Error
 	return count, err
 }
+
+// --- Preference operations ---
+
+func (r *notificationRepository) GetPreference(userID uint) (*model.NotificationPreference, error) {
+	var pref model.NotificationPreference
+	if err := r.db.Where("user_id = ?", userID).First(&pref).Error; err != nil {
+		return nil, err
+	}
+	return &pref, nil
+}
+
+func (r *notificationRepository) GetPreferenceByToken(token string) (*model.NotificationPreference, error) {
+	var pref model.NotificationPreference
+	if err := r.db.Where("unsubscribe_token = ?", token).First(&pref).Error; err != nil {
+		return nil, err
+	}
+	return &pref, nil
+}
+
+func (r *notificationRepository) SavePreference(pref *model.NotificationPreference) error {
+	if pref.ID == 0 {
+		return r.db.Create(pref).Error
+	}
+	return r.db.Save(pref).Error
+}
@@ -0,0 +1,143 @@
+package repository
+
+import (
+	"time"
+
+	"azure-magnetar/internal/model"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// TagRepository defines the interface for tag lookup, trending and tag follows.
+type TagRepository interface {
+	GetByName(name string) (*model.Tag, error)
+	Search(prefix string, limit int) ([]model.Tag, error)
+	Trending(since time.Time, limit int) ([]model.Tag, error)
+	CountWorks(tagID uint) (int64, error)
+	CountFollowers(tagID uint) (int64, error)
+
+	Follow(userID, tagID uint) (bool, error)
+	Unfollow(userID, tagID uint) (bool, error)
+	IsFollowing(userID, tagID uint) (bool, error)
+	ListFollowed(userID uint) ([]model.Tag, error)
+}
+
+type tagRepository struct {
+	db *gorm.DB
+}
+
+// NewTagRepository creates a new TagRepository.
+func NewTagRepository(db *gorm.DB) TagRepository {
+	return &tagRepository{db: db}
+}
+
+// tagCount is a tag with the number of public works using it.
+type tagCount struct {
+	ID        uint
+	Name      string
+	CreatedAt time.Time
+	WorkCount int64
+}
+
+func toTags(rows []tagCount) []model.Tag {
+	tags := make([]model.Tag, len(rows))
+	for i, row := range rows {
+		tags[i] = model.Tag{ID: row.ID, Name: row.Name, CreatedAt: row.CreatedAt, WorkCount: row.WorkCount}
+	}
+	return tags
+}
+
+func (r *tagRepository) GetByName(name string) (*model.Tag, error) {
+	var tag model.Tag
+	if err := r.db.Where("name = ?", name).First(&tag).Error; err != nil {
+		return nil, err
+	}
+	return &tag, nil
+}
+
+// Search returns tags whose name starts with prefix, most used first. The
+// prefix must not contain LIKE wildcards.
+func (r *tagRepository) Search(prefix string, limit int) ([]model.Tag, error) {
+	var rows []tagCount
+	err := r.db.Table("tags").
+		Select("tags.id, tags.name, tags.created_at, COUNT(posts.id) AS work_count").
+		Joins("LEFT JOIN post_tags ON post_tags.tag_id = tags.id").
+		Joins("LEFT JOIN posts ON posts.id = post_tags.post_id AND posts.visibility = ?", "public").
+		Where("tags.name LIKE ?", prefix+"%").
+		Group("tags.id, tags.name, tags.created_at").
+		Order("work_count DESC, tags.name ASC").
+		Limit(limit).
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+	return toTags(rows), nil
+}
+
+// Trending returns the tags attached to the most public works since the given
+// time, by when the tag was attached.
+func (r *tagRepository) Trending(since time.Time, limit int) ([]model.Tag, error) {
+	var rows []tagCount
+	err := r.db.Table("post_tags").
+		Select("tags.id, tags.name, tags.created_at, COUNT(*) AS work_count").
+		Joins("JOIN tags ON tags.id = post_tags.tag_id").
+		Joins("JOIN posts ON posts.id = post_tags.post_id").
+		Where("post_tags.created_at >= ? AND posts.visibility = ?", since, "public").
+		Group("tags.id, tags.name, tags.created_at").
+		Order("work_count DESC, tags.id DESC").
+		Limit(limit).
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+	return toTags(rows), nil
+}
+
+func (r *tagRepository) CountWorks(tagID uint) (int64, error) {
+	var count int64
+	err := r.db.Model(&model.PostTag{}).
+		Joins("JOIN posts ON posts.id = post_tags.post_id").
+		Where("post_tags.tag_id = ? AND posts.visibility = ?", tagID, "public").
+		Count(&count).Error
+	return count, err
+}
+
+func (r *tagRepository) CountFollowers(tagID uint) (int64, error) {
+	var count int64
+	err := r.db.Model(&model.TagFollow{}).Where("tag_id = ?", tagID).Count(&count).Error
+	return count, err
+}
+
+// --- Follows ---
+
+// Follow records userID following tagID and reports whether it was new.
+func (r *tagRepository) Follow(userID, tagID uint) (bool, error) {
+	result := r.db.Clauses(clause.OnConflict{DoNothing: true}).
+		Create(&model.TagFollow{UserID: userID, TagID: tagID})
+	return result.RowsAffected > 0, result.Error
+}
+
+// Unfollow removes userID's follow of tagID and reports whether there was one.
+func (r *tagRepository) Unfollow(userID, tagID uint) (bool, error) {
+	result := r.db.Where("user_id = ? AND tag_id = ?", userID, tagID).Delete(&model.TagFollow{})
+	return result.RowsAffected > 0, result.Error
+}
+
+func (r *tagRepository) IsFollowing(userID, tagID uint) (bool, error) {
+	var count int64
+	err := r.db.Model(&model.TagFollow{}).
+		Where("user_id = ? AND tag_id = ?", userID, tagID).
+		Count(&count).Error
+	return count > 0, err
+}
+
+// ListFollowed returns the tags userID follows, most recently followed first.
+func (r *tagRepository) ListFollowed(userID uint) ([]model.Tag, error) {
+	var tags []model.Tag
+	err := r.db.Joins("JOIN tag_follows ON tag_follows.tag_id = tags.id").
+		Where("tag_follows.user_id = ?", userID).
+		Order("tag_follows.created_at DESC").
+		Find(&tags).Error
+	return tags, err
+}
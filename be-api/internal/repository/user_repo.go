@@ -14,6 +14,7 @@ type UserRepository interface {
 	GetByEmail(email string) (*model.User, error)
 	GetByVerificationToken(token string) (*model.User, error)
 	GetByResetToken(token string) (*model.User, error)
+	GetByCalendarToken(token string) (*model.User, error)
 	GetAll() ([]model.User, error)
 	GetProfileByUserID(userID uint) (*model.UserProfile, error)
 	UpdateProfile(profile *model.UserProfile) error
@@ -73,6 +74,14 @@ func (r *userRepository) GetByResetToken(token string) (*model.User, error) {
 	return &user, nil
 }
 
+func (r *userRepository) GetByCalendarToken(token string) (*model.User, error) {
+	var user model.User
+	if err := r.db.Where("calendar_token = ?", token).First(&user).Error; err != nil {
+		return nil, err
+	}
+	return &user, nil
+}
+
 func (r *userRepository) GetProfileByUserID(userID uint) (*model.UserProfile, error) {
 	var profile model.UserProfile
 	if err := r.db.Where("user_id = ?", userID).First(&profile).Error; err != nil {
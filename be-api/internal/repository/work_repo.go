@@ -3,6 +3,7 @@ package repository
 import (
 	"azure-magnetar/internal/model"
 	"fmt"
+	"time"
 
 	"gorm.io/gorm"
 	"gorm.io/gorm/clause"
@@ -14,13 +15,20 @@ type WorkRepository interface {
 	GetByID(id uint, currentUserID uint) (*model.Post, error)
 	Update(post *model.Post) error
 	Delete(id uint) error
-	GetByUserID(userID uint) ([]model.Post, error)
+	GetByUserID(userID uint, visibilities []string) ([]model.Post, error)
 	GetPosts(offset, limit int, seed int64, filterType string, currentUserID uint) ([]model.Post, int64, error)
+	GetFollowingSince(userID uint, since time.Time, limit int) ([]model.Post, error)
+	ListByTag(tagID, beforeID uint, limit int, currentUserID uint) ([]model.Post, error)
+	ListByActivity(activityID, beforeID uint, limit int, currentUserID uint) ([]model.Post, error)
+	CountByActivity(activityID uint) (int64, error)
 	IncrementLikeCount(workID uint) error
 	DecrementLikeCount(workID uint) error
 	IncrementCommentCount(workID uint) error
 	DecrementCommentCount(workID uint) error
 	GetTagsByNames(names []string) ([]model.Tag, error)
+	GetByIDs(ids []uint) ([]model.Post, error)
+	IsFollowing(followerID, followingID uint) (bool, error)
+	SetPinnedWorks(userID uint, workIDs []uint) error
 }
 
 type workRepository struct {
@@ -73,7 +81,10 @@ func (r *workRepository) Create(post *model.Post) error {
 
 func (r *workRepository) GetByID(id uint, currentUserID uint) (*model.Post, error) {
 	var post model.Post
-	if err := r.db.Preload("Author").Preload("Author.Profile").Preload("Tags").First(&post, id).Error; err != nil {
+	if err := r.db.Preload("Author").Preload("Author.Profile").Preload("Tags").
+		Preload("Credits", "status = ?", model.CreditApproved).Preload("Credits.User").Preload("Credits.User.Profile").
+		Preload("Activity").
+		First(&post, id).Error; err != nil {
 		return nil, err
 	}
 
@@ -81,6 +92,12 @@ func (r *workRepository) GetByID(id uint, currentUserID uint) (*model.Post, erro
 		var count int64
 		r.db.Model(&model.Like{}).Where("user_id = ? AND work_id = ?", currentUserID, id).Count(&count)
 		post.IsLiked = count > 0
+
+		var saved int64
+		r.db.Model(&model.Bookmark{}).
+			Where("user_id = ? AND target_type = ? AND target_id = ?", currentUserID, model.BookmarkWork, id).
+			Count(&saved)
+		post.IsSaved = saved > 0
 	}
 
 	return &post, nil
@@ -88,7 +105,7 @@ func (r *workRepository) GetByID(id uint, currentUserID uint) (*model.Post, erro
 
 func (r *workRepository) Update(post *model.Post) error {
 	return r.db.Transaction(func(tx *gorm.DB) error {
-		if err := tx.Omit("Tags").Save(post).Error; err != nil {
+		if err := tx.Omit(clause.Associations).Save(post).Error; err != nil {
 			return err
 		}
 		return r.syncTags(tx, post, post.Tags)
@@ -115,16 +132,50 @@ func (r *workRepository) Delete(id uint) error {
 			return err
 		}
 
+		// Delete all bookmarks of this post
+		if err := tx.Where("target_type = ? AND target_id = ?", model.BookmarkWork, id).Delete(&model.Bookmark{}).Error; err != nil {
+			return err
+		}
+
+		// Remove the post from albums, falling back to the default cover
+		if err := tx.Where("work_id = ?", id).Delete(&model.AlbumWork{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Model(&model.Album{}).Where("cover_work_id = ?", id).
+			Update("cover_work_id", nil).Error; err != nil {
+			return err
+		}
+
+		// Delete all credits on this post
+		if err := tx.Where("work_id = ?", id).Delete(&model.WorkCredit{}).Error; err != nil {
+			return err
+		}
+
+		// Delete its view events and per-work insights; the author's rolled-up totals for past days are kept
+		if err := tx.Where("kind IN ? AND target_id = ?", []string{model.ViewWork, model.ViewWorkImpression}, id).
+			Delete(&model.ViewEvent{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("work_id = ?", id).Delete(&model.WorkDailyInsight{}).Error; err != nil {
+			return err
+		}
+
 		// Delete the post itself
 		return tx.Delete(&model.Post{}, id).Error
 	})
 }
 
-func (r *workRepository) GetByUserID(userID uint) ([]model.Post, error) {
+// GetByUserID returns a user's posts with pinned posts first, in pin order,
+// followed by the rest newest first. A non-empty visibilities limits the
+// result to those visibility levels.
+func (r *workRepository) GetByUserID(userID uint, visibilities []string) ([]model.Post, error) {
 	var posts []model.Post
-	err := r.db.Preload("Author").Preload("Author.Profile").Preload("Tags").
-		Where("user_id = ?", userID).
-		Order("created_at DESC").
+	query := r.db.Preload("Author").Preload("Author.Profile").Preload("Tags").
+		Where("user_id = ?", userID)
+	if len(visibilities) > 0 {
+		query = query.Where("visibility IN ?", visibilities)
+	}
+	err := query.Order("pin_order = 0, pin_order ASC, created_at DESC").
 		Find(&posts).Error
 	return posts, err
 }
@@ -136,9 +187,22 @@ func (r *workRepository) GetPosts(offset, limit int, seed int64, filterType stri
 	randSeed := fmt.Sprintf("RAND(%d)", seed)
 	query := r.db.Model(&model.Post{}).Preload("Author").Preload("Author.Profile").Preload("Tags")
 
+	// The following feed shows posts by followed users, including
+	// followers-only ones, and public posts with a followed tag. Everything
+	// else shows public posts only.
 	if filterType == "following" && currentUserID > 0 {
-		query = query.Joins("JOIN follows ON follows.following_id = posts.user_id").
-			Where("follows.follower_id = ?", currentUserID)
+		query = query.Where(
+			r.db.Where("posts.user_id IN (?) AND posts.visibility IN ?",
+				r.db.Model(&model.Follow{}).Select("following_id").Where("follower_id = ?", currentUserID),
+				[]string{"public", "followers"}).
+				Or("posts.user_id <> ? AND posts.visibility = ? AND posts.id IN (?)",
+					currentUserID, "public",
+					r.db.Model(&model.PostTag{}).Select("post_tags.post_id").
+						Joins("JOIN tag_follows ON tag_follows.tag_id = post_tags.tag_id").
+						Where("tag_follows.user_id = ?", currentUserID)),
+		)
+	} else {
+		query = query.Where("posts.visibility = ?", "public")
 	}
 
 	if err := query.Count(&total).Error; err != nil {
@@ -152,6 +216,12 @@ func (r *workRepository) GetPosts(offset, limit int, seed int64, filterType stri
 		return nil, 0, err
 	}
 
+	r.setViewerFlags(posts, currentUserID)
+	return posts, total, nil
+}
+
+// setViewerFlags fills IsLiked and IsSaved on posts for currentUserID.
+func (r *workRepository) setViewerFlags(posts []model.Post, currentUserID uint) {
 	if currentUserID > 0 && len(posts) > 0 {
 		var likedWorkIDs []uint
 		workIDs := make([]uint, len(posts))
@@ -168,12 +238,77 @@ func (r *workRepository) GetPosts(offset, limit int, seed int64, filterType stri
 			likedMap[id] = true
 		}
 
+		var savedWorkIDs []uint
+		r.db.Model(&model.Bookmark{}).
+			Where("user_id = ? AND target_type = ? AND target_id IN ?", currentUserID, model.BookmarkWork, workIDs).
+			Pluck("target_id", &savedWorkIDs)
+
+		savedMap := make(map[uint]bool)
+		for _, id := range savedWorkIDs {
+			savedMap[id] = true
+		}
+
 		for i := range posts {
 			posts[i].IsLiked = likedMap[posts[i].ID]
+			posts[i].IsSaved = savedMap[posts[i].ID]
 		}
 	}
+}
 
-	return posts, total, nil
+// ListByTag returns public posts with the given tag, newest first. A non-zero
+// beforeID only returns posts older than that ID.
+func (r *workRepository) ListByTag(tagID, beforeID uint, limit int, currentUserID uint) ([]model.Post, error) {
+	var posts []model.Post
+	query := r.db.Preload("Author").Preload("Author.Profile").Preload("Tags").
+		Joins("JOIN post_tags ON post_tags.post_id = posts.id").
+		Where("post_tags.tag_id = ? AND posts.visibility = ?", tagID, "public")
+	if beforeID > 0 {
+		query = query.Where("posts.id < ?", beforeID)
+	}
+	if err := query.Order("posts.id DESC").Limit(limit).Find(&posts).Error; err != nil {
+		return nil, err
+	}
+
+	r.setViewerFlags(posts, currentUserID)
+	return posts, nil
+}
+
+// ListByActivity returns the public posts linked to an activity, newest first.
+// A non-zero beforeID only returns posts older than that ID.
+func (r *workRepository) ListByActivity(activityID, beforeID uint, limit int, currentUserID uint) ([]model.Post, error) {
+	var posts []model.Post
+	query := r.db.Preload("Author").Preload("Author.Profile").Preload("Tags").
+		Where("activity_id = ? AND visibility = ?", activityID, "public")
+	if beforeID > 0 {
+		query = query.Where("id < ?", beforeID)
+	}
+	if err := query.Order("id DESC").Limit(limit).Find(&posts).Error; err != nil {
+		return nil, err
+	}
+
+	r.setViewerFlags(posts, currentUserID)
+	return posts, nil
+}
+
+// CountByActivity counts the public posts linked to an activity.
+func (r *workRepository) CountByActivity(activityID uint) (int64, error) {
+	var count int64
+	err := r.db.Model(&model.Post{}).Where("activity_id = ? AND visibility = ?", activityID, "public").Count(&count).Error
+	return count, err
+}
+
+// GetFollowingSince returns the newest posts created after since by users that
+// userID follows.
+func (r *workRepository) GetFollowingSince(userID uint, since time.Time, limit int) ([]model.Post, error) {
+	var posts []model.Post
+	err := r.db.Preload("Author").Preload("Author.Profile").
+		Joins("JOIN follows ON follows.following_id = posts.user_id").
+		Where("follows.follower_id = ? AND posts.created_at > ?", userID, since).
+		Where("posts.visibility IN ?", []string{"public", "followers"}).
+		Order("posts.created_at DESC").
+		Limit(limit).
+		Find(&posts).Error
+	return posts, err
 }
 
 func (r *workRepository) IncrementLikeCount(workID uint) error {
@@ -208,3 +343,42 @@ func (r *workRepository) GetTagsByNames(names []string) ([]model.Tag, error) {
 	err := r.db.Where("name IN ?", names).Find(&tags).Error
 	return tags, err
 }
+
+// GetByIDs returns the posts with the given IDs, in no particular order.
+func (r *workRepository) GetByIDs(ids []uint) ([]model.Post, error) {
+	var posts []model.Post
+	if len(ids) == 0 {
+		return posts, nil
+	}
+	err := r.db.Where("id IN ?", ids).Find(&posts).Error
+	return posts, err
+}
+
+// IsFollowing reports whether followerID follows followingID, for
+// followers-only posts.
+func (r *workRepository) IsFollowing(followerID, followingID uint) (bool, error) {
+	var count int64
+	err := r.db.Model(&model.Follow{}).
+		Where("follower_id = ? AND following_id = ?", followerID, followingID).
+		Count(&count).Error
+	return count > 0, err
+}
+
+// SetPinnedWorks replaces a user's pinned posts with workIDs, in order.
+func (r *workRepository) SetPinnedWorks(userID uint, workIDs []uint) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&model.Post{}).
+			Where("user_id = ? AND pin_order > 0", userID).
+			UpdateColumn("pin_order", 0).Error; err != nil {
+			return err
+		}
+		for i, id := range workIDs {
+			if err := tx.Model(&model.Post{}).
+				Where("id = ? AND user_id = ?", id, userID).
+				UpdateColumn("pin_order", i+1).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
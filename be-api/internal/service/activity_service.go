@@ -1,84 +1,312 @@
 package service
 
 import (
+	"errors"
 	"fmt"
+	"net/url"
+	"sort"
+	"strconv"
 	"strings"
 	"time"
+	"unicode/utf8"
 
 	"azure-magnetar/internal/model"
 	"azure-magnetar/internal/repository"
 	"azure-magnetar/pkg/apperror"
+	"azure-magnetar/pkg/auth"
+	"azure-magnetar/pkg/email"
+	"azure-magnetar/pkg/logger"
+	"azure-magnetar/pkg/recurrence"
 	"azure-magnetar/pkg/storage"
+	"azure-magnetar/pkg/utils"
 )
 
 // ActivityService defines the interface for activity-related business logic.
 type ActivityService interface {
 	Create(hostID uint, input CreateActivityInput) (*model.Activity, error)
-	GetByID(id uint) (*model.Activity, error)
+	GetByID(id, viewerID uint, inviteToken string) (*model.Activity, error)
 	Update(userID, activityID uint, input UpdateActivityInput) (*model.Activity, error)
 	Cancel(userID, activityID uint, reason string) error
 	Delete(userID, activityID uint) error
 	List(filter repository.ActivityFilter) ([]model.Activity, int64, error)
-	GetByUserID(userID uint) ([]model.Activity, error)
+	GetByUserID(userID, viewerID uint) ([]model.Activity, error)
+
+	// Series
+	CancelSeries(userID, activityID uint, reason string) error
+	GetSeriesOccurrences(activityID, viewerID uint) ([]model.Activity, error)
+	ExtendSeries() error
+
+	// Drafts
+	Publish(activityID, userID uint, input PublishInput) (*model.Activity, error)
+	PublishScheduled() error
+	Duplicate(activityID, userID uint, input DuplicateInput) (*model.Activity, error)
+
+	// Templates
+	SaveTemplate(activityID, userID uint, input SaveTemplateInput) (*model.ActivityTemplate, error)
+	ListTemplates(userID uint) ([]model.ActivityTemplate, error)
+	DeleteTemplate(userID, templateID uint) error
+
+	// Reminders
+	SendReminders() error
 
 	// Participation
-	Apply(activityID, userID uint, message string) error
+	Apply(activityID, userID uint, input ApplyInput) error
 	CancelApplication(activityID, userID uint) error
 	GetUserStatus(activityID, userID uint) (string, error)
-	InviteUser(activityID, hostID, targetID uint, message string) error
+	InviteUser(activityID, hostID uint, input InviteInput) error
+	AcceptInvitation(activityID, userID uint) error
+	DeclineInvitation(activityID, userID uint) error
+
+	// Invite links
+	CreateInviteLink(activityID, hostID uint, input CreateInviteLinkInput) (*model.ActivityInviteLink, error)
+	ListInviteLinks(activityID, hostID uint) ([]model.ActivityInviteLink, error)
+	RevokeInviteLink(activityID, hostID, linkID uint) error
+
+	// Co-hosts
+	AddCoHost(activityID, hostID uint, input CoHostInput) (*model.ActivityCoHost, error)
+	UpdateCoHost(activityID, hostID, userID uint, permission string) (*model.ActivityCoHost, error)
+	RemoveCoHost(activityID, userID, coHostID uint) error
+
+	// Announcements
+	Announce(activityID, userID uint, input AnnounceInput) (*model.ActivityAnnouncement, error)
+	ListAnnouncements(activityID, viewerID uint) ([]model.ActivityAnnouncement, error)
+
+	// Check-in
+	GetCheckInTicket(activityID, userID uint) (*CheckInTicket, error)
+	CheckIn(activityID, hostID uint, input CheckInInput) (*model.ActivityParticipant, error)
+	CloseAttendance() error
 
 	// Host Management
-	ListApplicants(activityID, hostID uint) ([]model.ActivityParticipant, error)
+	ListApplicants(activityID, hostID uint, filter repository.ApplicantFilter) ([]model.ActivityParticipant, error)
 	UpdateApplicantStatus(activityID, hostID, applicantUserID uint, status string) error
+	BatchUpdateApplicantStatus(activityID, hostID uint, input BatchUpdateApplicantsInput) (*BatchUpdateResult, error)
 	RejectApplicant(activityID, hostID, applicantID uint) error
 
 	// Participants (for rating)
 	ListParticipants(activityID uint) ([]model.ActivityParticipant, error)
+	// Works linked to the activity
+	ListWorks(activityID, viewerID uint, query ActivityWorksQuery) (*ActivityWorksPage, error)
 	// User applications
 	GetMyApplications(userID uint) ([]model.ActivityParticipant, error)
 }
 
 // CreateActivityInput represents the data for creating an activity.
 type CreateActivityInput struct {
-	Title           string   `json:"title" binding:"required"`
-	Description     string   `json:"description"`
-	Location        string   `json:"location"`
-	EventTime       string   `json:"eventTime"`
-	MaxParticipants int      `json:"maxParticipants"`
-	Images          []string `json:"images"`
-	Tags            string   `json:"tags"`
-	Roles           []string `json:"roles"`
+	Title           string          `json:"title"` // Required unless the template provides one
+	Description     string          `json:"description"`
+	Location        string          `json:"location"`
+	EventTime       string          `json:"eventTime"` // Read in Timezone unless it carries an offset
+	Timezone        string          `json:"timezone"`  // IANA zone, e.g. Asia/Taipei (default)
+	MaxParticipants int             `json:"maxParticipants"`
+	Images          []string        `json:"images"`
+	Tags            string          `json:"tags"`
+	Roles           []string        `json:"roles"`
+	RoleSlots       []RoleSlotInput `json:"roleSlots"`       // Overrides Roles and MaxParticipants when set
+	Questions       []QuestionInput `json:"questions"`       // Application form shown to applicants
+	Visibility      string          `json:"visibility"`      // public (default), unlisted, private
+	Recurrence      string          `json:"recurrence"`      // Optional RRULE subset, e.g. FREQ=WEEKLY;INTERVAL=1;COUNT=8
+	FreeCancelHours *int            `json:"freeCancelHours"` // Hours before the start after which cancelling counts as late (default 24)
+	Draft           bool            `json:"draft"`           // Save without publishing; only the host and co-hosts can see it
+	TemplateID      uint            `json:"templateId"`      // Saved template filling in any fields left unset
 }
 
 // UpdateActivityInput represents the data for updating an activity.
 type UpdateActivityInput struct {
-	Title           string   `json:"title"`
-	Description     string   `json:"description"`
-	Location        string   `json:"location"`
-	EventTime       string   `json:"eventTime"`
-	MaxParticipants *int     `json:"maxParticipants"`
-	Status          string   `json:"status"`
-	Images          []string `json:"images"`
-	Tags            string   `json:"tags"`
-	Roles           []string `json:"roles"`
+	Title           string          `json:"title"`
+	Description     string          `json:"description"`
+	Location        string          `json:"location"`
+	EventTime       string          `json:"eventTime"` // Read in the activity's timezone unless it carries an offset
+	Timezone        string          `json:"timezone"`  // IANA zone; changing it alone keeps the same instant
+	MaxParticipants *int            `json:"maxParticipants"`
+	Status          string          `json:"status"`
+	Images          []string        `json:"images"`
+	Tags            string          `json:"tags"`
+	Roles           []string        `json:"roles"`
+	RoleSlots       []RoleSlotInput `json:"roleSlots"`       // nil keeps the current slots; an empty list removes them
+	Questions       []QuestionInput `json:"questions"`       // nil keeps the current form; an empty list removes it
+	Visibility      string          `json:"visibility"`      // public, unlisted, private
+	Scope           string          `json:"scope"`           // occurrence (default) or series: apply to every upcoming occurrence
+	FreeCancelHours *int            `json:"freeCancelHours"` // Hours before the start after which cancelling counts as late
+}
+
+// RoleSlotInput describes how many people an activity needs for one role.
+type RoleSlotInput struct {
+	Role  string `json:"role"`
+	Count int    `json:"count"`
+}
+
+// QuestionInput describes one question on an activity's application form.
+type QuestionInput struct {
+	Type     string   `json:"type"` // short_text, choice, number, url, yes_no
+	Label    string   `json:"label"`
+	Options  []string `json:"options"` // Required for type "choice"
+	Required bool     `json:"required"`
 }
 
 // ApplyInput represents the data for applying to an activity.
 type ApplyInput struct {
-	Message string `json:"message"`
+	Message     string        `json:"message"`
+	Role        string        `json:"role"`        // Required when the activity defines role slots
+	Answers     []AnswerInput `json:"answers"`     // Answers to the activity's application form
+	InviteToken string        `json:"inviteToken"` // Signed invite link token; required for private activities
+}
+
+// AnswerInput is an applicant's answer to one application question.
+type AnswerInput struct {
+	QuestionID uint   `json:"questionId"`
+	Value      string `json:"value"`
 }
 
 // InviteInput represents the data for inviting a user.
 type InviteInput struct {
 	UserID  uint   `json:"userId" binding:"required"`
 	Message string `json:"message"`
+	Role    string `json:"role"` // Required when the activity defines role slots
+}
+
+// CreateInviteLinkInput represents the options for a shareable invite link.
+type CreateInviteLinkInput struct {
+	Mode      string `json:"mode"`      // apply (default) or auto_accept
+	MaxUses   int    `json:"maxUses"`   // 0 = unlimited
+	ExpiresAt string `json:"expiresAt"` // Optional; defaults to the event time
+}
+
+// PublishInput publishes a draft now or schedules it.
+type PublishInput struct {
+	PublishAt string `json:"publishAt"` // Optional; read in the activity's timezone unless it carries an offset
+}
+
+// DuplicateInput sets the event time of a duplicated activity.
+type DuplicateInput struct {
+	EventTime string `json:"eventTime"` // Optional; read in the source activity's timezone unless it carries an offset
+}
+
+// SaveTemplateInput names a template saved from an activity.
+type SaveTemplateInput struct {
+	Name string `json:"name" binding:"required"`
+}
+
+// CoHostInput adds a co-host to an activity.
+type CoHostInput struct {
+	UserID     uint   `json:"userId" binding:"required"`
+	Permission string `json:"permission" binding:"required"` // manage_applicants, edit_details, full
+}
+
+// UpdateCoHostInput changes a co-host's permission level.
+type UpdateCoHostInput struct {
+	Permission string `json:"permission" binding:"required"`
+}
+
+// AnnounceInput is a message broadcast to an activity's participants.
+type AnnounceInput struct {
+	Message        string `json:"message" binding:"required"`
+	IncludePending bool   `json:"includePending"` // Also reach applicants still awaiting review
+}
+
+// CheckInInput identifies who is being checked in: a scanned QR token or, as a
+// manual fallback, the participant's user ID.
+type CheckInInput struct {
+	Token  string `json:"token"`
+	UserID uint   `json:"userId"`
+}
+
+// CheckInTicket is the signed token a participant shows as a QR code at the event.
+type CheckInTicket struct {
+	ActivityID uint      `json:"activityId"`
+	Token      string    `json:"token"`
+	ExpiresAt  time.Time `json:"expiresAt"` // End of the event day; rescheduling also invalidates the token
+}
+
+// ActivityWorksQuery selects a page of an activity's works.
+type ActivityWorksQuery struct {
+	BeforeID uint
+	Limit    int
+}
+
+// ActivityWorksPage is a page of an activity's public works, newest first. Pass
+// NextBefore as ?before= to load older works; it is 0 when there are none.
+type ActivityWorksPage struct {
+	Items      []model.Post `json:"items"`
+	NextBefore uint         `json:"nextBefore"`
 }
 
+// seriesHorizon is how far ahead recurring occurrences are created.
+const seriesHorizon = 8 * 7 * 24 * time.Hour
+
+// reminderLeadTime is how long before the event participants are reminded.
+const reminderLeadTime = 24 * time.Hour
+
+// checkInOpensBefore is how long before the event check-in opens.
+const checkInOpensBefore = 2 * time.Hour
+
+// attendanceGracePeriod is how long after the start QR check-in stays open;
+// accepted participants who have not checked in by then are marked no_show.
+const attendanceGracePeriod = 12 * time.Hour
+
+// attendanceLookback limits CloseAttendance to recent activities so events held
+// before check-in existed are not retroactively marked.
+const attendanceLookback = 7 * 24 * time.Hour
+
+// defaultFreeCancelHours and maxFreeCancelHours bound an activity's free-cancel window.
+const (
+	defaultFreeCancelHours = 24
+	maxFreeCancelHours     = 30 * 24
+)
+
+// maxTemplatesPerUser caps how many activity templates a user can keep.
+const maxTemplatesPerUser = 50
+
+// galleryPreviewSize is how many linked works an ended activity's detail shows.
+const galleryPreviewSize = 12
+
+// defaultActivityWorksPageSize and maxActivityWorksPageSize bound a page of an
+// activity's works.
+const (
+	defaultActivityWorksPageSize = 20
+	maxActivityWorksPageSize     = 50
+)
+
+// maxAnnouncementLength caps the length of an announcement message.
+const maxAnnouncementLength = 1000
+
+// inviteTTL is how long an invitation stays valid, capped at the event time.
+const inviteTTL = 7 * 24 * time.Hour
+
 // UpdateApplicantStatusInput represents the status update body.
 type UpdateApplicantStatusInput struct {
 	Status string `json:"status" binding:"required"`
 }
 
+// BatchUpdateApplicantsInput accepts or rejects several applicants at once.
+// Message, when set, is appended to each applicant's notification.
+type BatchUpdateApplicantsInput struct {
+	UserIDs []uint `json:"userIds" binding:"required"`
+	Status  string `json:"status" binding:"required"`
+	Message string `json:"message"`
+}
+
+// BatchUpdateResult reports which applicants a batch review changed. Skipped
+// applicants already had the requested status.
+type BatchUpdateResult struct {
+	Updated []uint `json:"updated"`
+	Skipped []uint `json:"skipped"`
+}
+
+// maxBatchApplicants caps how many applicants one batch review can touch.
+const maxBatchApplicants = 100
+
+// maxDecisionMessageLength caps the custom message sent with a batch review.
+const maxDecisionMessageLength = 500
+
+// applicantSorts lists the supported ApplicantFilter.Sort values.
+var applicantSorts = map[string]bool{
+	"":            true,
+	"applied":     true,
+	"applied_asc": true,
+	"rating":      true,
+	"followers":   true,
+}
+
 type activityService struct {
 	repo         repository.ActivityRepository
 	commentRepo  repository.CommentRepository
@@ -86,22 +314,46 @@ type activityService struct {
 	gcsBucket    string
 	notifService NotificationService
 	ratingRepo   repository.RatingRepository
+	userRepo     repository.UserRepository
+	chatRepo     repository.ChatRepository
+	followRepo   repository.FollowRepository
+	workRepo     repository.WorkRepository
+	frontendURL  string
+	linkSecret   string
 }
 
-// NewActivityService creates a new ActivityService.
-func NewActivityService(repo repository.ActivityRepository, commentRepo repository.CommentRepository, ratingRepo repository.RatingRepository, apiBaseURL, gcsBucket string, notifService NotificationService) ActivityService {
+// NewActivityService creates a new ActivityService. linkSecret signs shareable
+// invite links, which point at frontendURL.
+func NewActivityService(repo repository.ActivityRepository, commentRepo repository.CommentRepository, ratingRepo repository.RatingRepository, userRepo repository.UserRepository, chatRepo repository.ChatRepository, followRepo repository.FollowRepository, workRepo repository.WorkRepository, apiBaseURL, gcsBucket string, notifService NotificationService, frontendURL, linkSecret string) ActivityService {
 	return &activityService{
 		repo:         repo,
 		commentRepo:  commentRepo,
 		ratingRepo:   ratingRepo,
+		userRepo:     userRepo,
+		chatRepo:     chatRepo,
+		followRepo:   followRepo,
+		workRepo:     workRepo,
 		apiBaseURL:   apiBaseURL,
 		gcsBucket:    gcsBucket,
 		notifService: notifService,
+		frontendURL:  frontendURL,
+		linkSecret:   linkSecret,
 	}
 }
 
 func (s *activityService) Create(hostID uint, input CreateActivityInput) (*model.Activity, error) {
 	var imageURLs []string
+	if input.TemplateID != 0 {
+		template, err := s.repo.GetTemplate(input.TemplateID)
+		if err != nil || template.UserID != hostID {
+			return nil, apperror.New(apperror.CodeNotFound, "template not found")
+		}
+		imageURLs = applyTemplate(&input, template)
+	}
+	if strings.TrimSpace(input.Title) == "" {
+		return nil, apperror.New(apperror.CodeValidation, "title is required")
+	}
+
 	if len(input.Images) > 0 {
 		for i, imgBase64 := range input.Images {
 			url, err := storage.SaveBase64Image(s.apiBaseURL, s.gcsBucket, "activities", hostID, imgBase64, i)
@@ -112,39 +364,126 @@ func (s *activityService) Create(hostID uint, input CreateActivityInput) (*model
 		}
 	}
 
+	timezone, loc, err := loadTimezone(input.Timezone)
+	if err != nil {
+		return nil, err
+	}
+
 	var eventTime time.Time
 	if input.EventTime != "" {
-		parsedTime, err := parseEventTime(input.EventTime)
+		parsedTime, err := parseEventTime(input.EventTime, loc)
 		if err != nil {
 			return nil, fmt.Errorf("invalid event time format: %w", err)
 		}
 		eventTime = parsedTime
 	}
 
+	slots, err := buildRoleSlots(input.RoleSlots)
+	if err != nil {
+		return nil, err
+	}
+
+	questions, err := buildQuestions(input.Questions)
+	if err != nil {
+		return nil, err
+	}
+
+	freeCancelHours := defaultFreeCancelHours
+	if input.FreeCancelHours != nil {
+		if err := validateFreeCancelHours(*input.FreeCancelHours); err != nil {
+			return nil, err
+		}
+		freeCancelHours = *input.FreeCancelHours
+	}
+
+	visibility := input.Visibility
+	if visibility == "" {
+		visibility = "public"
+	}
+	if !activityVisibilities[visibility] {
+		return nil, apperror.Newf(apperror.CodeValidation, "unsupported visibility %q", visibility)
+	}
+
+	var series *model.ActivitySeries
+	if input.Recurrence != "" {
+		if input.Draft {
+			return nil, apperror.New(apperror.CodeValidation, "recurring activities cannot be saved as drafts")
+		}
+		rule, err := recurrence.Parse(input.Recurrence)
+		if err != nil {
+			return nil, apperror.Wrap(apperror.CodeValidation, err.Error(), err)
+		}
+		if eventTime.IsZero() {
+			return nil, apperror.New(apperror.CodeValidation, "recurring activities need an event time")
+		}
+		series = &model.ActivitySeries{
+			HostID:    hostID,
+			Rule:      rule.String(),
+			StartTime: eventTime,
+			LastSlot:  eventTime,
+			Status:    "active",
+		}
+	}
+
 	activity := &model.Activity{
 		HostID:          hostID,
 		Title:           input.Title,
 		Description:     input.Description,
 		Location:        input.Location,
 		EventTime:       eventTime,
+		Timezone:        timezone,
 		MaxParticipants: input.MaxParticipants,
 		Status:          "open",
+		Visibility:      visibility,
 		Images:          imageURLs,
 		Tags:            input.Tags,
 		Roles:           input.Roles,
+		Questions:       questions,
+		FreeCancelHours: freeCancelHours,
+	}
+	applyRoleSlots(activity, slots)
+	if input.Draft {
+		activity.Status = "draft"
+	} else {
+		now := time.Now()
+		activity.PublishedAt = &now
+	}
+
+	if series != nil {
+		setSeriesTemplate(series, activity)
+		if err := s.repo.CreateSeries(series); err != nil {
+			return nil, fmt.Errorf("failed to create activity series: %w", err)
+		}
+		activity.SeriesID = &series.ID
 	}
 
 	if err := s.repo.Create(activity); err != nil {
 		return nil, fmt.Errorf("failed to create activity: %w", err)
 	}
 
+	if series != nil {
+		if err := s.extendSeries(series); err != nil {
+			logger.Warn("failed to create upcoming series occurrences", "seriesID", series.ID, "error", err)
+		}
+	}
+
 	return activity, nil
 }
 
-func (s *activityService) GetByID(id uint) (*model.Activity, error) {
+func (s *activityService) GetByID(id, viewerID uint, inviteToken string) (*model.Activity, error) {
 	activity, err := s.repo.GetByID(id)
 	if err != nil {
-		return nil, err
+		return nil, apperror.New(apperror.CodeNotFound, "activity not found")
+	}
+
+	if !canViewActivity(s.repo, activity, viewerID) {
+		// Holders of a valid invite link may view private activities, but not drafts
+		if activity.Status == "draft" {
+			return nil, apperror.New(apperror.CodeNotFound, "activity not found")
+		}
+		if _, err := s.resolveInviteLink(activity, inviteToken); err != nil {
+			return nil, apperror.New(apperror.CodeNotFound, "activity not found")
+		}
 	}
 
 	// BE-H2 CQS fix: status auto-transition is applied lazily in List/GetByUserID.
@@ -155,9 +494,41 @@ func (s *activityService) GetByID(id uint) (*model.Activity, error) {
 		activity.Host.AverageRating = avg
 	}
 
+	// Show what came out of the shoot once it is over
+	if hasActivityEnded(activity) {
+		s.loadGallery(activity)
+	}
+
 	return activity, nil
 }
 
+// loadGallery fills an activity's gallery preview with its newest linked works.
+func (s *activityService) loadGallery(activity *model.Activity) {
+	count, err := s.workRepo.CountByActivity(activity.ID)
+	if err != nil || count == 0 {
+		return
+	}
+	works, err := s.workRepo.ListByActivity(activity.ID, 0, galleryPreviewSize, 0)
+	if err != nil {
+		logger.Warn("failed to load activity gallery", "activityID", activity.ID, "error", err)
+		return
+	}
+	activity.Gallery = works
+	activity.GalleryCount = count
+}
+
+// hasActivityEnded reports whether an activity took place: it is marked ended,
+// or it is still open or full but its event time has passed.
+func hasActivityEnded(activity *model.Activity) bool {
+	if activity.Status == "ended" {
+		return true
+	}
+	if activity.Status != "open" && activity.Status != "full" {
+		return false
+	}
+	return !activity.EventTime.IsZero() && time.Now().After(activity.EventTime)
+}
+
 // autoEndIfExpired checks if an activity's event time has passed and
 // transitions its status to "ended" if it's still "open" or "full".
 func (s *activityService) autoEndIfExpired(activity *model.Activity) {
@@ -181,11 +552,55 @@ func (s *activityService) Update(userID, activityID uint, input UpdateActivityIn
 	if err != nil {
 		return nil, apperror.New(apperror.CodeNotFound, "activity not found")
 	}
-	// Verify host
-	if activity.HostID != userID {
-		return nil, apperror.New(apperror.CodeForbidden, "only the host can update this activity")
+	perm := model.CoHostEditDetails
+	switch {
+	case input.Scope == "series":
+		perm = permOwner // touches occurrences co-hosts may not manage
+	case input.Status != "":
+		perm = model.CoHostFull // a status change can end or cancel the activity
+	}
+	if err := s.authorize(activity, userID, perm); err != nil {
+		return nil, err
+	}
+
+	if input.Scope == "series" {
+		return s.updateSeries(userID, activity, input)
+	}
+
+	if err := s.applyUpdate(userID, activity, input); err != nil {
+		return nil, err
+	}
+	return activity, nil
+}
+
+// applyUpdate applies the non-empty fields of input to activity and saves it.
+func (s *activityService) applyUpdate(userID uint, activity *model.Activity, input UpdateActivityInput) error {
+	update, err := s.editActivity(userID, activity, input)
+	if err != nil {
+		return err
+	}
+
+	if update.ReplaceRoleSlots {
+		if err := s.repo.ReplaceRoleSlots(activity.ID, activity.RoleSlots); err != nil {
+			return fmt.Errorf("failed to update role slots: %w", err)
+		}
+	}
+	if update.ReplaceQuestions {
+		if err := s.repo.ReplaceQuestions(activity.ID, activity.Questions); err != nil {
+			return fmt.Errorf("failed to update application questions: %w", err)
+		}
+	}
+	if err := s.repo.Update(activity); err != nil {
+		return fmt.Errorf("failed to update activity: %w", err)
 	}
 
+	return nil
+}
+
+// editActivity applies the non-empty fields of input to activity without
+// saving it, reporting which child rows the caller must replace.
+func (s *activityService) editActivity(userID uint, activity *model.Activity, input UpdateActivityInput) (repository.ActivityUpdate, error) {
+	update := repository.ActivityUpdate{Activity: activity}
 	if input.Title != "" {
 		activity.Title = input.Title
 	}
@@ -195,21 +610,31 @@ func (s *activityService) Update(userID, activityID uint, input UpdateActivityIn
 	if input.Location != "" {
 		activity.Location = input.Location
 	}
+	if input.Timezone != "" {
+		timezone, _, err := loadTimezone(input.Timezone)
+		if err != nil {
+			return update, err
+		}
+		activity.Timezone = timezone
+	}
 	if input.EventTime != "" {
-		t, err := parseEventTime(input.EventTime)
+		t, err := parseEventTime(input.EventTime, activity.Zone())
 		if err != nil {
-			return nil, fmt.Errorf("invalid event time format: %w", err)
+			return update, fmt.Errorf("invalid event time format: %w", err)
+		}
+		if !t.Equal(activity.EventTime) {
+			activity.ReminderSentAt = nil
 		}
 		activity.EventTime = t
 	}
 	if input.MaxParticipants != nil {
 		activity.MaxParticipants = *input.MaxParticipants
-		if activity.Status != "open" && activity.Status != "cancelled" && activity.Status != "ended" && activity.MaxParticipants > int(activity.CurrentParticipants) {
-			activity.Status = "open"
-		}
 	}
-	if input.Status != "" {
-		activity.Status = input.Status
+	if input.FreeCancelHours != nil {
+		if err := validateFreeCancelHours(*input.FreeCancelHours); err != nil {
+			return update, err
+		}
+		activity.FreeCancelHours = *input.FreeCancelHours
 	}
 	if len(input.Images) > 0 {
 		var imageURLs []string
@@ -220,7 +645,7 @@ func (s *activityService) Update(userID, activityID uint, input UpdateActivityIn
 			} else {
 				url, err := storage.SaveBase64Image(s.apiBaseURL, s.gcsBucket, "activities", userID, imgStr, i)
 				if err != nil {
-					return nil, fmt.Errorf("failed to update image %d: %w", i, err)
+					return update, fmt.Errorf("failed to update image %d: %w", i, err)
 				}
 				imageURLs = append(imageURLs, url)
 			}
@@ -230,15 +655,56 @@ func (s *activityService) Update(userID, activityID uint, input UpdateActivityIn
 	if input.Tags != "" {
 		activity.Tags = input.Tags
 	}
+	if input.Visibility != "" {
+		if !activityVisibilities[input.Visibility] {
+			return update, apperror.Newf(apperror.CodeValidation, "unsupported visibility %q", input.Visibility)
+		}
+		activity.Visibility = input.Visibility
+	}
 	if len(input.Roles) > 0 {
 		activity.Roles = input.Roles
 	}
+	if input.RoleSlots != nil {
+		slots, err := buildRoleSlots(input.RoleSlots)
+		if err != nil {
+			return update, err
+		}
+		if err := checkRoleSlotsCoverAccepted(activity, slots); err != nil {
+			return update, err
+		}
+		applyRoleSlots(activity, slots)
+		s.refreshCounts(activity)
+		update.ReplaceRoleSlots = true
+	} else {
+		applyRoleSlots(activity, activity.RoleSlots)
+	}
+	if input.Questions != nil {
+		questions, err := buildQuestions(input.Questions)
+		if err != nil {
+			return update, err
+		}
+		activity.Questions = questions
+		update.ReplaceQuestions = true
+	}
 
-	if err := s.repo.Update(activity); err != nil {
-		return nil, fmt.Errorf("failed to update activity: %w", err)
+	if input.Status != "" {
+		if activity.Status == "draft" || input.Status == "draft" {
+			return update, apperror.New(apperror.CodeValidation, "drafts are published through the publish endpoint")
+		}
+		activity.Status = input.Status
+	} else {
+		refreshCapacityStatus(activity)
 	}
 
-	return activity, nil
+	// A scheduled draft must stay publishable
+	if activity.Status == "draft" && activity.PublishAt != nil {
+		if err := validateForPublish(activity, *activity.PublishAt); err != nil {
+			return update, err
+		}
+	}
+
+	activity.Sequence++
+	return update, nil
 }
 
 func (s *activityService) Cancel(userID, activityID uint, reason string) error {
@@ -247,8 +713,8 @@ func (s *activityService) Cancel(userID, activityID uint, reason string) error {
 		return apperror.New(apperror.CodeNotFound, "activity not found")
 	}
 
-	if activity.HostID != userID {
-		return apperror.New(apperror.CodeForbidden, "only the host can cancel this activity")
+	if err := s.authorize(activity, userID, model.CoHostFull); err != nil {
+		return err
 	}
 
 	if activity.Status == "cancelled" {
@@ -262,6 +728,7 @@ func (s *activityService) Cancel(userID, activityID uint, reason string) error {
 
 	// Update status
 	activity.Status = "cancelled"
+	activity.Sequence++
 	if err := s.repo.Update(activity); err != nil {
 		return fmt.Errorf("failed to cancel activity: %w", err)
 	}
@@ -287,8 +754,8 @@ func (s *activityService) Delete(userID, activityID uint) error {
 		return apperror.New(apperror.CodeNotFound, "activity not found")
 	}
 
-	if activity.HostID != userID {
-		return apperror.New(apperror.CodeForbidden, "only the host can delete this activity")
+	if err := s.authorize(activity, userID, model.CoHostFull); err != nil {
+		return err
 	}
 
 	return s.repo.Delete(activityID)
@@ -305,201 +772,2201 @@ func (s *activityService) List(filter repository.ActivityFilter) ([]model.Activi
 	return activities, total, nil
 }
 
-func (s *activityService) GetByUserID(userID uint) ([]model.Activity, error) {
+func (s *activityService) GetByUserID(userID, viewerID uint) ([]model.Activity, error) {
 	activities, err := s.repo.GetByUserID(userID)
 	if err != nil {
 		return nil, err
 	}
+
+	// Other viewers only see unlisted and private activities they are part of,
+	// and drafts only show to their hosts
+	visible := activities[:0]
 	for i := range activities {
-		s.autoEndIfExpired(&activities[i])
+		a := &activities[i]
+		if a.Status == "draft" && !isActivityHost(s.repo, a, viewerID) {
+			continue
+		}
+		if viewerID == userID || a.Visibility == "public" || isActivityMember(s.repo, a, viewerID) {
+			visible = append(visible, activities[i])
+		}
+	}
+
+	for i := range visible {
+		s.autoEndIfExpired(&visible[i])
 	}
-	return activities, nil
+	return visible, nil
 }
 
-// --- Participation ---
+// --- Series ---
 
-func (s *activityService) Apply(activityID, userID uint, message string) error {
-	activity, err := s.repo.GetByID(activityID)
+// updateSeries applies input to activity and to every upcoming occurrence of its
+// series, and stores the result as the series template for future occurrences.
+// A changed event time shifts all of them, and the rule grid, by the same
+// offset. Everything is saved in one transaction.
+func (s *activityService) updateSeries(userID uint, activity *model.Activity, input UpdateActivityInput) (*model.Activity, error) {
+	if activity.SeriesID == nil {
+		return nil, apperror.New(apperror.CodeValidation, "activity is not part of a series")
+	}
+	series, err := s.repo.GetSeries(*activity.SeriesID)
 	if err != nil {
-		return apperror.New(apperror.CodeNotFound, "activity not found")
+		return nil, apperror.New(apperror.CodeNotFound, "activity series not found")
 	}
-
-	if activity.HostID == userID {
-		return apperror.New(apperror.CodeConflict, "host cannot apply to their own activity")
+	occurrences, err := s.repo.ListSeriesOccurrences(series.ID)
+	if err != nil {
+		return nil, err
 	}
 
-	// Sync status with reality (Self-healing)
-	if activity.Status != "cancelled" && activity.Status != "ended" {
-		currentCount := int(activity.CurrentParticipants)
-		if activity.MaxParticipants > currentCount && activity.Status == "full" {
-			activity.Status = "open"
-			_ = s.repo.Update(activity)
-		} else if activity.MaxParticipants <= currentCount && activity.Status == "open" {
-			activity.Status = "full"
-			_ = s.repo.Update(activity)
-		}
+	original := activity.EventTime
+	update, err := s.editActivity(userID, activity, input)
+	if err != nil {
+		return nil, err
 	}
+	updates := []repository.ActivityUpdate{update}
+	shift := activity.EventTime.Sub(original)
 
-	if activity.Status != "open" {
-		return apperror.New(apperror.CodeConflict, "activity is not open for applications")
+	// Siblings reuse the already stored image URLs instead of re-uploading
+	rest := input
+	rest.EventTime = ""
+	rest.Status = ""
+	if len(input.Images) > 0 {
+		rest.Images = activity.Images
 	}
 
-	if existing, _ := s.repo.GetParticipant(activityID, userID); existing != nil {
-		return apperror.New(apperror.CodeConflict, "already applied to this activity")
+	now := time.Now()
+	for _, occ := range occurrences {
+		if occ.ID == activity.ID || !isUpcoming(&occ, now) {
+			continue
+		}
+		sibling, err := s.repo.GetByID(occ.ID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load occurrence %d: %w", occ.ID, err)
+		}
+		if shift != 0 {
+			sibling.EventTime = sibling.EventTime.Add(shift)
+			sibling.ReminderSentAt = nil
+		}
+		update, err := s.editActivity(userID, sibling, rest)
+		if err != nil {
+			return nil, err
+		}
+		updates = append(updates, update)
 	}
 
-	participant := &model.ActivityParticipant{
-		ActivityID: activityID,
-		UserID:     userID,
-		Status:     "pending",
-		Message:    message,
+	setSeriesTemplate(series, activity)
+	if shift != 0 {
+		series.StartTime = series.StartTime.Add(shift)
+		series.LastSlot = series.LastSlot.Add(shift)
 	}
-
-	if err := s.repo.CreateParticipant(participant); err != nil {
-		return err
+	if err := s.repo.UpdateSeriesOccurrences(series, updates); err != nil {
+		return nil, fmt.Errorf("failed to update activity series: %w", err)
 	}
 
-	// Send notification to host
-	_ = s.notifService.SendNotification(activity.HostID, userID, "join_request", fmt.Sprintf("%d", activityID), activity.Title)
-
-	return nil
+	return activity, nil
 }
 
-func (s *activityService) InviteUser(activityID, hostID, targetID uint, message string) error {
-	// 1. Check if activity exists
+// CancelSeries stops a series and cancels each of its upcoming occurrences
+// through Cancel, which notifies their participants. Occurrences that can no
+// longer be cancelled (e.g. starting within 12 hours) are left as they are.
+func (s *activityService) CancelSeries(userID, activityID uint, reason string) error {
 	activity, err := s.repo.GetByID(activityID)
 	if err != nil {
-		return err
+		return apperror.New(apperror.CodeNotFound, "activity not found")
 	}
-
-	// 2. Check if hostID is the owner
-	if activity.HostID != hostID {
-		return apperror.New(apperror.CodeForbidden, "only the host can invite users")
+	if err := s.authorize(activity, userID, permOwner); err != nil {
+		return err
 	}
-
-	// 3. Send Notification
-	// Type: "invitation", ReferenceID: activityID, Content: custom message or default
-	notifContent := fmt.Sprintf("邀請你參加活動：%s", activity.Title)
-	if message != "" {
-		notifContent = fmt.Sprintf("%s - 邀請訊息：%s", notifContent, message)
+	if activity.SeriesID == nil {
+		return apperror.New(apperror.CodeValidation, "activity is not part of a series")
 	}
 
-	return s.notifService.SendNotification(targetID, hostID, "invitation", fmt.Sprintf("%d", activityID), notifContent)
-}
-
-func (s *activityService) CancelApplication(activityID, userID uint) error {
-	return s.repo.DeleteParticipant(activityID, userID)
-}
-
-func (s *activityService) RejectApplicant(activityID, hostID, applicantID uint) error {
-	return s.UpdateApplicantStatus(activityID, hostID, applicantID, "rejected")
-}
-
-func (s *activityService) GetUserStatus(activityID, userID uint) (string, error) {
-	activity, err := s.repo.GetByID(activityID)
+	series, err := s.repo.GetSeries(*activity.SeriesID)
 	if err != nil {
-		return "", apperror.New(apperror.CodeNotFound, "activity not found")
+		return apperror.New(apperror.CodeNotFound, "activity series not found")
+	}
+	if series.Status == "cancelled" {
+		return apperror.New(apperror.CodeConflict, "series is already cancelled")
 	}
 
-	if activity.HostID == userID {
-		return "host", nil
+	series.Status = "cancelled"
+	if err := s.repo.UpdateSeries(series); err != nil {
+		return fmt.Errorf("failed to cancel activity series: %w", err)
 	}
 
-	participant, err := s.repo.GetParticipant(activityID, userID)
+	occurrences, err := s.repo.ListSeriesOccurrences(series.ID)
 	if err != nil {
-		return "idle", nil
+		return err
+	}
+	now := time.Now()
+	for _, occ := range occurrences {
+		if !isUpcoming(&occ, now) {
+			continue
+		}
+		if err := s.Cancel(userID, occ.ID, reason); err != nil {
+			logger.Warn("skipped cancelling series occurrence", "seriesID", series.ID, "activityID", occ.ID, "error", err)
+		}
 	}
 
-	return participant.Status, nil
+	return nil
 }
 
-// --- Host Management ---
-
-func (s *activityService) ListApplicants(activityID, hostID uint) ([]model.ActivityParticipant, error) {
+func (s *activityService) GetSeriesOccurrences(activityID, viewerID uint) ([]model.Activity, error) {
 	activity, err := s.repo.GetByID(activityID)
-	if err != nil {
+	if err != nil || !canViewActivity(s.repo, activity, viewerID) {
 		return nil, apperror.New(apperror.CodeNotFound, "activity not found")
 	}
-
-	if activity.HostID != hostID {
-		return nil, apperror.New(apperror.CodeForbidden, "only the host can view applicants")
+	if activity.SeriesID == nil {
+		return []model.Activity{*activity}, nil
 	}
 
-	applicants, err := s.repo.ListApplicants(activityID)
+	occurrences, err := s.repo.ListSeriesOccurrences(*activity.SeriesID)
 	if err != nil {
 		return nil, err
 	}
 
-	for i := range applicants {
-		// Populate average rating
-		if avg, err := s.ratingRepo.GetAverageByUserID(applicants[i].UserID); err == nil {
-			applicants[i].User.AverageRating = avg
+	visible := occurrences[:0]
+	for i := range occurrences {
+		if canViewActivity(s.repo, &occurrences[i], viewerID) {
+			s.autoEndIfExpired(&occurrences[i])
+			visible = append(visible, occurrences[i])
 		}
 	}
-
-	return applicants, nil
+	return visible, nil
 }
 
-func (s *activityService) UpdateApplicantStatus(activityID, hostID, applicantUserID uint, status string) error {
-	if status != "accepted" && status != "rejected" {
-		return apperror.New(apperror.CodeValidation, "status must be 'accepted' or 'rejected'")
-	}
-
-	activity, err := s.repo.GetByID(activityID)
+// ExtendSeries creates upcoming occurrences for every active series so that
+// each one always has occurrences scheduled seriesHorizon ahead.
+func (s *activityService) ExtendSeries() error {
+	seriesList, err := s.repo.ListActiveSeries()
 	if err != nil {
-		return apperror.New(apperror.CodeNotFound, "activity not found")
+		return err
 	}
 
-	if activity.HostID != hostID {
-		return apperror.New(apperror.CodeForbidden, "only the host can manage applicants")
+	var errs []error
+	for i := range seriesList {
+		if err := s.extendSeries(&seriesList[i]); err != nil {
+			errs = append(errs, fmt.Errorf("series %d: %w", seriesList[i].ID, err))
+		}
 	}
+	return errors.Join(errs...)
+}
 
-	participant, err := s.repo.GetParticipant(activityID, applicantUserID)
+// extendSeries creates an occurrence from the series template for each rule
+// slot after series.LastSlot up to seriesHorizon. Slots are taken from the
+// rule grid, so moving or deleting an occurrence never brings its slot back,
+// and a slot that already has an occurrence is skipped.
+func (s *activityService) extendSeries(series *model.ActivitySeries) error {
+	rule, err := recurrence.Parse(series.Rule)
 	if err != nil {
-		return apperror.New(apperror.CodeNotFound, "applicant not found")
+		return err
+	}
+	occurrences, err := s.repo.ListSeriesOccurrences(series.ID)
+	if err != nil {
+		return err
+	}
+	if series.LastSlot.IsZero() && len(occurrences) > 0 {
+		// Series created before templates were stored start from their latest occurrence
+		latest, err := s.repo.GetByID(occurrences[len(occurrences)-1].ID)
+		if err != nil {
+			return err
+		}
+		setSeriesTemplate(series, latest)
+		series.LastSlot = latest.EventTime
+	}
+	taken := make(map[int64]bool, len(occurrences))
+	for _, occ := range occurrences {
+		taken[occ.EventTime.Unix()] = true
 	}
 
-	if err := s.repo.UpdateParticipantStatus(participant.ID, status); err != nil {
-		return fmt.Errorf("failed to update status: %w", err)
+	// Expand in the series' zone so occurrences keep their local wall-clock
+	// time across DST changes.
+	loc := series.Zone()
+	slots := rule.Expand(series.StartTime.In(loc), series.LastSlot, time.Now().Add(seriesHorizon))
+	if len(slots) == 0 {
+		return nil
+	}
+	for _, t := range slots {
+		if taken[t.Unix()] {
+			continue
+		}
+		if err := s.repo.Create(newOccurrence(series, t.UTC())); err != nil {
+			return fmt.Errorf("failed to create occurrence: %w", err)
+		}
 	}
 
-	// Auto-update activity status to "full" if accepted count reaches max
-	if status == "accepted" && activity.MaxParticipants > 0 {
-		accepted, err := s.repo.CountAccepted(activityID)
-		if err == nil && int(accepted) >= activity.MaxParticipants {
-			activity.Status = "full"
-			_ = s.repo.Update(activity)
+	series.LastSlot = slots[len(slots)-1].UTC()
+	if err := s.repo.UpdateSeries(series); err != nil {
+		return fmt.Errorf("failed to update activity series: %w", err)
+	}
+	return nil
+}
+
+// setSeriesTemplate stores an occurrence's details and co-hosts on its series,
+// for the occurrences created after it.
+func setSeriesTemplate(series *model.ActivitySeries, src *model.Activity) {
+	series.Title = src.Title
+	series.Description = src.Description
+	series.Location = src.Location
+	series.Timezone = src.Timezone
+	series.MaxParticipants = src.MaxParticipants
+	series.FreeCancelHours = src.FreeCancelHours
+	series.Visibility = src.Visibility
+	series.Images = append([]string(nil), src.Images...)
+	series.Tags = src.Tags
+	series.Roles = append([]string(nil), src.Roles...)
+
+	series.RoleSlots = nil
+	for _, slot := range src.RoleSlots {
+		series.RoleSlots = append(series.RoleSlots, model.TemplateRoleSlot{Role: slot.Role, Count: slot.Count})
+	}
+	series.Questions = nil
+	for _, q := range src.Questions {
+		series.Questions = append(series.Questions, model.TemplateQuestion{
+			Type:     q.Type,
+			Label:    q.Label,
+			Options:  append([]string(nil), q.Options...),
+			Required: q.Required,
+		})
+	}
+	series.CoHosts = nil
+	for _, c := range src.CoHosts {
+		series.CoHosts = append(series.CoHosts, model.TemplateCoHost{UserID: c.UserID, Permission: c.Permission})
+	}
+}
+
+// newOccurrence creates an open occurrence of series at eventTime from the
+// series template.
+func newOccurrence(series *model.ActivitySeries, eventTime time.Time) *model.Activity {
+	occ := &model.Activity{
+		HostID:          series.HostID,
+		SeriesID:        &series.ID,
+		Title:           series.Title,
+		Description:     series.Description,
+		Location:        series.Location,
+		EventTime:       eventTime,
+		Timezone:        series.Timezone,
+		MaxParticipants: series.MaxParticipants,
+		FreeCancelHours: series.FreeCancelHours,
+		Status:          "open",
+		Visibility:      series.Visibility,
+		Images:          append([]string(nil), series.Images...),
+		Tags:            series.Tags,
+		Roles:           append([]string(nil), series.Roles...),
+	}
+	for _, slot := range series.RoleSlots {
+		occ.RoleSlots = append(occ.RoleSlots, model.ActivityRoleSlot{Role: slot.Role, Count: slot.Count})
+	}
+	for i, q := range series.Questions {
+		occ.Questions = append(occ.Questions, model.ActivityQuestion{
+			Position: i,
+			Type:     q.Type,
+			Label:    q.Label,
+			Options:  append([]string(nil), q.Options...),
+			Required: q.Required,
+		})
+	}
+	for _, c := range series.CoHosts {
+		occ.CoHosts = append(occ.CoHosts, model.ActivityCoHost{UserID: c.UserID, Permission: c.Permission})
+	}
+	return occ
+}
+
+// copyActivity copies an activity's details and settings into a new, unsaved
+// activity. Schedule, status, series and co-hosts are left to the caller.
+func copyActivity(src *model.Activity) *model.Activity {
+	dst := &model.Activity{
+		HostID:          src.HostID,
+		Title:           src.Title,
+		Description:     src.Description,
+		Location:        src.Location,
+		Timezone:        src.Timezone,
+		MaxParticipants: src.MaxParticipants,
+		FreeCancelHours: src.FreeCancelHours,
+		Visibility:      src.Visibility,
+		Images:          append([]string(nil), src.Images...),
+		Tags:            src.Tags,
+		Roles:           append([]string(nil), src.Roles...),
+	}
+	for _, slot := range src.RoleSlots {
+		dst.RoleSlots = append(dst.RoleSlots, model.ActivityRoleSlot{Role: slot.Role, Count: slot.Count})
+	}
+	for _, q := range src.Questions {
+		dst.Questions = append(dst.Questions, model.ActivityQuestion{
+			Position: q.Position,
+			Type:     q.Type,
+			Label:    q.Label,
+			Options:  append([]string(nil), q.Options...),
+			Required: q.Required,
+		})
+	}
+	return dst
+}
+
+// isUpcoming reports whether an occurrence is still open or full and has not started.
+func isUpcoming(activity *model.Activity, now time.Time) bool {
+	return (activity.Status == "open" || activity.Status == "full") && activity.EventTime.After(now)
+}
+
+// --- Drafts ---
+
+// Publish makes a draft visible now, or schedules it when input.PublishAt is set.
+func (s *activityService) Publish(activityID, userID uint, input PublishInput) (*model.Activity, error) {
+	activity, err := s.repo.GetByID(activityID)
+	if err != nil {
+		return nil, apperror.New(apperror.CodeNotFound, "activity not found")
+	}
+	if err := s.authorize(activity, userID, model.CoHostFull); err != nil {
+		return nil, err
+	}
+	if activity.Status != "draft" {
+		return nil, apperror.New(apperror.CodeConflict, "activity is already published")
+	}
+
+	if input.PublishAt == "" {
+		if err := s.publish(activity); err != nil {
+			return nil, err
 		}
+		return activity, nil
 	}
 
-	// Send notification to applicant
-	_ = s.notifService.SendNotification(applicantUserID, hostID, status, fmt.Sprintf("%d", activityID), activity.Title)
+	publishAt, err := parseEventTime(input.PublishAt, activity.Zone())
+	if err != nil {
+		return nil, apperror.Wrap(apperror.CodeValidation, "invalid publish time", err)
+	}
+	if !publishAt.After(time.Now()) {
+		return nil, apperror.New(apperror.CodeValidation, "publish time must be in the future")
+	}
+	if err := validateForPublish(activity, publishAt); err != nil {
+		return nil, err
+	}
+	activity.PublishAt = &publishAt
+	if err := s.repo.Update(activity); err != nil {
+		return nil, fmt.Errorf("failed to schedule activity: %w", err)
+	}
+	return activity, nil
+}
+
+// PublishScheduled publishes drafts whose scheduled time has come. Drafts that
+// no longer pass validation are unscheduled and left as drafts.
+func (s *activityService) PublishScheduled() error {
+	due, err := s.repo.ListScheduledPublishes(time.Now())
+	if err != nil {
+		return err
+	}
+
+	var errs []error
+	for _, d := range due {
+		activity, err := s.repo.GetByID(d.ID)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("activity %d: %w", d.ID, err))
+			continue
+		}
+		if err := s.publish(activity); err != nil {
+			if _, ok := apperror.AsAppError(err); !ok {
+				errs = append(errs, fmt.Errorf("activity %d: %w", d.ID, err))
+				continue
+			}
+			logger.Warn("scheduled activity is not publishable", "activityID", activity.ID, "error", err)
+			activity.PublishAt = nil
+			if err := s.repo.Update(activity); err != nil {
+				errs = append(errs, fmt.Errorf("activity %d: %w", d.ID, err))
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// publish validates a draft and opens it.
+func (s *activityService) publish(activity *model.Activity) error {
+	now := time.Now()
+	if err := validateForPublish(activity, now); err != nil {
+		return err
+	}
 
+	activity.Status = "open"
+	activity.PublishAt = nil
+	activity.PublishedAt = &now
+	if err := s.repo.Update(activity); err != nil {
+		return fmt.Errorf("failed to publish activity: %w", err)
+	}
 	return nil
 }
 
-func (s *activityService) ListParticipants(activityID uint) ([]model.ActivityParticipant, error) {
-	participants, err := s.repo.ListParticipants(activityID)
+// validateForPublish checks that a draft is complete enough to go live at the
+// given time: the event is still ahead, it has images, and capacity is set.
+func validateForPublish(activity *model.Activity, at time.Time) error {
+	var problems []string
+	if activity.EventTime.IsZero() || !activity.EventTime.After(at) {
+		problems = append(problems, "event time must be in the future")
+	}
+	if len(activity.Images) == 0 {
+		problems = append(problems, "add at least one image")
+	}
+	if activity.MaxParticipants <= 0 && len(activity.RoleSlots) == 0 {
+		problems = append(problems, "set a capacity or role slots")
+	}
+	if len(problems) > 0 {
+		return apperror.New(apperror.CodeValidation, "cannot publish: "+strings.Join(problems, "; "))
+	}
+	return nil
+}
+
+// Duplicate copies an activity's details and settings into a new draft hosted
+// by the caller. Co-hosts, participants and the schedule are not copied.
+func (s *activityService) Duplicate(activityID, userID uint, input DuplicateInput) (*model.Activity, error) {
+	src, err := s.repo.GetByID(activityID)
 	if err != nil {
+		return nil, apperror.New(apperror.CodeNotFound, "activity not found")
+	}
+	if err := s.authorize(src, userID, model.CoHostEditDetails); err != nil {
 		return nil, err
 	}
 
-	for i := range participants {
-		// Populate average rating (calculated on the fly)
-		if avg, err := s.ratingRepo.GetAverageByUserID(participants[i].UserID); err == nil {
-			participants[i].User.AverageRating = avg
+	activity := copyActivity(src)
+	activity.HostID = userID
+	activity.Status = "draft"
+	if input.EventTime != "" {
+		eventTime, err := parseEventTime(input.EventTime, src.Zone())
+		if err != nil {
+			return nil, apperror.Wrap(apperror.CodeValidation, "invalid event time", err)
 		}
+		activity.EventTime = eventTime
 	}
 
-	return participants, nil
+	if err := s.repo.Create(activity); err != nil {
+		return nil, fmt.Errorf("failed to duplicate activity: %w", err)
+	}
+	return activity, nil
 }
 
-func (s *activityService) GetMyApplications(userID uint) ([]model.ActivityParticipant, error) {
-	return s.repo.GetApplicationsByUserID(userID)
+// --- Templates ---
+
+// SaveTemplate stores an activity's details and settings as a personal template
+// of the caller.
+func (s *activityService) SaveTemplate(activityID, userID uint, input SaveTemplateInput) (*model.ActivityTemplate, error) {
+	activity, err := s.repo.GetByID(activityID)
+	if err != nil {
+		return nil, apperror.New(apperror.CodeNotFound, "activity not found")
+	}
+	if err := s.authorize(activity, userID, model.CoHostEditDetails); err != nil {
+		return nil, err
+	}
+
+	name := strings.TrimSpace(input.Name)
+	if name == "" || utf8.RuneCountInString(name) > 100 {
+		return nil, apperror.New(apperror.CodeValidation, "template name must be 1 to 100 characters")
+	}
+	count, err := s.repo.CountTemplates(userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count templates: %w", err)
+	}
+	if count >= maxTemplatesPerUser {
+		return nil, apperror.Newf(apperror.CodeConflict, "you can keep at most %d templates", maxTemplatesPerUser)
+	}
+
+	template := &model.ActivityTemplate{
+		UserID:          userID,
+		Name:            name,
+		Title:           activity.Title,
+		Description:     activity.Description,
+		Location:        activity.Location,
+		Timezone:        activity.Timezone,
+		MaxParticipants: activity.MaxParticipants,
+		FreeCancelHours: activity.FreeCancelHours,
+		Visibility:      activity.Visibility,
+		Images:          append([]string(nil), activity.Images...),
+		Tags:            activity.Tags,
+		Roles:           append([]string(nil), activity.Roles...),
+	}
+	for _, slot := range activity.RoleSlots {
+		template.RoleSlots = append(template.RoleSlots, model.TemplateRoleSlot{Role: slot.Role, Count: slot.Count})
+	}
+	for _, q := range activity.Questions {
+		template.Questions = append(template.Questions, model.TemplateQuestion{
+			Type:     q.Type,
+			Label:    q.Label,
+			Options:  append([]string(nil), q.Options...),
+			Required: q.Required,
+		})
+	}
+
+	if err := s.repo.CreateTemplate(template); err != nil {
+		return nil, fmt.Errorf("failed to save template: %w", err)
+	}
+	return template, nil
+}
+
+func (s *activityService) ListTemplates(userID uint) ([]model.ActivityTemplate, error) {
+	return s.repo.ListTemplates(userID)
+}
+
+func (s *activityService) DeleteTemplate(userID, templateID uint) error {
+	if err := s.repo.DeleteTemplate(userID, templateID); err != nil {
+		return apperror.New(apperror.CodeNotFound, "template not found")
+	}
+	return nil
+}
+
+// applyTemplate fills the fields input leaves unset from a saved template. It
+// returns the template's images when input brings none of its own.
+func applyTemplate(input *CreateActivityInput, template *model.ActivityTemplate) []string {
+	if input.Title == "" {
+		input.Title = template.Title
+	}
+	if input.Description == "" {
+		input.Description = template.Description
+	}
+	if input.Location == "" {
+		input.Location = template.Location
+	}
+	if input.Timezone == "" {
+		input.Timezone = template.Timezone
+	}
+	if input.MaxParticipants == 0 {
+		input.MaxParticipants = template.MaxParticipants
+	}
+	if input.FreeCancelHours == nil && template.FreeCancelHours > 0 {
+		hours := template.FreeCancelHours
+		input.FreeCancelHours = &hours
+	}
+	if input.Visibility == "" {
+		input.Visibility = template.Visibility
+	}
+	if input.Tags == "" {
+		input.Tags = template.Tags
+	}
+	if len(input.Roles) == 0 {
+		input.Roles = append([]string(nil), template.Roles...)
+	}
+	if input.RoleSlots == nil {
+		for _, slot := range template.RoleSlots {
+			input.RoleSlots = append(input.RoleSlots, RoleSlotInput{Role: slot.Role, Count: slot.Count})
+		}
+	}
+	if input.Questions == nil {
+		for _, q := range template.Questions {
+			input.Questions = append(input.Questions, QuestionInput{
+				Type:     q.Type,
+				Label:    q.Label,
+				Options:  append([]string(nil), q.Options...),
+				Required: q.Required,
+			})
+		}
+	}
+	if len(input.Images) > 0 {
+		return nil
+	}
+	return append([]string(nil), template.Images...)
+}
+
+// --- Reminders ---
+
+// SendReminders notifies the accepted participants of activities
+// starting within reminderLeadTime. The start time is shown in the activity's
+// own timezone.
+func (s *activityService) SendReminders() error {
+	activities, err := s.repo.ListDueReminders(time.Now().Add(reminderLeadTime))
+	if err != nil {
+		return err
+	}
+
+	var errs []error
+	for i := range activities {
+		activity := &activities[i]
+		msg := fmt.Sprintf("活動提醒：%s 將於 %s 開始", activity.Title, activity.LocalTime(activity.EventTime).Format("01/02 15:04 MST"))
+		refID := fmt.Sprintf("%d", activity.ID)
+
+		participants, err := s.repo.ListParticipants(activity.ID)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("activity %d: %w", activity.ID, err))
+			continue
+		}
+		for _, p := range participants {
+			_ = s.notifService.SendNotification(p.UserID, activity.HostID, "activity_reminder", refID, msg)
+		}
+
+		if err := s.repo.MarkReminderSent(activity.ID); err != nil {
+			errs = append(errs, fmt.Errorf("activity %d: %w", activity.ID, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// --- Participation ---
+
+func (s *activityService) Apply(activityID, userID uint, input ApplyInput) error {
+	activity, err := s.repo.GetByID(activityID)
+	if err != nil {
+		return apperror.New(apperror.CodeNotFound, "activity not found")
+	}
+
+	if activity.HostID == userID {
+		return apperror.New(apperror.CodeConflict, "host cannot apply to their own activity")
+	}
+	if s.isCoHost(activityID, userID) {
+		return apperror.New(apperror.CodeConflict, "co-hosts cannot apply to the activity")
+	}
+
+	var link *model.ActivityInviteLink
+	if input.InviteToken != "" {
+		if link, err = s.resolveInviteLink(activity, input.InviteToken); err != nil {
+			return err
+		}
+	}
+
+	existing, _ := s.repo.GetParticipant(activityID, userID)
+	if activity.Visibility == "private" && link == nil && (existing == nil || isReusableParticipant(existing)) {
+		return apperror.New(apperror.CodeForbidden, "this activity is invite only")
+	}
+
+	// Sync status with reality (Self-healing)
+	if refreshCapacityStatus(activity) {
+		_ = s.repo.Update(activity)
+	}
+
+	if activity.Status != "open" {
+		return apperror.New(apperror.CodeConflict, "activity is not open for applications")
+	}
+
+	role := strings.TrimSpace(input.Role)
+	if len(activity.RoleSlots) > 0 {
+		if role == "" {
+			return apperror.New(apperror.CodeValidation, "please choose a role to apply for")
+		}
+		if err := checkCapacity(activity, role); err != nil {
+			return err
+		}
+	}
+
+	if existing != nil && !isReusableParticipant(existing) {
+		if existing.Status == "invited" {
+			return apperror.New(apperror.CodeConflict, "you have a pending invitation to this activity")
+		}
+		return apperror.New(apperror.CodeConflict, "already applied to this activity")
+	}
+
+	answers, err := validateAnswers(activity.Questions, input.Answers)
+	if err != nil {
+		return err
+	}
+
+	participant := &model.ActivityParticipant{
+		ActivityID: activityID,
+		UserID:     userID,
+		Status:     "pending",
+		Role:       role,
+		Message:    input.Message,
+		Answers:    answers,
+	}
+
+	if existing != nil {
+		// A declined or expired invitation turns into a regular application
+		participant.ID = existing.ID
+		participant.AppliedAt = time.Now()
+	}
+
+	switch {
+	case link != nil:
+		var check func(map[string]int64) error
+		if link.Mode == "auto_accept" {
+			participant.Status = "accepted"
+			check = func(acceptedByRole map[string]int64) error {
+				return checkBatchCapacity(activity, acceptedByRole, map[string]int64{role: 1})
+			}
+		}
+		// The link's use is only counted if the application is saved
+		used, err := s.repo.ApplyWithInviteLink(link.ID, participant, check)
+		if err != nil {
+			if _, ok := apperror.AsAppError(err); ok {
+				return err
+			}
+			return fmt.Errorf("failed to apply with invite link: %w", err)
+		}
+		if !used {
+			return apperror.New(apperror.CodeConflict, "invite link has reached its use limit")
+		}
+	case existing != nil:
+		if err := s.repo.UpdateParticipant(participant); err != nil {
+			return err
+		}
+	default:
+		if err := s.repo.CreateParticipant(participant); err != nil {
+			return err
+		}
+	}
+
+	if participant.Status == "accepted" {
+		s.refreshCounts(activity)
+		if refreshCapacityStatus(activity) {
+			_ = s.repo.Update(activity)
+		}
+		s.openChatRoom(activity)
+		s.notifyHosts(activity, userID, "participant_joined", activity.Title)
+		return nil
+	}
+
+	// Send notification to host
+	s.notifyHosts(activity, userID, "join_request", activity.Title)
+
+	return nil
+}
+
+func (s *activityService) InviteUser(activityID, hostID uint, input InviteInput) error {
+	activity, err := s.repo.GetByID(activityID)
+	if err != nil {
+		return apperror.New(apperror.CodeNotFound, "activity not found")
+	}
+
+	if err := s.authorize(activity, hostID, model.CoHostManageApplicants); err != nil {
+		return err
+	}
+
+	if input.UserID == hostID {
+		return apperror.New(apperror.CodeValidation, "cannot invite yourself")
+	}
+	if isActivityHost(s.repo, activity, input.UserID) {
+		return apperror.New(apperror.CodeValidation, "cannot invite the host or a co-host")
+	}
+	if _, err := s.userRepo.GetByID(input.UserID); err != nil {
+		return apperror.New(apperror.CodeNotFound, "user not found")
+	}
+
+	if activity.Status != "open" && activity.Status != "full" {
+		return apperror.New(apperror.CodeConflict, "activity is no longer accepting participants")
+	}
+
+	role := strings.TrimSpace(input.Role)
+	if len(activity.RoleSlots) > 0 {
+		if role == "" {
+			return apperror.New(apperror.CodeValidation, "please choose a role for the invitation")
+		}
+		if findRoleSlot(activity, role) == nil {
+			return apperror.Newf(apperror.CodeValidation, "role %q is not part of this activity", role)
+		}
+	}
+
+	expiresAt := time.Now().Add(inviteTTL)
+	if !activity.EventTime.IsZero() && activity.EventTime.Before(expiresAt) {
+		expiresAt = activity.EventTime
+	}
+
+	existing, _ := s.repo.GetParticipant(activityID, input.UserID)
+	switch {
+	case existing == nil:
+		participant := &model.ActivityParticipant{
+			ActivityID:      activityID,
+			UserID:          input.UserID,
+			Status:          "invited",
+			Role:            role,
+			Message:         input.Message,
+			InviteExpiresAt: &expiresAt,
+		}
+		if err := s.repo.CreateParticipant(participant); err != nil {
+			return fmt.Errorf("failed to create invitation: %w", err)
+		}
+	case isReusableParticipant(existing):
+		existing.Status = "invited"
+		existing.Role = role
+		existing.Message = input.Message
+		existing.InviteExpiresAt = &expiresAt
+		if err := s.repo.UpdateParticipant(existing); err != nil {
+			return fmt.Errorf("failed to renew invitation: %w", err)
+		}
+	case existing.Status == "invited":
+		return apperror.New(apperror.CodeConflict, "user has already been invited")
+	default:
+		return apperror.New(apperror.CodeConflict, "user has already applied to this activity")
+	}
+
+	// Type: "invitation", ReferenceID: activityID, Content: custom message or default
+	notifContent := fmt.Sprintf("邀請你參加活動：%s", activity.Title)
+	if input.Message != "" {
+		notifContent = fmt.Sprintf("%s - 邀請訊息：%s", notifContent, input.Message)
+	}
+
+	return s.notifService.SendNotification(input.UserID, hostID, "invitation", fmt.Sprintf("%d", activityID), notifContent)
+}
+
+func (s *activityService) AcceptInvitation(activityID, userID uint) error {
+	activity, participant, err := s.getPendingInvitation(activityID, userID)
+	if err != nil {
+		return err
+	}
+
+	if activity.Status != "open" && activity.Status != "full" {
+		return apperror.New(apperror.CodeConflict, "activity is no longer accepting participants")
+	}
+	if err := checkCapacity(activity, participant.Role); err != nil {
+		return err
+	}
+
+	participant.Status = "accepted"
+	participant.InviteExpiresAt = nil
+	if err := s.repo.UpdateParticipant(participant); err != nil {
+		return fmt.Errorf("failed to accept invitation: %w", err)
+	}
+
+	s.refreshCounts(activity)
+	if refreshCapacityStatus(activity) {
+		_ = s.repo.Update(activity)
+	}
+	s.openChatRoom(activity)
+
+	s.notifyHosts(activity, userID, "invitation_accepted", activity.Title)
+
+	return nil
+}
+
+func (s *activityService) DeclineInvitation(activityID, userID uint) error {
+	activity, participant, err := s.getPendingInvitation(activityID, userID)
+	if err != nil {
+		return err
+	}
+
+	participant.Status = "declined"
+	participant.InviteExpiresAt = nil
+	if err := s.repo.UpdateParticipant(participant); err != nil {
+		return fmt.Errorf("failed to decline invitation: %w", err)
+	}
+
+	s.notifyHosts(activity, userID, "invitation_declined", activity.Title)
+
+	return nil
+}
+
+// getPendingInvitation loads the activity and the user's unexpired invitation to it.
+func (s *activityService) getPendingInvitation(activityID, userID uint) (*model.Activity, *model.ActivityParticipant, error) {
+	activity, err := s.repo.GetByID(activityID)
+	if err != nil {
+		return nil, nil, apperror.New(apperror.CodeNotFound, "activity not found")
+	}
+
+	participant, err := s.repo.GetParticipant(activityID, userID)
+	if err != nil || participant.Status != "invited" {
+		return nil, nil, apperror.New(apperror.CodeNotFound, "invitation not found")
+	}
+	if isInviteExpired(participant) {
+		return nil, nil, apperror.New(apperror.CodeConflict, "invitation has expired")
+	}
+
+	return activity, participant, nil
+}
+
+// isInviteExpired reports whether an invitation is past its expiry time.
+func isInviteExpired(p *model.ActivityParticipant) bool {
+	return p.Status == "invited" && p.InviteExpiresAt != nil && time.Now().After(*p.InviteExpiresAt)
+}
+
+// isReviewable reports whether the host can accept or reject a participant
+// record: a pending application, or a previous decision being revised.
+func isReviewable(p *model.ActivityParticipant) bool {
+	return p.Status == "pending" || p.Status == "accepted" || p.Status == "rejected"
+}
+
+// isReusableParticipant reports whether a participant record is a declined or
+// expired invitation, or a free withdrawal, that may be replaced by a new
+// invitation or application. Late cancellations are kept for the user's record.
+func isReusableParticipant(p *model.ActivityParticipant) bool {
+	return p.Status == "declined" || p.Status == "withdrawn" || isInviteExpired(p)
+}
+
+// CancelApplication withdraws a pending or accepted application. Accepted
+// participants cancelling inside the activity's free-cancel window are recorded
+// as late cancellations. The host is notified either way.
+func (s *activityService) CancelApplication(activityID, userID uint) error {
+	activity, err := s.repo.GetByID(activityID)
+	if err != nil {
+		return apperror.New(apperror.CodeNotFound, "activity not found")
+	}
+	participant, err := s.repo.GetParticipant(activityID, userID)
+	if err != nil || (participant.Status != "pending" && participant.Status != "accepted") {
+		return apperror.New(apperror.CodeNotFound, "no active application for this activity")
+	}
+
+	now := time.Now()
+	if activity.Status == "ended" || (!activity.EventTime.IsZero() && !now.Before(activity.EventTime)) {
+		return apperror.New(apperror.CodeValidation, "activity has already started")
+	}
+
+	wasAccepted := participant.Status == "accepted"
+	participant.Status = "withdrawn"
+	if wasAccepted && isLateCancellation(activity, now) {
+		participant.Status = "late_cancelled"
+	}
+	participant.CancelledAt = &now
+	if err := s.repo.UpdateParticipant(participant); err != nil {
+		return fmt.Errorf("failed to cancel application: %w", err)
+	}
+
+	if wasAccepted {
+		s.refreshCounts(activity)
+		if refreshCapacityStatus(activity) {
+			_ = s.repo.Update(activity)
+		}
+	}
+
+	notifType := "application_withdrawn"
+	if participant.Status == "late_cancelled" {
+		notifType = "late_cancellation"
+	}
+	s.notifyHosts(activity, userID, notifType, activity.Title)
+	return nil
+}
+
+// isLateCancellation reports whether cancelling at now falls inside the
+// activity's free-cancel window before the start time.
+func isLateCancellation(activity *model.Activity, now time.Time) bool {
+	if activity.EventTime.IsZero() {
+		return false
+	}
+	window := time.Duration(activity.FreeCancelHours) * time.Hour
+	return now.After(activity.EventTime.Add(-window))
+}
+
+func (s *activityService) RejectApplicant(activityID, hostID, applicantID uint) error {
+	return s.UpdateApplicantStatus(activityID, hostID, applicantID, "rejected")
+}
+
+func (s *activityService) GetUserStatus(activityID, userID uint) (string, error) {
+	activity, err := s.repo.GetByID(activityID)
+	if err != nil {
+		return "", apperror.New(apperror.CodeNotFound, "activity not found")
+	}
+
+	if activity.HostID == userID {
+		return "host", nil
+	}
+	if s.isCoHost(activityID, userID) {
+		return "cohost", nil
+	}
+
+	participant, err := s.repo.GetParticipant(activityID, userID)
+	if err != nil || isReusableParticipant(participant) {
+		return "idle", nil
+	}
+
+	return participant.Status, nil
+}
+
+// openChatRoom creates the activity's chat room on the first acceptance.
+func (s *activityService) openChatRoom(activity *model.Activity) {
+	if _, err := s.chatRepo.EnsureRoom(activity.ID); err != nil {
+		logger.Warn("failed to open chat room", "activityID", activity.ID, "error", err)
+	}
+}
+
+// --- Host Management ---
+
+func (s *activityService) ListApplicants(activityID, hostID uint, filter repository.ApplicantFilter) ([]model.ActivityParticipant, error) {
+	activity, err := s.repo.GetByID(activityID)
+	if err != nil {
+		return nil, apperror.New(apperror.CodeNotFound, "activity not found")
+	}
+
+	if err := s.authorize(activity, hostID, model.CoHostManageApplicants); err != nil {
+		return nil, err
+	}
+
+	if !applicantSorts[filter.Sort] {
+		return nil, apperror.Newf(apperror.CodeValidation, "unsupported sort %q", filter.Sort)
+	}
+
+	applicants, err := s.repo.ListApplicants(activityID, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	userIDs := make([]uint, len(applicants))
+	for i := range applicants {
+		userIDs[i] = applicants[i].UserID
+	}
+	lateCancels, err := s.repo.CountLateCancels(userIDs)
+	if err != nil {
+		logger.Warn("failed to count late cancellations", "activityID", activityID, "error", err)
+	}
+	ratings, err := s.ratingRepo.GetAveragesByUserIDs(userIDs)
+	if err != nil {
+		logger.Warn("failed to load applicant ratings", "activityID", activityID, "error", err)
+	}
+	followers, err := s.followRepo.FollowersAmong(activity.HostID, userIDs)
+	if err != nil {
+		logger.Warn("failed to load host followers", "activityID", activityID, "error", err)
+	}
+
+	filtered := applicants[:0]
+	for _, a := range applicants {
+		a.User.AverageRating = ratings[a.UserID]
+		a.User.LateCancelCount = lateCancels[a.UserID]
+		a.FollowsHost = followers[a.UserID]
+
+		if filter.FollowsHost != nil && a.FollowsHost != *filter.FollowsHost {
+			continue
+		}
+		if filter.MinRating > 0 && a.User.AverageRating < filter.MinRating {
+			continue
+		}
+		filtered = append(filtered, a)
+	}
+
+	sortApplicants(filtered, filter.Sort)
+	return filtered, nil
+}
+
+// sortApplicants orders applicants by the given ApplicantFilter.Sort value.
+// Ties fall back to the most recent application first.
+func sortApplicants(applicants []model.ActivityParticipant, by string) {
+	newer := func(a, b *model.ActivityParticipant) bool {
+		return a.AppliedAt.After(b.AppliedAt)
+	}
+	sort.SliceStable(applicants, func(i, j int) bool {
+		a, b := &applicants[i], &applicants[j]
+		switch by {
+		case "applied_asc":
+			return a.AppliedAt.Before(b.AppliedAt)
+		case "rating":
+			if a.User.AverageRating != b.User.AverageRating {
+				return a.User.AverageRating > b.User.AverageRating
+			}
+		case "followers":
+			if a.FollowsHost != b.FollowsHost {
+				return a.FollowsHost
+			}
+		}
+		return newer(a, b)
+	})
+}
+
+func (s *activityService) UpdateApplicantStatus(activityID, hostID, applicantUserID uint, status string) error {
+	if status != "accepted" && status != "rejected" {
+		return apperror.New(apperror.CodeValidation, "status must be 'accepted' or 'rejected'")
+	}
+
+	activity, err := s.repo.GetByID(activityID)
+	if err != nil {
+		return apperror.New(apperror.CodeNotFound, "activity not found")
+	}
+
+	if err := s.authorize(activity, hostID, model.CoHostManageApplicants); err != nil {
+		return err
+	}
+
+	participant, err := s.repo.GetParticipant(activityID, applicantUserID)
+	if err != nil || !isReviewable(participant) {
+		return apperror.New(apperror.CodeNotFound, "applicant not found")
+	}
+
+	if status == "accepted" && participant.Status != "accepted" {
+		if err := checkCapacity(activity, participant.Role); err != nil {
+			return err
+		}
+	}
+
+	if err := s.repo.UpdateParticipantStatus(participant.ID, status); err != nil {
+		return fmt.Errorf("failed to update status: %w", err)
+	}
+
+	// Keep "open"/"full" in sync with the new accepted counts
+	s.refreshCounts(activity)
+	if refreshCapacityStatus(activity) {
+		_ = s.repo.Update(activity)
+	}
+	if status == "accepted" {
+		s.openChatRoom(activity)
+	}
+
+	// Send notification to applicant
+	_ = s.notifService.SendNotification(applicantUserID, hostID, status, fmt.Sprintf("%d", activityID), activity.Title)
+
+	return nil
+}
+
+// BatchUpdateApplicantStatus accepts or rejects several applicants in one
+// transaction. Every user must have a reviewable record; otherwise nothing is
+// changed. Acceptances are checked against the remaining capacity while the
+// activity row is locked, so concurrent reviews cannot overfill it.
+func (s *activityService) BatchUpdateApplicantStatus(activityID, hostID uint, input BatchUpdateApplicantsInput) (*BatchUpdateResult, error) {
+	if input.Status != "accepted" && input.Status != "rejected" {
+		return nil, apperror.New(apperror.CodeValidation, "status must be 'accepted' or 'rejected'")
+	}
+	message := strings.TrimSpace(input.Message)
+	if utf8.RuneCountInString(message) > maxDecisionMessageLength {
+		return nil, apperror.Newf(apperror.CodeValidation, "message must be at most %d characters", maxDecisionMessageLength)
+	}
+
+	userIDs := make([]uint, 0, len(input.UserIDs))
+	seen := make(map[uint]bool, len(input.UserIDs))
+	for _, id := range input.UserIDs {
+		if !seen[id] {
+			seen[id] = true
+			userIDs = append(userIDs, id)
+		}
+	}
+	if len(userIDs) == 0 {
+		return nil, apperror.New(apperror.CodeValidation, "userIds must not be empty")
+	}
+	if len(userIDs) > maxBatchApplicants {
+		return nil, apperror.Newf(apperror.CodeValidation, "at most %d applicants can be reviewed at once", maxBatchApplicants)
+	}
+
+	activity, err := s.repo.GetByID(activityID)
+	if err != nil {
+		return nil, apperror.New(apperror.CodeNotFound, "activity not found")
+	}
+
+	if err := s.authorize(activity, hostID, model.CoHostManageApplicants); err != nil {
+		return nil, err
+	}
+
+	result := &BatchUpdateResult{Updated: []uint{}, Skipped: []uint{}}
+	var participantIDs []uint
+	newlyAccepted := make(map[string]int64)
+	for _, userID := range userIDs {
+		participant, err := s.repo.GetParticipant(activityID, userID)
+		if err != nil || !isReviewable(participant) {
+			return nil, apperror.Newf(apperror.CodeNotFound, "applicant %d not found", userID)
+		}
+		if participant.Status == input.Status {
+			result.Skipped = append(result.Skipped, userID)
+			continue
+		}
+		participantIDs = append(participantIDs, participant.ID)
+		result.Updated = append(result.Updated, userID)
+		if input.Status == "accepted" {
+			newlyAccepted[participant.Role]++
+		}
+	}
+
+	if len(participantIDs) == 0 {
+		return result, nil
+	}
+
+	var check func(map[string]int64) error
+	if len(newlyAccepted) > 0 {
+		check = func(acceptedByRole map[string]int64) error {
+			return checkBatchCapacity(activity, acceptedByRole, newlyAccepted)
+		}
+	}
+	if err := s.repo.UpdateParticipantStatuses(activityID, participantIDs, input.Status, check); err != nil {
+		if _, ok := apperror.AsAppError(err); ok {
+			return nil, err
+		}
+		if errors.Is(err, repository.ErrParticipantsChanged) {
+			return nil, apperror.New(apperror.CodeConflict, "some applicants changed their application in the meantime; reload and try again")
+		}
+		return nil, fmt.Errorf("failed to update statuses: %w", err)
+	}
+
+	s.refreshCounts(activity)
+	if refreshCapacityStatus(activity) {
+		_ = s.repo.Update(activity)
+	}
+	if input.Status == "accepted" {
+		s.openChatRoom(activity)
+	}
+
+	content := activity.Title
+	if message != "" {
+		content = activity.Title + "\n" + message
+	}
+	refID := fmt.Sprintf("%d", activityID)
+	for _, userID := range result.Updated {
+		_ = s.notifService.SendNotification(userID, hostID, input.Status, refID, content)
+	}
+
+	return result, nil
+}
+
+// checkBatchCapacity ensures adding newlyAccepted (per role) on top of the
+// current accepted counts stays within the activity's role slots or, without
+// slots, MaxParticipants (0 = unlimited).
+func checkBatchCapacity(activity *model.Activity, acceptedByRole, newlyAccepted map[string]int64) error {
+	if len(activity.RoleSlots) > 0 {
+		roles := make([]string, 0, len(newlyAccepted))
+		for role := range newlyAccepted {
+			roles = append(roles, role)
+		}
+		sort.Strings(roles)
+
+		for _, role := range roles {
+			slot := findRoleSlot(activity, role)
+			if slot == nil {
+				return apperror.Newf(apperror.CodeValidation, "role %q is not part of this activity", role)
+			}
+			left := int64(slot.Count) - acceptedByRole[role]
+			if newlyAccepted[role] > left {
+				return apperror.Newf(apperror.CodeConflict, "role %q has %d spots left", role, max(left, 0))
+			}
+		}
+		return nil
+	}
+
+	if activity.MaxParticipants == 0 {
+		return nil
+	}
+	var accepted, adding int64
+	for _, n := range acceptedByRole {
+		accepted += n
+	}
+	for _, n := range newlyAccepted {
+		adding += n
+	}
+	left := int64(activity.MaxParticipants) - accepted
+	if adding > left {
+		return apperror.Newf(apperror.CodeConflict, "only %d spots left", max(left, 0))
+	}
+	return nil
+}
+
+func (s *activityService) ListParticipants(activityID uint) ([]model.ActivityParticipant, error) {
+	participants, err := s.repo.ListParticipants(activityID)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range participants {
+		// Populate average rating (calculated on the fly)
+		if avg, err := s.ratingRepo.GetAverageByUserID(participants[i].UserID); err == nil {
+			participants[i].User.AverageRating = avg
+		}
+	}
+
+	return participants, nil
+}
+
+// ListWorks returns a page of the public works linked to an activity the
+// viewer can see.
+func (s *activityService) ListWorks(activityID, viewerID uint, query ActivityWorksQuery) (*ActivityWorksPage, error) {
+	activity, err := s.repo.GetByID(activityID)
+	if err != nil || !canViewActivity(s.repo, activity, viewerID) {
+		return nil, apperror.New(apperror.CodeNotFound, "activity not found")
+	}
+
+	limit := clampLimit(query.Limit, defaultActivityWorksPageSize, maxActivityWorksPageSize)
+
+	// Fetch one extra to learn whether older works remain
+	posts, err := s.workRepo.ListByActivity(activityID, query.BeforeID, limit+1, viewerID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list works: %w", err)
+	}
+
+	page := &ActivityWorksPage{Items: posts}
+	if len(posts) > limit {
+		page.Items = posts[:limit]
+		page.NextBefore = posts[limit-1].ID
+	}
+	if page.Items == nil {
+		page.Items = []model.Post{}
+	}
+	return page, nil
+}
+
+func (s *activityService) GetMyApplications(userID uint) ([]model.ActivityParticipant, error) {
+	return s.repo.GetApplicationsByUserID(userID)
+}
+
+// --- Invite Links ---
+
+var inviteLinkModes = map[string]bool{
+	"apply":       true,
+	"auto_accept": true,
+}
+
+func (s *activityService) CreateInviteLink(activityID, hostID uint, input CreateInviteLinkInput) (*model.ActivityInviteLink, error) {
+	activity, err := s.repo.GetByID(activityID)
+	if err != nil {
+		return nil, apperror.New(apperror.CodeNotFound, "activity not found")
+	}
+	if err := s.authorize(activity, hostID, model.CoHostManageApplicants); err != nil {
+		return nil, err
+	}
+
+	mode := input.Mode
+	if mode == "" {
+		mode = "apply"
+	}
+	if !inviteLinkModes[mode] {
+		return nil, apperror.Newf(apperror.CodeValidation, "unsupported invite link mode %q", mode)
+	}
+	if input.MaxUses < 0 {
+		return nil, apperror.New(apperror.CodeValidation, "maxUses cannot be negative")
+	}
+
+	var expiresAt *time.Time
+	if input.ExpiresAt != "" {
+		t, err := parseEventTime(input.ExpiresAt, activity.Zone())
+		if err != nil {
+			return nil, apperror.Wrap(apperror.CodeValidation, "invalid expiry time", err)
+		}
+		if t.Before(time.Now()) {
+			return nil, apperror.New(apperror.CodeValidation, "expiry time must be in the future")
+		}
+		expiresAt = &t
+	} else if !activity.EventTime.IsZero() {
+		t := activity.EventTime
+		expiresAt = &t
+	}
+
+	nonce, err := utils.GenerateSecureToken(16)
+	if err != nil {
+		return nil, err
+	}
+
+	link := &model.ActivityInviteLink{
+		ActivityID: activityID,
+		Nonce:      nonce,
+		Mode:       mode,
+		MaxUses:    input.MaxUses,
+		ExpiresAt:  expiresAt,
+	}
+	if err := s.repo.CreateInviteLink(link); err != nil {
+		return nil, fmt.Errorf("failed to create invite link: %w", err)
+	}
+
+	s.fillInviteLinkURL(link)
+	return link, nil
+}
+
+func (s *activityService) ListInviteLinks(activityID, hostID uint) ([]model.ActivityInviteLink, error) {
+	activity, err := s.repo.GetByID(activityID)
+	if err != nil {
+		return nil, apperror.New(apperror.CodeNotFound, "activity not found")
+	}
+	if err := s.authorize(activity, hostID, model.CoHostManageApplicants); err != nil {
+		return nil, err
+	}
+
+	links, err := s.repo.ListInviteLinks(activityID)
+	if err != nil {
+		return nil, err
+	}
+	for i := range links {
+		s.fillInviteLinkURL(&links[i])
+	}
+	return links, nil
+}
+
+func (s *activityService) RevokeInviteLink(activityID, hostID, linkID uint) error {
+	activity, err := s.repo.GetByID(activityID)
+	if err != nil {
+		return apperror.New(apperror.CodeNotFound, "activity not found")
+	}
+	if err := s.authorize(activity, hostID, model.CoHostManageApplicants); err != nil {
+		return err
+	}
+
+	if err := s.repo.RevokeInviteLink(activityID, linkID); err != nil {
+		return apperror.New(apperror.CodeNotFound, "invite link not found")
+	}
+	return nil
+}
+
+// fillInviteLinkURL computes the signed token and shareable URL for a link.
+// The token binds the link's nonce to its activity.
+func (s *activityService) fillInviteLinkURL(link *model.ActivityInviteLink) {
+	link.Token = auth.SignValue(fmt.Sprintf("%d.%s", link.ActivityID, link.Nonce), s.linkSecret)
+	link.URL = fmt.Sprintf("%s/activities/%d?invite=%s", s.frontendURL, link.ActivityID, url.QueryEscape(link.Token))
+}
+
+// resolveInviteLink verifies a signed invite token for the activity and returns
+// the link if it is still usable. The use limit is enforced when the link is used.
+func (s *activityService) resolveInviteLink(activity *model.Activity, token string) (*model.ActivityInviteLink, error) {
+	invalid := apperror.New(apperror.CodeValidation, "invalid or expired invite link")
+
+	value, err := auth.VerifySignedValue(token, s.linkSecret)
+	if err != nil {
+		return nil, invalid
+	}
+	idPart, nonce, ok := strings.Cut(value, ".")
+	if !ok || idPart != strconv.FormatUint(uint64(activity.ID), 10) {
+		return nil, invalid
+	}
+
+	link, err := s.repo.GetInviteLinkByNonce(nonce)
+	if err != nil || link.ActivityID != activity.ID || link.RevokedAt != nil {
+		return nil, invalid
+	}
+	if link.ExpiresAt != nil && time.Now().After(*link.ExpiresAt) {
+		return nil, invalid
+	}
+	return link, nil
+}
+
+// --- Co-hosts ---
+
+// maxCoHosts caps how many co-hosts an activity can have.
+const maxCoHosts = 10
+
+// permOwner is an authorize level reserved for the activity's creator, above
+// every co-host permission (e.g. managing co-hosts, series-wide changes).
+const permOwner = "owner"
+
+// coHostPermissions lists the permission levels a co-host can be given.
+var coHostPermissions = map[string]bool{
+	model.CoHostManageApplicants: true,
+	model.CoHostEditDetails:      true,
+	model.CoHostFull:             true,
+}
+
+// permissionDenied holds the error message returned for each authorize level.
+var permissionDenied = map[string]string{
+	model.CoHostManageApplicants: "you do not have permission to manage this activity's applicants",
+	model.CoHostEditDetails:      "you do not have permission to edit this activity",
+	model.CoHostFull:             "only the host or a full co-host can do this",
+	permOwner:                    "only the host can do this",
+}
+
+// authorize checks that userID may act on the activity at the given level.
+// The host may do anything; co-hosts are allowed what their permission
+// covers, with "full" covering every co-host level.
+func (s *activityService) authorize(activity *model.Activity, userID uint, level string) error {
+	return authorizeActivity(s.repo, activity, userID, level)
+}
+
+// authorizeActivity is authorize for services that share the activity repository.
+func authorizeActivity(repo repository.ActivityRepository, activity *model.Activity, userID uint, level string) error {
+	if userID != 0 && activity.HostID == userID {
+		return nil
+	}
+	if level != permOwner && userID != 0 {
+		if coHost, err := repo.GetCoHost(activity.ID, userID); err == nil {
+			if coHost.Permission == model.CoHostFull || coHost.Permission == level {
+				return nil
+			}
+		}
+	}
+	return apperror.New(apperror.CodeForbidden, permissionDenied[level])
+}
+
+// isCoHost reports whether userID co-hosts the activity.
+func (s *activityService) isCoHost(activityID, userID uint) bool {
+	_, err := s.repo.GetCoHost(activityID, userID)
+	return err == nil
+}
+
+// notifyHosts sends a host notification to the host and every co-host.
+func (s *activityService) notifyHosts(activity *model.Activity, actorID uint, notifType, content string) {
+	refID := fmt.Sprintf("%d", activity.ID)
+	_ = s.notifService.SendNotification(activity.HostID, actorID, notifType, refID, content)
+
+	coHosts, err := s.repo.ListCoHosts(activity.ID)
+	if err != nil {
+		logger.Warn("failed to list co-hosts for notification", "activityID", activity.ID, "error", err)
+		return
+	}
+	for _, c := range coHosts {
+		_ = s.notifService.SendNotification(c.UserID, actorID, notifType, refID, content)
+	}
+}
+
+func (s *activityService) AddCoHost(activityID, hostID uint, input CoHostInput) (*model.ActivityCoHost, error) {
+	activity, err := s.repo.GetByID(activityID)
+	if err != nil {
+		return nil, apperror.New(apperror.CodeNotFound, "activity not found")
+	}
+	if err := s.authorize(activity, hostID, permOwner); err != nil {
+		return nil, err
+	}
+	if !coHostPermissions[input.Permission] {
+		return nil, apperror.Newf(apperror.CodeValidation, "unsupported co-host permission %q", input.Permission)
+	}
+	if input.UserID == activity.HostID {
+		return nil, apperror.New(apperror.CodeValidation, "the host cannot be a co-host")
+	}
+	if s.isCoHost(activityID, input.UserID) {
+		return nil, apperror.New(apperror.CodeConflict, "user is already a co-host")
+	}
+	if p, err := s.repo.GetParticipant(activityID, input.UserID); err == nil && (p.Status == "pending" || p.Status == "accepted" || p.Status == "invited") {
+		return nil, apperror.New(apperror.CodeConflict, "user is already a participant of this activity")
+	}
+
+	coHosts, err := s.repo.ListCoHosts(activityID)
+	if err != nil {
+		return nil, err
+	}
+	if len(coHosts) >= maxCoHosts {
+		return nil, apperror.Newf(apperror.CodeValidation, "an activity can have at most %d co-hosts", maxCoHosts)
+	}
+
+	coHost := &model.ActivityCoHost{
+		ActivityID: activityID,
+		UserID:     input.UserID,
+		Permission: input.Permission,
+	}
+	if err := s.repo.AddCoHost(coHost); err != nil {
+		return nil, fmt.Errorf("failed to add co-host: %w", err)
+	}
+
+	_ = s.notifService.SendNotification(input.UserID, hostID, "cohost_added", fmt.Sprintf("%d", activityID), activity.Title)
+	return coHost, nil
+}
+
+func (s *activityService) UpdateCoHost(activityID, hostID, userID uint, permission string) (*model.ActivityCoHost, error) {
+	activity, err := s.repo.GetByID(activityID)
+	if err != nil {
+		return nil, apperror.New(apperror.CodeNotFound, "activity not found")
+	}
+	if err := s.authorize(activity, hostID, permOwner); err != nil {
+		return nil, err
+	}
+	if !coHostPermissions[permission] {
+		return nil, apperror.Newf(apperror.CodeValidation, "unsupported co-host permission %q", permission)
+	}
+
+	coHost, err := s.repo.GetCoHost(activityID, userID)
+	if err != nil {
+		return nil, apperror.New(apperror.CodeNotFound, "co-host not found")
+	}
+	coHost.Permission = permission
+	if err := s.repo.UpdateCoHost(coHost); err != nil {
+		return nil, fmt.Errorf("failed to update co-host: %w", err)
+	}
+	return coHost, nil
+}
+
+// RemoveCoHost removes a co-host. The host can remove anyone; a co-host can
+// remove themselves.
+func (s *activityService) RemoveCoHost(activityID, userID, coHostID uint) error {
+	activity, err := s.repo.GetByID(activityID)
+	if err != nil {
+		return apperror.New(apperror.CodeNotFound, "activity not found")
+	}
+	if userID != coHostID {
+		if err := s.authorize(activity, userID, permOwner); err != nil {
+			return err
+		}
+	}
+
+	if err := s.repo.RemoveCoHost(activityID, coHostID); err != nil {
+		return apperror.New(apperror.CodeNotFound, "co-host not found")
+	}
+	return nil
+}
+
+// --- Announcements ---
+
+// Announce stores an announcement and delivers it to accepted participants, and
+// to pending applicants when input.IncludePending is set, as a notification and
+// an email.
+func (s *activityService) Announce(activityID, userID uint, input AnnounceInput) (*model.ActivityAnnouncement, error) {
+	activity, err := s.repo.GetByID(activityID)
+	if err != nil {
+		return nil, apperror.New(apperror.CodeNotFound, "activity not found")
+	}
+	if err := s.authorize(activity, userID, model.CoHostManageApplicants); err != nil {
+		return nil, err
+	}
+	if activity.Status == "draft" || activity.Status == "cancelled" {
+		return nil, apperror.Newf(apperror.CodeValidation, "cannot announce on a %s activity", activity.Status)
+	}
+
+	message := strings.TrimSpace(input.Message)
+	if message == "" {
+		return nil, apperror.New(apperror.CodeValidation, "message is required")
+	}
+	if utf8.RuneCountInString(message) > maxAnnouncementLength {
+		return nil, apperror.Newf(apperror.CodeValidation, "message must be at most %d characters", maxAnnouncementLength)
+	}
+
+	applicants, err := s.repo.ListApplicants(activityID, repository.ApplicantFilter{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list participants: %w", err)
+	}
+	var recipients []model.ActivityParticipant
+	for _, p := range applicants {
+		if p.Status == "accepted" || (input.IncludePending && p.Status == "pending") {
+			recipients = append(recipients, p)
+		}
+	}
+
+	announcement := &model.ActivityAnnouncement{
+		ActivityID:     activityID,
+		AuthorID:       userID,
+		Message:        message,
+		IncludePending: input.IncludePending,
+		RecipientCount: len(recipients),
+	}
+	if err := s.repo.CreateAnnouncement(announcement); err != nil {
+		return nil, fmt.Errorf("failed to save announcement: %w", err)
+	}
+
+	refID := fmt.Sprintf("%d", activityID)
+	content := fmt.Sprintf("「%s」公告：%s", activity.Title, message)
+	for _, p := range recipients {
+		_ = s.notifService.SendNotification(p.UserID, userID, "activity_announcement", refID, content)
+	}
+	go s.emailAnnouncement(activity, userID, message, recipients)
+
+	return announcement, nil
+}
+
+// emailAnnouncement sends an announcement to every verified recipient. It runs
+// in the background; failures are logged.
+func (s *activityService) emailAnnouncement(activity *model.Activity, authorID uint, message string, recipients []model.ActivityParticipant) {
+	mail := email.ActivityAnnouncement{
+		ActivityTitle: activity.Title,
+		AuthorName:    activityStaffName(activity, authorID),
+		Message:       message,
+		Link:          fmt.Sprintf("%s/activities/%d", s.frontendURL, activity.ID),
+	}
+	if !activity.EventTime.IsZero() {
+		mail.EventTime = activity.LocalTime(activity.EventTime).Format("01/02 15:04 MST")
+	}
+
+	for _, p := range recipients {
+		if p.UserID == authorID || p.User.Email == "" || !p.User.IsVerified {
+			continue
+		}
+		if err := email.SendActivityAnnouncementEmail(p.User.Email, mail); err != nil {
+			logger.Warn("failed to email announcement", "activityID", activity.ID, "userID", p.UserID, "error", err)
+		}
+	}
+}
+
+// ListAnnouncements returns an activity's announcements. The host side and
+// accepted participants see all of them; pending applicants only see those
+// that were sent to them.
+func (s *activityService) ListAnnouncements(activityID, viewerID uint) ([]model.ActivityAnnouncement, error) {
+	activity, err := s.repo.GetByID(activityID)
+	if err != nil {
+		return nil, apperror.New(apperror.CodeNotFound, "activity not found")
+	}
+
+	includeAll := isActivityHost(s.repo, activity, viewerID)
+	if !includeAll {
+		p, err := s.repo.GetParticipant(activityID, viewerID)
+		if viewerID == 0 || err != nil || (p.Status != "accepted" && p.Status != "pending") {
+			return nil, apperror.New(apperror.CodeForbidden, "announcements are only visible to participants")
+		}
+		includeAll = p.Status == "accepted"
+	}
+
+	announcements, err := s.repo.ListAnnouncements(activityID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list announcements: %w", err)
+	}
+	if includeAll {
+		return announcements, nil
+	}
+
+	visible := make([]model.ActivityAnnouncement, 0, len(announcements))
+	for _, a := range announcements {
+		if a.IncludePending {
+			visible = append(visible, a)
+		}
+	}
+	return visible, nil
+}
+
+// activityStaffName returns the display name of the host or co-host userID.
+func activityStaffName(activity *model.Activity, userID uint) string {
+	user := activity.Host
+	for _, c := range activity.CoHosts {
+		if c.UserID == userID {
+			user = c.User
+		}
+	}
+	if user.Profile.DisplayName != "" {
+		return user.Profile.DisplayName
+	}
+	if user.UserName != "" {
+		return user.UserName
+	}
+	return "主辦人"
+}
+
+// --- Check-in ---
+
+// GetCheckInTicket returns the signed check-in token for an accepted participant.
+func (s *activityService) GetCheckInTicket(activityID, userID uint) (*CheckInTicket, error) {
+	activity, err := s.repo.GetByID(activityID)
+	if err != nil {
+		return nil, apperror.New(apperror.CodeNotFound, "activity not found")
+	}
+	if activity.Status == "cancelled" {
+		return nil, apperror.New(apperror.CodeValidation, "activity is cancelled")
+	}
+	participant, err := s.repo.GetParticipant(activityID, userID)
+	if err != nil || (participant.Status != "accepted" && participant.Status != "no_show") {
+		return nil, apperror.New(apperror.CodeForbidden, "only accepted participants can check in")
+	}
+
+	expiresAt := checkInExpiry(activity, time.Now())
+	return &CheckInTicket{
+		ActivityID: activityID,
+		Token:      auth.SignValue(fmt.Sprintf("checkin.%d.%d.%d", activityID, userID, expiresAt.Unix()), s.linkSecret),
+		ExpiresAt:  expiresAt,
+	}, nil
+}
+
+// checkInExpiry is when check-in tokens for the activity stop working: the end
+// of the event's local day, or the end of QR check-in if that is later. For an
+// activity without an event time, tokens last a day from issue.
+func checkInExpiry(activity *model.Activity, issuedAt time.Time) time.Time {
+	if activity.EventTime.IsZero() {
+		return issuedAt.Add(24 * time.Hour).Truncate(time.Second)
+	}
+	expiry := startOfDay(activity.EventTime, activity.Zone()).AddDate(0, 0, 1)
+	if closes := activity.EventTime.Add(attendanceGracePeriod); closes.After(expiry) {
+		expiry = closes
+	}
+	return expiry.UTC()
+}
+
+// CheckIn records a participant's attendance. Scanned tokens are accepted while
+// check-in is open; manual check-in by user ID also works afterwards so the host
+// can correct a no_show.
+func (s *activityService) CheckIn(activityID, hostID uint, input CheckInInput) (*model.ActivityParticipant, error) {
+	activity, err := s.repo.GetByID(activityID)
+	if err != nil {
+		return nil, apperror.New(apperror.CodeNotFound, "activity not found")
+	}
+	if err := s.authorize(activity, hostID, model.CoHostManageApplicants); err != nil {
+		return nil, err
+	}
+	if activity.Status == "cancelled" {
+		return nil, apperror.New(apperror.CodeValidation, "activity is cancelled")
+	}
+
+	now := time.Now()
+	if !activity.EventTime.IsZero() && now.Before(activity.EventTime.Add(-checkInOpensBefore)) {
+		return nil, apperror.New(apperror.CodeValidation, "check-in has not opened yet")
+	}
+
+	userID := input.UserID
+	if input.Token != "" {
+		userID, err = s.verifyCheckInToken(activity, input.Token, now)
+		if err != nil {
+			return nil, err
+		}
+		closed := activity.AttendanceClosedAt != nil ||
+			(!activity.EventTime.IsZero() && now.After(activity.EventTime.Add(attendanceGracePeriod)))
+		if closed {
+			return nil, apperror.New(apperror.CodeValidation, "check-in has closed, use manual check-in instead")
+		}
+	}
+	if userID == 0 {
+		return nil, apperror.New(apperror.CodeValidation, "token or userId is required")
+	}
+
+	participant, err := s.repo.GetParticipant(activityID, userID)
+	if err != nil || (participant.Status != "accepted" && participant.Status != "no_show") {
+		return nil, apperror.New(apperror.CodeNotFound, "participant not found")
+	}
+	if participant.CheckedInAt != nil {
+		return nil, apperror.New(apperror.CodeConflict, "participant is already checked in")
+	}
+
+	participant.Status = "accepted"
+	participant.CheckedInAt = &now
+	if err := s.repo.UpdateParticipant(participant); err != nil {
+		return nil, fmt.Errorf("failed to check in participant: %w", err)
+	}
+	return participant, nil
+}
+
+// CloseAttendance marks accepted participants who never checked in as no_show
+// once an activity's check-in window has passed.
+func (s *activityService) CloseAttendance() error {
+	cutoff := time.Now().Add(-attendanceGracePeriod)
+	activities, err := s.repo.ListAttendanceDue(cutoff.Add(-attendanceLookback), cutoff)
+	if err != nil {
+		return err
+	}
+
+	var errs []error
+	for _, activity := range activities {
+		marked, err := s.repo.CloseAttendance(activity.ID)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("activity %d: %w", activity.ID, err))
+			continue
+		}
+		if marked > 0 {
+			logger.Info("marked no-show participants", "activityID", activity.ID, "count", marked)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// verifyCheckInToken checks a signed check-in token for the activity and
+// returns the participant's user ID. Expired tokens, and tokens issued before
+// the activity was rescheduled, are rejected.
+func (s *activityService) verifyCheckInToken(activity *model.Activity, token string, now time.Time) (uint, error) {
+	invalid := apperror.New(apperror.CodeValidation, "invalid check-in code")
+
+	value, err := auth.VerifySignedValue(token, s.linkSecret)
+	if err != nil {
+		return 0, invalid
+	}
+	parts := strings.Split(value, ".")
+	if len(parts) != 4 || parts[0] != "checkin" || parts[1] != strconv.FormatUint(uint64(activity.ID), 10) {
+		return 0, invalid
+	}
+	userID, err := strconv.ParseUint(parts[2], 10, 64)
+	if err != nil || userID == 0 {
+		return 0, invalid
+	}
+	expires, err := strconv.ParseInt(parts[3], 10, 64)
+	if err != nil {
+		return 0, invalid
+	}
+	if !activity.EventTime.IsZero() && expires != checkInExpiry(activity, now).Unix() {
+		return 0, apperror.New(apperror.CodeValidation, "check-in code is from before the activity was rescheduled")
+	}
+	if now.After(time.Unix(expires, 0)) {
+		return 0, apperror.New(apperror.CodeValidation, "check-in code has expired")
+	}
+	return uint(userID), nil
+}
+
+// --- Visibility ---
+
+var activityVisibilities = map[string]bool{
+	"public":   true,
+	"unlisted": true,
+	"private":  true,
+}
+
+// canViewActivity reports whether viewerID (0 = anonymous) may see the activity.
+// Drafts are visible to the host and co-hosts only. Public and unlisted
+// activities are visible to anyone who has the ID; private ones only to the
+// hosts and to users who applied or were invited.
+func canViewActivity(repo repository.ActivityRepository, activity *model.Activity, viewerID uint) bool {
+	if activity.Status == "draft" {
+		return isActivityHost(repo, activity, viewerID)
+	}
+	return activity.Visibility != "private" || isActivityMember(repo, activity, viewerID)
+}
+
+// isActivityHost reports whether viewerID is the host or a co-host.
+func isActivityHost(repo repository.ActivityRepository, activity *model.Activity, viewerID uint) bool {
+	if viewerID == 0 {
+		return false
+	}
+	if activity.HostID == viewerID {
+		return true
+	}
+	_, err := repo.GetCoHost(activity.ID, viewerID)
+	return err == nil
+}
+
+// isActivityMember reports whether viewerID is the host, a co-host, or has an
+// active application or invitation for the activity.
+func isActivityMember(repo repository.ActivityRepository, activity *model.Activity, viewerID uint) bool {
+	if viewerID == 0 {
+		return false
+	}
+	if isActivityHost(repo, activity, viewerID) {
+		return true
+	}
+	p, err := repo.GetParticipant(activity.ID, viewerID)
+	return err == nil && !isReusableParticipant(p)
+}
+
+// isActivityCrew reports whether userID is the host, a co-host, or an accepted
+// participant of the activity — someone who takes part in the shoot. It gates
+// the activity chat, linking works to the activity and work credits.
+func isActivityCrew(repo repository.ActivityRepository, activity *model.Activity, userID uint) bool {
+	if userID == 0 {
+		return false
+	}
+	if isActivityHost(repo, activity, userID) {
+		return true
+	}
+	p, err := repo.GetParticipant(activity.ID, userID)
+	return err == nil && p.Status == "accepted"
+}
+
+// --- Capacity ---
+
+// buildRoleSlots validates role slot input and converts it to models.
+func buildRoleSlots(inputs []RoleSlotInput) ([]model.ActivityRoleSlot, error) {
+	slots := make([]model.ActivityRoleSlot, 0, len(inputs))
+	seen := make(map[string]bool)
+	for _, in := range inputs {
+		role := strings.TrimSpace(in.Role)
+		if role == "" {
+			return nil, apperror.New(apperror.CodeValidation, "role name is required for every role slot")
+		}
+		if in.Count < 1 {
+			return nil, apperror.Newf(apperror.CodeValidation, "role %q needs at least 1 slot", role)
+		}
+		if seen[role] {
+			return nil, apperror.Newf(apperror.CodeValidation, "role %q is listed more than once", role)
+		}
+		seen[role] = true
+		slots = append(slots, model.ActivityRoleSlot{Role: role, Count: in.Count})
+	}
+	return slots, nil
+}
+
+// applyRoleSlots sets the activity's role slots and keeps the legacy Roles list
+// and MaxParticipants in sync with them.
+func applyRoleSlots(activity *model.Activity, slots []model.ActivityRoleSlot) {
+	activity.RoleSlots = slots
+	if len(slots) == 0 {
+		return
+	}
+
+	roles := make([]string, 0, len(slots))
+	total := 0
+	for _, slot := range slots {
+		roles = append(roles, slot.Role)
+		total += slot.Count
+	}
+	activity.Roles = roles
+	activity.MaxParticipants = total
+}
+
+// checkRoleSlotsCoverAccepted rejects slot changes that would drop a role or
+// shrink it below the number of participants already accepted for it.
+func checkRoleSlotsCoverAccepted(activity *model.Activity, slots []model.ActivityRoleSlot) error {
+	newCounts := make(map[string]int, len(slots))
+	for _, slot := range slots {
+		newCounts[slot.Role] = slot.Count
+	}
+	for _, old := range activity.RoleSlots {
+		if old.Filled == 0 {
+			continue
+		}
+		count, ok := newCounts[old.Role]
+		if !ok {
+			return apperror.Newf(apperror.CodeConflict, "role %q already has accepted participants and cannot be removed", old.Role)
+		}
+		if int64(count) < old.Filled {
+			return apperror.Newf(apperror.CodeConflict, "role %q already has %d accepted participants", old.Role, old.Filled)
+		}
+	}
+	return nil
+}
+
+// findRoleSlot returns the activity's slot for role, or nil if none exists.
+func findRoleSlot(activity *model.Activity, role string) *model.ActivityRoleSlot {
+	for i := range activity.RoleSlots {
+		if activity.RoleSlots[i].Role == role {
+			return &activity.RoleSlots[i]
+		}
+	}
+	return nil
+}
+
+// checkCapacity ensures there is room to accept one more participant for role.
+// Activities without role slots are limited by MaxParticipants (0 = unlimited).
+func checkCapacity(activity *model.Activity, role string) error {
+	if len(activity.RoleSlots) > 0 {
+		slot := findRoleSlot(activity, role)
+		if slot == nil {
+			return apperror.Newf(apperror.CodeValidation, "role %q is not part of this activity", role)
+		}
+		if slot.Filled >= int64(slot.Count) {
+			return apperror.Newf(apperror.CodeConflict, "role %q is already filled", role)
+		}
+		return nil
+	}
+
+	if activity.MaxParticipants > 0 && activity.CurrentParticipants >= int64(activity.MaxParticipants) {
+		return apperror.New(apperror.CodeConflict, "activity is already full")
+	}
+	return nil
+}
+
+// isActivityFull reports whether every role slot is filled or, for activities
+// without slots, whether MaxParticipants (0 = unlimited) has been reached.
+func isActivityFull(activity *model.Activity) bool {
+	if len(activity.RoleSlots) > 0 {
+		for _, slot := range activity.RoleSlots {
+			if slot.Filled < int64(slot.Count) {
+				return false
+			}
+		}
+		return true
+	}
+	return activity.MaxParticipants > 0 && activity.CurrentParticipants >= int64(activity.MaxParticipants)
+}
+
+// refreshCapacityStatus flips an open/full activity to match its participant
+// counts. It reports whether the status changed; callers persist the change.
+func refreshCapacityStatus(activity *model.Activity) bool {
+	if activity.Status != "open" && activity.Status != "full" {
+		return false
+	}
+
+	want := "open"
+	if isActivityFull(activity) {
+		want = "full"
+	}
+	if activity.Status == want {
+		return false
+	}
+	activity.Status = want
+	return true
+}
+
+// refreshCounts reloads the accepted participant counts (overall and per role).
+func (s *activityService) refreshCounts(activity *model.Activity) {
+	if count, err := s.repo.CountAccepted(activity.ID); err == nil {
+		activity.CurrentParticipants = count
+	}
+	if len(activity.RoleSlots) == 0 {
+		return
+	}
+	if byRole, err := s.repo.CountAcceptedByRole(activity.ID); err == nil {
+		for i := range activity.RoleSlots {
+			activity.RoleSlots[i].Filled = byRole[activity.RoleSlots[i].Role]
+		}
+	}
+}
+
+// --- Application Form ---
+
+const (
+	maxQuestions       = 20
+	maxShortTextLength = 500
+)
+
+var questionTypes = map[string]bool{
+	"short_text": true,
+	"choice":     true,
+	"number":     true,
+	"url":        true,
+	"yes_no":     true,
+}
+
+// buildQuestions validates application question input and converts it to models.
+func buildQuestions(inputs []QuestionInput) ([]model.ActivityQuestion, error) {
+	if len(inputs) > maxQuestions {
+		return nil, apperror.Newf(apperror.CodeValidation, "an application form can have at most %d questions", maxQuestions)
+	}
+
+	questions := make([]model.ActivityQuestion, 0, len(inputs))
+	for i, in := range inputs {
+		label := strings.TrimSpace(in.Label)
+		if label == "" {
+			return nil, apperror.Newf(apperror.CodeValidation, "question %d needs a label", i+1)
+		}
+		if !questionTypes[in.Type] {
+			return nil, apperror.Newf(apperror.CodeValidation, "question %q has an unsupported type %q", label, in.Type)
+		}
+
+		var options []string
+		if in.Type == "choice" {
+			for _, opt := range in.Options {
+				if opt = strings.TrimSpace(opt); opt != "" {
+					options = append(options, opt)
+				}
+			}
+			if len(options) < 2 {
+				return nil, apperror.Newf(apperror.CodeValidation, "choice question %q needs at least 2 options", label)
+			}
+		}
+
+		questions = append(questions, model.ActivityQuestion{
+			Position: i,
+			Type:     in.Type,
+			Label:    label,
+			Options:  options,
+			Required: in.Required,
+		})
+	}
+	return questions, nil
+}
+
+// validateAnswers checks an applicant's answers against the activity's form and
+// returns them in question order with normalized values.
+func validateAnswers(questions []model.ActivityQuestion, inputs []AnswerInput) ([]model.ApplicationAnswer, error) {
+	byQuestion := make(map[uint]string, len(inputs))
+	for _, in := range inputs {
+		byQuestion[in.QuestionID] = strings.TrimSpace(in.Value)
+	}
+
+	known := make(map[uint]bool, len(questions))
+	answers := make([]model.ApplicationAnswer, 0, len(questions))
+	for _, q := range questions {
+		known[q.ID] = true
+		value := byQuestion[q.ID]
+		if value == "" {
+			if q.Required {
+				return nil, apperror.Newf(apperror.CodeValidation, "please answer %q", q.Label)
+			}
+			continue
+		}
+
+		normalized, err := normalizeAnswer(q, value)
+		if err != nil {
+			return nil, err
+		}
+		answers = append(answers, model.ApplicationAnswer{
+			QuestionID: q.ID,
+			Label:      q.Label,
+			Type:       q.Type,
+			Value:      normalized,
+		})
+	}
+
+	for id := range byQuestion {
+		if !known[id] {
+			return nil, apperror.Newf(apperror.CodeValidation, "question %d is not part of this activity", id)
+		}
+	}
+	return answers, nil
+}
+
+// normalizeAnswer validates a non-empty answer against its question type.
+func normalizeAnswer(q model.ActivityQuestion, value string) (string, error) {
+	switch q.Type {
+	case "short_text":
+		if utf8.RuneCountInString(value) > maxShortTextLength {
+			return "", apperror.Newf(apperror.CodeValidation, "answer to %q must be at most %d characters", q.Label, maxShortTextLength)
+		}
+	case "choice":
+		for _, opt := range q.Options {
+			if opt == value {
+				return value, nil
+			}
+		}
+		return "", apperror.Newf(apperror.CodeValidation, "answer to %q must be one of the listed options", q.Label)
+	case "number":
+		if _, err := strconv.ParseFloat(value, 64); err != nil {
+			return "", apperror.Newf(apperror.CodeValidation, "answer to %q must be a number", q.Label)
+		}
+	case "url":
+		u, err := url.ParseRequestURI(value)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return "", apperror.Newf(apperror.CodeValidation, "answer to %q must be a valid http(s) link", q.Label)
+		}
+	case "yes_no":
+		switch strings.ToLower(value) {
+		case "yes", "true":
+			return "yes", nil
+		case "no", "false":
+			return "no", nil
+		}
+		return "", apperror.Newf(apperror.CodeValidation, "answer to %q must be yes or no", q.Label)
+	}
+	return value, nil
+}
+
+// validateFreeCancelHours checks a free-cancel window in hours.
+func validateFreeCancelHours(hours int) error {
+	if hours < 1 || hours > maxFreeCancelHours {
+		return apperror.Newf(apperror.CodeValidation, "freeCancelHours must be between 1 and %d", maxFreeCancelHours)
+	}
+	return nil
+}
+
+// loadTimezone validates an IANA timezone name, defaulting to model.DefaultTimezone.
+func loadTimezone(name string) (string, *time.Location, error) {
+	if name == "" {
+		name = model.DefaultTimezone
+	}
+	loc, err := time.LoadLocation(name)
+	if err != nil || name == "Local" {
+		return "", nil, apperror.Newf(apperror.CodeValidation, "unknown timezone %q", name)
+	}
+	return loc.String(), loc, nil
 }
 
 // parseEventTime parses common time formats from the frontend and normalizes to UTC.
-func parseEventTime(timeStr string) (time.Time, error) {
+// Times without an offset are read as wall-clock time in loc.
+func parseEventTime(timeStr string, loc *time.Location) (time.Time, error) {
 	formats := []string{
 		time.RFC3339,
 		"2006-01-02T15:04:05Z07:00", // Explicit ISO8601
@@ -510,7 +2977,7 @@ func parseEventTime(timeStr string) (time.Time, error) {
 	}
 
 	for _, format := range formats {
-		if t, err := time.Parse(format, timeStr); err == nil {
+		if t, err := time.ParseInLocation(format, timeStr, loc); err == nil {
 			// Normalize to UTC for consistent DB storage regardless of client timezone
 			return t.UTC(), nil
 		}
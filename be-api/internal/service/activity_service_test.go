@@ -2,12 +2,16 @@ package service_test
 
 import (
 	"errors"
+	"fmt"
+	"sort"
+	"strings"
 	"testing"
 	"time"
 
 	"azure-magnetar/internal/model"
 	"azure-magnetar/internal/repository"
 	"azure-magnetar/internal/service"
+	"azure-magnetar/pkg/auth"
 )
 
 type mockCommentRepo struct{}
@@ -48,10 +52,18 @@ func newMockCommentRepo() *mockCommentRepo {
 // --- Mock Activity Repository ---
 
 type mockActivityRepo struct {
-	activities   map[uint]*model.Activity
-	participants map[string]*model.ActivityParticipant // key: "activityID-userID"
-	nextID       uint
-	nextPID      uint
+	activities    map[uint]*model.Activity
+	participants  map[string]*model.ActivityParticipant // key: "activityID-userID"
+	nextID        uint
+	nextPID       uint
+	nextQID       uint
+	links         []*model.ActivityInviteLink
+	series        map[uint]*model.ActivitySeries
+	coHosts       []*model.ActivityCoHost
+	templates     []*model.ActivityTemplate
+	announcements []*model.ActivityAnnouncement
+
+	beforeStatusUpdate func() // Runs at the start of UpdateParticipantStatuses and ApplyWithInviteLink, to simulate concurrent changes
 }
 
 func newMockActivityRepo() *mockActivityRepo {
@@ -60,9 +72,13 @@ func newMockActivityRepo() *mockActivityRepo {
 		participants: make(map[string]*model.ActivityParticipant),
 		nextID:       1,
 		nextPID:      1,
+		nextQID:      1,
+		series:       make(map[uint]*model.ActivitySeries),
 	}
 }
 
+const testLinkSecret = "test-link-secret"
+
 func participantKey(activityID, userID uint) string {
 	return string(rune(activityID)) + "-" + string(rune(userID))
 }
@@ -70,15 +86,35 @@ func participantKey(activityID, userID uint) string {
 func (r *mockActivityRepo) Create(activity *model.Activity) error {
 	activity.ID = r.nextID
 	r.nextID++
+	r.assignQuestionIDs(activity.ID, activity.Questions)
+	for i := range activity.CoHosts {
+		activity.CoHosts[i].ActivityID = activity.ID
+		c := activity.CoHosts[i]
+		r.coHosts = append(r.coHosts, &c)
+	}
 	r.activities[activity.ID] = activity
 	return nil
 }
 
+func (r *mockActivityRepo) assignQuestionIDs(activityID uint, questions []model.ActivityQuestion) {
+	for i := range questions {
+		questions[i].ID = r.nextQID
+		questions[i].ActivityID = activityID
+		questions[i].Position = i
+		r.nextQID++
+	}
+}
+
 func (r *mockActivityRepo) GetByID(id uint) (*model.Activity, error) {
 	a, ok := r.activities[id]
 	if !ok {
 		return nil, errors.New("not found")
 	}
+	a.CurrentParticipants, _ = r.CountAccepted(id)
+	byRole, _ := r.CountAcceptedByRole(id)
+	for i := range a.RoleSlots {
+		a.RoleSlots[i].Filled = byRole[a.RoleSlots[i].Role]
+	}
 	return a, nil
 }
 
@@ -95,13 +131,36 @@ func (r *mockActivityRepo) Delete(id uint) error {
 func (r *mockActivityRepo) List(_ repository.ActivityFilter) ([]model.Activity, int64, error) {
 	var result []model.Activity
 	for _, a := range r.activities {
-		result = append(result, *a)
+		if a.Visibility == "" || a.Visibility == "public" {
+			result = append(result, *a)
+		}
 	}
 	return result, int64(len(result)), nil
 }
 
-func (r *mockActivityRepo) GetByUserID(_ uint) ([]model.Activity, error) {
-	return nil, nil
+func (r *mockActivityRepo) GetByUserID(userID uint) ([]model.Activity, error) {
+	var result []model.Activity
+	for _, a := range r.activities {
+		if a.HostID == userID {
+			result = append(result, *a)
+		}
+	}
+	return result, nil
+}
+
+func (r *mockActivityRepo) ReplaceRoleSlots(activityID uint, slots []model.ActivityRoleSlot) error {
+	if a, ok := r.activities[activityID]; ok {
+		a.RoleSlots = slots
+	}
+	return nil
+}
+
+func (r *mockActivityRepo) ReplaceQuestions(activityID uint, questions []model.ActivityQuestion) error {
+	r.assignQuestionIDs(activityID, questions)
+	if a, ok := r.activities[activityID]; ok {
+		a.Questions = questions
+	}
+	return nil
 }
 
 func (r *mockActivityRepo) CreateParticipant(p *model.ActivityParticipant) error {
@@ -112,11 +171,6 @@ func (r *mockActivityRepo) CreateParticipant(p *model.ActivityParticipant) error
 	return nil
 }
 
-func (r *mockActivityRepo) DeleteParticipant(activityID, userID uint) error {
-	delete(r.participants, participantKey(activityID, userID))
-	return nil
-}
-
 func (r *mockActivityRepo) GetParticipant(activityID, userID uint) (*model.ActivityParticipant, error) {
 	p, ok := r.participants[participantKey(activityID, userID)]
 	if !ok {
@@ -135,10 +189,11 @@ func (r *mockActivityRepo) ListParticipants(activityID uint) ([]model.ActivityPa
 	return result, nil
 }
 
-func (r *mockActivityRepo) ListApplicants(activityID uint) ([]model.ActivityParticipant, error) {
+func (r *mockActivityRepo) ListApplicants(activityID uint, filter repository.ApplicantFilter) ([]model.ActivityParticipant, error) {
 	var result []model.ActivityParticipant
 	for _, p := range r.participants {
-		if p.ActivityID == activityID {
+		if p.ActivityID == activityID && (filter.Role == "" || p.Role == filter.Role) &&
+			(filter.Status == "" || p.Status == filter.Status) {
 			result = append(result, *p)
 		}
 	}
@@ -155,6 +210,331 @@ func (r *mockActivityRepo) UpdateParticipantStatus(id uint, status string) error
 	return errors.New("not found")
 }
 
+func (r *mockActivityRepo) UpdateParticipantStatuses(activityID uint, participantIDs []uint, status string, check func(map[string]int64) error) error {
+	if r.beforeStatusUpdate != nil {
+		r.beforeStatusUpdate()
+	}
+	if check != nil {
+		counts, _ := r.CountAcceptedByRole(activityID)
+		if err := check(counts); err != nil {
+			return err
+		}
+	}
+	var matched []*model.ActivityParticipant
+	for _, id := range participantIDs {
+		for _, p := range r.participants {
+			if p.ActivityID == activityID && p.ID == id && (p.Status == "pending" || p.Status == "accepted" || p.Status == "rejected") {
+				matched = append(matched, p)
+			}
+		}
+	}
+	if len(matched) != len(participantIDs) {
+		return repository.ErrParticipantsChanged
+	}
+	for _, p := range matched {
+		p.Status = status
+	}
+	return nil
+}
+
+func (r *mockActivityRepo) UpdateParticipant(p *model.ActivityParticipant) error {
+	r.participants[participantKey(p.ActivityID, p.UserID)] = p
+	return nil
+}
+
+func (r *mockActivityRepo) CreateSeries(series *model.ActivitySeries) error {
+	series.ID = uint(len(r.series) + 1)
+	r.series[series.ID] = series
+	return nil
+}
+
+func (r *mockActivityRepo) GetSeries(id uint) (*model.ActivitySeries, error) {
+	series, ok := r.series[id]
+	if !ok {
+		return nil, errors.New("not found")
+	}
+	return series, nil
+}
+
+func (r *mockActivityRepo) UpdateSeries(series *model.ActivitySeries) error {
+	r.series[series.ID] = series
+	return nil
+}
+
+func (r *mockActivityRepo) UpdateSeriesOccurrences(series *model.ActivitySeries, updates []repository.ActivityUpdate) error {
+	for _, u := range updates {
+		if u.ReplaceQuestions {
+			r.assignQuestionIDs(u.Activity.ID, u.Activity.Questions)
+		}
+		r.activities[u.Activity.ID] = u.Activity
+	}
+	r.series[series.ID] = series
+	return nil
+}
+
+func (r *mockActivityRepo) ListActiveSeries() ([]model.ActivitySeries, error) {
+	var result []model.ActivitySeries
+	for _, series := range r.series {
+		if series.Status == "active" {
+			result = append(result, *series)
+		}
+	}
+	return result, nil
+}
+
+func (r *mockActivityRepo) ListSeriesOccurrences(seriesID uint) ([]model.Activity, error) {
+	var result []model.Activity
+	for _, a := range r.activities {
+		if a.SeriesID != nil && *a.SeriesID == seriesID {
+			result = append(result, *a)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].EventTime.Before(result[j].EventTime) })
+	return result, nil
+}
+
+func (r *mockActivityRepo) ListScheduledPublishes(before time.Time) ([]model.Activity, error) {
+	var result []model.Activity
+	for _, a := range r.activities {
+		if a.Status == "draft" && a.PublishAt != nil && !a.PublishAt.After(before) {
+			result = append(result, *a)
+		}
+	}
+	return result, nil
+}
+
+func (r *mockActivityRepo) ListDueReminders(before time.Time) ([]model.Activity, error) {
+	var result []model.Activity
+	now := time.Now()
+	for _, a := range r.activities {
+		if (a.Status == "open" || a.Status == "full") && a.ReminderSentAt == nil &&
+			a.EventTime.After(now) && !a.EventTime.After(before) {
+			result = append(result, *a)
+		}
+	}
+	return result, nil
+}
+
+func (r *mockActivityRepo) MarkReminderSent(id uint) error {
+	if a, ok := r.activities[id]; ok {
+		now := time.Now()
+		a.ReminderSentAt = &now
+	}
+	return nil
+}
+
+func (r *mockActivityRepo) ListAttendanceDue(startedAfter, startedBefore time.Time) ([]model.Activity, error) {
+	var result []model.Activity
+	for _, a := range r.activities {
+		if a.Status != "cancelled" && a.AttendanceClosedAt == nil &&
+			a.EventTime.After(startedAfter) && !a.EventTime.After(startedBefore) {
+			result = append(result, *a)
+		}
+	}
+	return result, nil
+}
+
+func (r *mockActivityRepo) CloseAttendance(activityID uint) (int64, error) {
+	var marked int64
+	for _, p := range r.participants {
+		if p.ActivityID == activityID && p.Status == "accepted" && p.CheckedInAt == nil {
+			p.Status = "no_show"
+			marked++
+		}
+	}
+	if a, ok := r.activities[activityID]; ok {
+		now := time.Now()
+		a.AttendanceClosedAt = &now
+	}
+	return marked, nil
+}
+
+func (r *mockActivityRepo) CountAttendance(userID uint) (int64, int64, error) {
+	var attended, noShows int64
+	for _, p := range r.participants {
+		if p.UserID != userID {
+			continue
+		}
+		if p.CheckedInAt != nil {
+			attended++
+		} else if p.Status == "no_show" {
+			noShows++
+		}
+	}
+	return attended, noShows, nil
+}
+
+func (r *mockActivityRepo) CountLateCancels(userIDs []uint) (map[uint]int64, error) {
+	result := make(map[uint]int64)
+	for _, p := range r.participants {
+		if p.Status == "late_cancelled" {
+			result[p.UserID]++
+		}
+	}
+	return result, nil
+}
+
+func (r *mockActivityRepo) AddCoHost(coHost *model.ActivityCoHost) error {
+	coHost.ID = uint(len(r.coHosts) + 1)
+	r.coHosts = append(r.coHosts, coHost)
+	if a, ok := r.activities[coHost.ActivityID]; ok {
+		a.CoHosts = append(a.CoHosts, *coHost)
+	}
+	return nil
+}
+
+func (r *mockActivityRepo) GetCoHost(activityID, userID uint) (*model.ActivityCoHost, error) {
+	for _, c := range r.coHosts {
+		if c.ActivityID == activityID && c.UserID == userID {
+			return c, nil
+		}
+	}
+	return nil, errors.New("not found")
+}
+
+func (r *mockActivityRepo) UpdateCoHost(coHost *model.ActivityCoHost) error {
+	return nil
+}
+
+func (r *mockActivityRepo) RemoveCoHost(activityID, userID uint) error {
+	for i, c := range r.coHosts {
+		if c.ActivityID == activityID && c.UserID == userID {
+			r.coHosts = append(r.coHosts[:i], r.coHosts[i+1:]...)
+			return nil
+		}
+	}
+	return errors.New("not found")
+}
+
+func (r *mockActivityRepo) ListCoHosts(activityID uint) ([]model.ActivityCoHost, error) {
+	var result []model.ActivityCoHost
+	for _, c := range r.coHosts {
+		if c.ActivityID == activityID {
+			result = append(result, *c)
+		}
+	}
+	return result, nil
+}
+
+func (r *mockActivityRepo) CreateTemplate(template *model.ActivityTemplate) error {
+	template.ID = uint(len(r.templates) + 1)
+	r.templates = append(r.templates, template)
+	return nil
+}
+
+func (r *mockActivityRepo) GetTemplate(id uint) (*model.ActivityTemplate, error) {
+	for _, t := range r.templates {
+		if t.ID == id {
+			return t, nil
+		}
+	}
+	return nil, errors.New("not found")
+}
+
+func (r *mockActivityRepo) ListTemplates(userID uint) ([]model.ActivityTemplate, error) {
+	var result []model.ActivityTemplate
+	for _, t := range r.templates {
+		if t.UserID == userID {
+			result = append(result, *t)
+		}
+	}
+	return result, nil
+}
+
+func (r *mockActivityRepo) CountTemplates(userID uint) (int64, error) {
+	templates, _ := r.ListTemplates(userID)
+	return int64(len(templates)), nil
+}
+
+func (r *mockActivityRepo) DeleteTemplate(userID, id uint) error {
+	for i, t := range r.templates {
+		if t.ID == id && t.UserID == userID {
+			r.templates = append(r.templates[:i], r.templates[i+1:]...)
+			return nil
+		}
+	}
+	return errors.New("not found")
+}
+
+func (r *mockActivityRepo) CreateAnnouncement(announcement *model.ActivityAnnouncement) error {
+	announcement.ID = uint(len(r.announcements) + 1)
+	r.announcements = append(r.announcements, announcement)
+	return nil
+}
+
+func (r *mockActivityRepo) ListAnnouncements(activityID uint) ([]model.ActivityAnnouncement, error) {
+	var result []model.ActivityAnnouncement
+	for i := len(r.announcements) - 1; i >= 0; i-- {
+		if r.announcements[i].ActivityID == activityID {
+			result = append(result, *r.announcements[i])
+		}
+	}
+	return result, nil
+}
+
+func (r *mockActivityRepo) CreateInviteLink(link *model.ActivityInviteLink) error {
+	link.ID = uint(len(r.links) + 1)
+	r.links = append(r.links, link)
+	return nil
+}
+
+func (r *mockActivityRepo) GetInviteLinkByNonce(nonce string) (*model.ActivityInviteLink, error) {
+	for _, l := range r.links {
+		if l.Nonce == nonce {
+			return l, nil
+		}
+	}
+	return nil, errors.New("not found")
+}
+
+func (r *mockActivityRepo) ListInviteLinks(activityID uint) ([]model.ActivityInviteLink, error) {
+	var result []model.ActivityInviteLink
+	for _, l := range r.links {
+		if l.ActivityID == activityID {
+			result = append(result, *l)
+		}
+	}
+	return result, nil
+}
+
+func (r *mockActivityRepo) ApplyWithInviteLink(linkID uint, p *model.ActivityParticipant, check func(map[string]int64) error) (bool, error) {
+	if r.beforeStatusUpdate != nil {
+		r.beforeStatusUpdate()
+	}
+	for _, l := range r.links {
+		if l.ID == linkID {
+			if l.RevokedAt != nil || (l.MaxUses > 0 && l.Uses >= l.MaxUses) {
+				return false, nil
+			}
+			if check != nil {
+				counts, _ := r.CountAcceptedByRole(p.ActivityID)
+				if err := check(counts); err != nil {
+					return false, err
+				}
+			}
+			if p.ID != 0 {
+				_ = r.UpdateParticipant(p)
+			} else {
+				_ = r.CreateParticipant(p)
+			}
+			l.Uses++
+			return true, nil
+		}
+	}
+	return false, errors.New("not found")
+}
+
+func (r *mockActivityRepo) RevokeInviteLink(activityID, id uint) error {
+	for _, l := range r.links {
+		if l.ID == id && l.ActivityID == activityID && l.RevokedAt == nil {
+			now := time.Now()
+			l.RevokedAt = &now
+			return nil
+		}
+	}
+	return errors.New("not found")
+}
+
 func (r *mockActivityRepo) CountAccepted(activityID uint) (int64, error) {
 	var count int64
 	for _, p := range r.participants {
@@ -165,6 +545,16 @@ func (r *mockActivityRepo) CountAccepted(activityID uint) (int64, error) {
 	return count, nil
 }
 
+func (r *mockActivityRepo) CountAcceptedByRole(activityID uint) (map[string]int64, error) {
+	result := make(map[string]int64)
+	for _, p := range r.participants {
+		if p.ActivityID == activityID && p.Status == "accepted" {
+			result[p.Role]++
+		}
+	}
+	return result, nil
+}
+
 func (r *mockActivityRepo) BatchCountAccepted(activityIDs []uint) (map[uint]int64, error) {
 	result := make(map[uint]int64)
 	for _, id := range activityIDs {
@@ -186,16 +576,40 @@ func (r *mockActivityRepo) GetApplicationsByUserID(userID uint) ([]model.Activit
 
 // --- Mock Notification Service ---
 
-type mockNotificationService struct{}
+type mockNotificationService struct {
+	sent []model.Notification
+}
 
 func newMockNotificationService() *mockNotificationService {
 	return &mockNotificationService{}
 }
 
 func (m *mockNotificationService) SendNotification(userID, actorID uint, notifType, referenceID, content string) error {
+	m.sent = append(m.sent, model.Notification{UserID: userID, ActorID: actorID, Type: notifType, ReferenceID: referenceID, Content: content})
 	return nil
 }
 
+// sentTo reports whether a notification of notifType was sent to userID.
+func (m *mockNotificationService) sentTo(userID uint, notifType string) bool {
+	for _, n := range m.sent {
+		if n.UserID == userID && n.Type == notifType {
+			return true
+		}
+	}
+	return false
+}
+
+// sentOf returns the notifications of a type sent to a user.
+func (m *mockNotificationService) sentOf(userID uint, notifType string) []model.Notification {
+	var result []model.Notification
+	for _, n := range m.sent {
+		if n.UserID == userID && n.Type == notifType {
+			result = append(result, n)
+		}
+	}
+	return result
+}
+
 func (m *mockNotificationService) GetByUserID(userID uint) ([]model.Notification, error) {
 	return nil, nil
 }
@@ -208,12 +622,24 @@ func (m *mockNotificationService) GetUnreadCount(userID uint) (int64, error) {
 	return 0, nil
 }
 
+func (m *mockNotificationService) GetPreferences(userID uint) (*model.NotificationPreference, error) {
+	return &model.NotificationPreference{UserID: userID, EmailDigest: true}, nil
+}
+
+func (m *mockNotificationService) UpdatePreferences(userID uint, _ service.UpdateNotificationPreferencesInput) (*model.NotificationPreference, error) {
+	return &model.NotificationPreference{UserID: userID}, nil
+}
+
+func (m *mockNotificationService) Unsubscribe(_ string) error {
+	return nil
+}
+
 // --- Activity Service Tests ---
 
 func TestCreateActivity(t *testing.T) {
 	repo := newMockActivityRepo()
 	notif := newMockNotificationService()
-	svc := service.NewActivityService(repo, newMockCommentRepo(), newMockRatingRepo(), "http://localhost:8080", "", notif)
+	svc := service.NewActivityService(repo, newMockCommentRepo(), newMockRatingRepo(), newMockUserRepo(), newMockChatRepo(repo), newMockFollowRepo(), newMockWorkRepo(), "http://localhost:8080", "", notif, "http://localhost:3000", testLinkSecret)
 
 	input := service.CreateActivityInput{
 		Title:       "Test Activity",
@@ -238,7 +664,7 @@ func TestCreateActivity(t *testing.T) {
 
 func TestUpdateActivity_OnlyHost(t *testing.T) {
 	repo := newMockActivityRepo()
-	svc := service.NewActivityService(repo, newMockCommentRepo(), newMockRatingRepo(), "http://localhost:8080", "", newMockNotificationService())
+	svc := service.NewActivityService(repo, newMockCommentRepo(), newMockRatingRepo(), newMockUserRepo(), newMockChatRepo(repo), newMockFollowRepo(), newMockWorkRepo(), "http://localhost:8080", "", newMockNotificationService(), "http://localhost:3000", testLinkSecret)
 
 	input := service.CreateActivityInput{Title: "Test Activity"}
 	activity, _ := svc.Create(1, input)
@@ -261,7 +687,7 @@ func TestUpdateActivity_OnlyHost(t *testing.T) {
 
 func TestDeleteActivity_OnlyHost(t *testing.T) {
 	repo := newMockActivityRepo()
-	svc := service.NewActivityService(repo, newMockCommentRepo(), newMockRatingRepo(), "http://localhost:8080", "", newMockNotificationService())
+	svc := service.NewActivityService(repo, newMockCommentRepo(), newMockRatingRepo(), newMockUserRepo(), newMockChatRepo(repo), newMockFollowRepo(), newMockWorkRepo(), "http://localhost:8080", "", newMockNotificationService(), "http://localhost:3000", testLinkSecret)
 
 	input := service.CreateActivityInput{Title: "Test Activity"}
 	activity, _ := svc.Create(1, input)
@@ -279,12 +705,12 @@ func TestDeleteActivity_OnlyHost(t *testing.T) {
 
 func TestApply_HostCannotApply(t *testing.T) {
 	repo := newMockActivityRepo()
-	svc := service.NewActivityService(repo, newMockCommentRepo(), newMockRatingRepo(), "http://localhost:8080", "", newMockNotificationService())
+	svc := service.NewActivityService(repo, newMockCommentRepo(), newMockRatingRepo(), newMockUserRepo(), newMockChatRepo(repo), newMockFollowRepo(), newMockWorkRepo(), "http://localhost:8080", "", newMockNotificationService(), "http://localhost:3000", testLinkSecret)
 
 	input := service.CreateActivityInput{Title: "Test Activity"}
 	activity, _ := svc.Create(1, input)
 
-	err := svc.Apply(activity.ID, 1, "I want to join")
+	err := svc.Apply(activity.ID, 1, service.ApplyInput{Message: "I want to join"})
 	if err == nil {
 		t.Fatal("host should not be able to apply to own activity")
 	}
@@ -292,12 +718,12 @@ func TestApply_HostCannotApply(t *testing.T) {
 
 func TestApply_Success(t *testing.T) {
 	repo := newMockActivityRepo()
-	svc := service.NewActivityService(repo, newMockCommentRepo(), newMockRatingRepo(), "http://localhost:8080", "", newMockNotificationService())
+	svc := service.NewActivityService(repo, newMockCommentRepo(), newMockRatingRepo(), newMockUserRepo(), newMockChatRepo(repo), newMockFollowRepo(), newMockWorkRepo(), "http://localhost:8080", "", newMockNotificationService(), "http://localhost:3000", testLinkSecret)
 
 	input := service.CreateActivityInput{Title: "Test Activity", MaxParticipants: 10}
 	activity, _ := svc.Create(1, input)
 
-	err := svc.Apply(activity.ID, 2, "I want to join")
+	err := svc.Apply(activity.ID, 2, service.ApplyInput{Message: "I want to join"})
 	if err != nil {
 		t.Fatalf("Apply failed: %v", err)
 	}
@@ -305,13 +731,13 @@ func TestApply_Success(t *testing.T) {
 
 func TestApply_Duplicate(t *testing.T) {
 	repo := newMockActivityRepo()
-	svc := service.NewActivityService(repo, newMockCommentRepo(), newMockRatingRepo(), "http://localhost:8080", "", newMockNotificationService())
+	svc := service.NewActivityService(repo, newMockCommentRepo(), newMockRatingRepo(), newMockUserRepo(), newMockChatRepo(repo), newMockFollowRepo(), newMockWorkRepo(), "http://localhost:8080", "", newMockNotificationService(), "http://localhost:3000", testLinkSecret)
 
 	input := service.CreateActivityInput{Title: "Test Activity", MaxParticipants: 10}
 	activity, _ := svc.Create(1, input)
 
-	_ = svc.Apply(activity.ID, 2, "first")
-	err := svc.Apply(activity.ID, 2, "second")
+	_ = svc.Apply(activity.ID, 2, service.ApplyInput{Message: "first"})
+	err := svc.Apply(activity.ID, 2, service.ApplyInput{Message: "second"})
 	if err == nil {
 		t.Fatal("duplicate application should fail")
 	}
@@ -319,14 +745,14 @@ func TestApply_Duplicate(t *testing.T) {
 
 func TestApply_NotOpenActivity(t *testing.T) {
 	repo := newMockActivityRepo()
-	svc := service.NewActivityService(repo, newMockCommentRepo(), newMockRatingRepo(), "http://localhost:8080", "", newMockNotificationService())
+	svc := service.NewActivityService(repo, newMockCommentRepo(), newMockRatingRepo(), newMockUserRepo(), newMockChatRepo(repo), newMockFollowRepo(), newMockWorkRepo(), "http://localhost:8080", "", newMockNotificationService(), "http://localhost:3000", testLinkSecret)
 
 	input := service.CreateActivityInput{Title: "Test Activity", MaxParticipants: 10}
 	activity, _ := svc.Create(1, input)
 	activity.Status = "ended"
 	_ = repo.Update(activity)
 
-	err := svc.Apply(activity.ID, 2, "join")
+	err := svc.Apply(activity.ID, 2, service.ApplyInput{Message: "join"})
 	if err == nil {
 		t.Fatal("should not allow application to non-open activity")
 	}
@@ -334,7 +760,7 @@ func TestApply_NotOpenActivity(t *testing.T) {
 
 func TestGetUserStatus(t *testing.T) {
 	repo := newMockActivityRepo()
-	svc := service.NewActivityService(repo, newMockCommentRepo(), newMockRatingRepo(), "http://localhost:8080", "", newMockNotificationService())
+	svc := service.NewActivityService(repo, newMockCommentRepo(), newMockRatingRepo(), newMockUserRepo(), newMockChatRepo(repo), newMockFollowRepo(), newMockWorkRepo(), "http://localhost:8080", "", newMockNotificationService(), "http://localhost:3000", testLinkSecret)
 
 	input := service.CreateActivityInput{Title: "Test Activity", MaxParticipants: 10}
 	activity, _ := svc.Create(1, input)
@@ -352,7 +778,7 @@ func TestGetUserStatus(t *testing.T) {
 	}
 
 	// Applied
-	_ = svc.Apply(activity.ID, 2, "join")
+	_ = svc.Apply(activity.ID, 2, service.ApplyInput{Message: "join"})
 	status, _ = svc.GetUserStatus(activity.ID, 2)
 	if status != "pending" {
 		t.Errorf("Status = %s, want pending", status)
@@ -361,11 +787,11 @@ func TestGetUserStatus(t *testing.T) {
 
 func TestUpdateApplicantStatus_OnlyHost(t *testing.T) {
 	repo := newMockActivityRepo()
-	svc := service.NewActivityService(repo, newMockCommentRepo(), newMockRatingRepo(), "http://localhost:8080", "", newMockNotificationService())
+	svc := service.NewActivityService(repo, newMockCommentRepo(), newMockRatingRepo(), newMockUserRepo(), newMockChatRepo(repo), newMockFollowRepo(), newMockWorkRepo(), "http://localhost:8080", "", newMockNotificationService(), "http://localhost:3000", testLinkSecret)
 
 	input := service.CreateActivityInput{Title: "Test Activity", MaxParticipants: 10}
 	activity, _ := svc.Create(1, input)
-	_ = svc.Apply(activity.ID, 2, "join")
+	_ = svc.Apply(activity.ID, 2, service.ApplyInput{Message: "join"})
 
 	// Non-host
 	err := svc.UpdateApplicantStatus(activity.ID, 3, 2, "accepted")
@@ -382,11 +808,11 @@ func TestUpdateApplicantStatus_OnlyHost(t *testing.T) {
 
 func TestUpdateApplicantStatus_InvalidStatus(t *testing.T) {
 	repo := newMockActivityRepo()
-	svc := service.NewActivityService(repo, newMockCommentRepo(), newMockRatingRepo(), "http://localhost:8080", "", newMockNotificationService())
+	svc := service.NewActivityService(repo, newMockCommentRepo(), newMockRatingRepo(), newMockUserRepo(), newMockChatRepo(repo), newMockFollowRepo(), newMockWorkRepo(), "http://localhost:8080", "", newMockNotificationService(), "http://localhost:3000", testLinkSecret)
 
 	input := service.CreateActivityInput{Title: "Test Activity", MaxParticipants: 10}
 	activity, _ := svc.Create(1, input)
-	_ = svc.Apply(activity.ID, 2, "join")
+	_ = svc.Apply(activity.ID, 2, service.ApplyInput{Message: "join"})
 
 	err := svc.UpdateApplicantStatus(activity.ID, 1, 2, "invalid_status")
 	if err == nil {
@@ -396,7 +822,7 @@ func TestUpdateApplicantStatus_InvalidStatus(t *testing.T) {
 
 func TestCreateActivity_EventTimeWithTimezoneOffset(t *testing.T) {
 	repo := newMockActivityRepo()
-	svc := service.NewActivityService(repo, newMockCommentRepo(), newMockRatingRepo(), "http://localhost:8080", "", newMockNotificationService())
+	svc := service.NewActivityService(repo, newMockCommentRepo(), newMockRatingRepo(), newMockUserRepo(), newMockChatRepo(repo), newMockFollowRepo(), newMockWorkRepo(), "http://localhost:8080", "", newMockNotificationService(), "http://localhost:3000", testLinkSecret)
 
 	input := service.CreateActivityInput{
 		Title:     "Timezone Test",
@@ -428,7 +854,7 @@ func TestCreateActivity_EventTimeWithTimezoneOffset(t *testing.T) {
 	}
 
 	// Verify via GetByID
-	fetched, err := svc.GetByID(activity.ID)
+	fetched, err := svc.GetByID(activity.ID, 0, "")
 	if err != nil {
 		t.Fatalf("GetByID failed: %v", err)
 	}
@@ -439,7 +865,7 @@ func TestCreateActivity_EventTimeWithTimezoneOffset(t *testing.T) {
 
 func TestCreateActivity_EventTimeWithoutOffset(t *testing.T) {
 	repo := newMockActivityRepo()
-	svc := service.NewActivityService(repo, newMockCommentRepo(), newMockRatingRepo(), "http://localhost:8080", "", newMockNotificationService())
+	svc := service.NewActivityService(repo, newMockCommentRepo(), newMockRatingRepo(), newMockUserRepo(), newMockChatRepo(repo), newMockFollowRepo(), newMockWorkRepo(), "http://localhost:8080", "", newMockNotificationService(), "http://localhost:3000", testLinkSecret)
 
 	input := service.CreateActivityInput{
 		Title:     "No Offset Test",
@@ -451,18 +877,142 @@ func TestCreateActivity_EventTimeWithoutOffset(t *testing.T) {
 		t.Fatalf("Create failed: %v", err)
 	}
 
-	// Without offset, time.Parse treats it as UTC, so it stays 21:00 UTC
-	if activity.EventTime.Hour() != 21 {
-		t.Errorf("EventTime hour = %d, want 21", activity.EventTime.Hour())
+	// Without offset, the time is read in the default Asia/Taipei zone: 21:00 local = 13:00 UTC
+	if activity.EventTime.Hour() != 13 {
+		t.Errorf("EventTime hour = %d, want 13", activity.EventTime.Hour())
 	}
 	if activity.EventTime.Location().String() != "UTC" {
 		t.Errorf("EventTime location = %s, want UTC", activity.EventTime.Location().String())
 	}
+	if activity.Timezone != model.DefaultTimezone {
+		t.Errorf("Timezone = %q, want %q", activity.Timezone, model.DefaultTimezone)
+	}
+}
+
+func TestCreateActivity_ExplicitTimezone(t *testing.T) {
+	repo := newMockActivityRepo()
+	svc := service.NewActivityService(repo, newMockCommentRepo(), newMockRatingRepo(), newMockUserRepo(), newMockChatRepo(repo), newMockFollowRepo(), newMockWorkRepo(), "http://localhost:8080", "", newMockNotificationService(), "http://localhost:3000", testLinkSecret)
+
+	activity, err := svc.Create(1, service.CreateActivityInput{
+		Title:     "Tokyo Shoot",
+		EventTime: "2026-07-01 10:00",
+		Timezone:  "Asia/Tokyo",
+	})
+	if err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+
+	want := time.Date(2026, 7, 1, 1, 0, 0, 0, time.UTC)
+	if !activity.EventTime.Equal(want) {
+		t.Errorf("EventTime = %v, want %v", activity.EventTime, want)
+	}
+	activity.Localize()
+	if activity.LocalEventTime != "2026-07-01T10:00:00+09:00" {
+		t.Errorf("LocalEventTime = %q, want 2026-07-01T10:00:00+09:00", activity.LocalEventTime)
+	}
+
+	// An explicit offset wins over the activity's zone
+	activity, err = svc.Create(1, service.CreateActivityInput{
+		Title:     "Offset Shoot",
+		EventTime: "2026-07-01T10:00:00Z",
+		Timezone:  "Asia/Tokyo",
+	})
+	if err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+	if activity.EventTime.Hour() != 10 {
+		t.Errorf("EventTime hour = %d, want 10", activity.EventTime.Hour())
+	}
+
+	// Changing only the timezone keeps the same instant
+	updated, err := svc.Update(1, activity.ID, service.UpdateActivityInput{Timezone: "Europe/London"})
+	if err != nil {
+		t.Fatalf("Update failed: %v", err)
+	}
+	if updated.EventTime.Hour() != 10 || updated.Timezone != "Europe/London" {
+		t.Errorf("after timezone change: EventTime = %v, Timezone = %q", updated.EventTime, updated.Timezone)
+	}
+
+	// A new local time is read in the updated zone (BST, UTC+1)
+	updated, err = svc.Update(1, activity.ID, service.UpdateActivityInput{EventTime: "2026-07-02 18:30"})
+	if err != nil {
+		t.Fatalf("Update failed: %v", err)
+	}
+	want = time.Date(2026, 7, 2, 17, 30, 0, 0, time.UTC)
+	if !updated.EventTime.Equal(want) {
+		t.Errorf("EventTime = %v, want %v", updated.EventTime, want)
+	}
+}
+
+func TestCreateActivity_InvalidTimezone(t *testing.T) {
+	repo := newMockActivityRepo()
+	svc := service.NewActivityService(repo, newMockCommentRepo(), newMockRatingRepo(), newMockUserRepo(), newMockChatRepo(repo), newMockFollowRepo(), newMockWorkRepo(), "http://localhost:8080", "", newMockNotificationService(), "http://localhost:3000", testLinkSecret)
+
+	_, err := svc.Create(1, service.CreateActivityInput{
+		Title:     "Nowhere",
+		EventTime: "2026-07-01 10:00",
+		Timezone:  "Mars/Olympus_Mons",
+	})
+	if err == nil {
+		t.Fatal("expected error for unknown timezone")
+	}
+}
+
+func TestSendReminders_LocalTimeAndOnce(t *testing.T) {
+	repo := newMockActivityRepo()
+	notif := newMockNotificationService()
+	svc := service.NewActivityService(repo, newMockCommentRepo(), newMockRatingRepo(), newMockUserRepo(), newMockChatRepo(repo), newMockFollowRepo(), newMockWorkRepo(), "http://localhost:8080", "", notif, "http://localhost:3000", testLinkSecret)
+
+	soon := time.Now().Add(3 * time.Hour).UTC()
+	activity, err := svc.Create(1, service.CreateActivityInput{
+		Title:     "Night Walk",
+		EventTime: soon.Format(time.RFC3339),
+		Timezone:  "America/New_York",
+	})
+	if err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+	later, err := svc.Create(1, service.CreateActivityInput{
+		Title:     "Next Week",
+		EventTime: time.Now().Add(7 * 24 * time.Hour).UTC().Format(time.RFC3339),
+	})
+	if err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+	for _, id := range []uint{activity.ID, later.ID} {
+		if err := svc.Apply(id, 2, service.ApplyInput{}); err != nil {
+			t.Fatalf("Apply failed: %v", err)
+		}
+		if err := svc.UpdateApplicantStatus(id, 1, 2, "accepted"); err != nil {
+			t.Fatalf("UpdateApplicantStatus failed: %v", err)
+		}
+	}
+
+	if err := svc.SendReminders(); err != nil {
+		t.Fatalf("SendReminders failed: %v", err)
+	}
+	reminders := notif.sentOf(2, "activity_reminder")
+	if len(reminders) != 1 {
+		t.Fatalf("reminders = %d, want 1", len(reminders))
+	}
+	ny, _ := time.LoadLocation("America/New_York")
+	wantTime := soon.In(ny).Format("01/02 15:04 MST")
+	if !strings.Contains(reminders[0].Content, wantTime) {
+		t.Errorf("reminder %q should mention local time %s", reminders[0].Content, wantTime)
+	}
+
+	// Already reminded activities are skipped
+	if err := svc.SendReminders(); err != nil {
+		t.Fatalf("SendReminders failed: %v", err)
+	}
+	if got := len(notif.sentOf(2, "activity_reminder")); got != 1 {
+		t.Errorf("reminders after second run = %d, want 1", got)
+	}
 }
 
 func TestGetByID_AutoEndExpiredActivity(t *testing.T) {
 	repo := newMockActivityRepo()
-	svc := service.NewActivityService(repo, newMockCommentRepo(), newMockRatingRepo(), "http://localhost:8080", "", newMockNotificationService())
+	svc := service.NewActivityService(repo, newMockCommentRepo(), newMockRatingRepo(), newMockUserRepo(), newMockChatRepo(repo), newMockFollowRepo(), newMockWorkRepo(), "http://localhost:8080", "", newMockNotificationService(), "http://localhost:3000", testLinkSecret)
 
 	// Create an activity with an event time in the past (1 hour ago)
 	input := service.CreateActivityInput{
@@ -481,7 +1031,7 @@ func TestGetByID_AutoEndExpiredActivity(t *testing.T) {
 
 	// BE-H2 CQS fix: GetByID is a pure read — it no longer triggers auto-end.
 	// The status remains 'open' as persisted in the DB until List runs.
-	fetched, err := svc.GetByID(created.ID)
+	fetched, err := svc.GetByID(created.ID, 0, "")
 	if err != nil {
 		t.Fatalf("GetByID failed: %v", err)
 	}
@@ -501,3 +1051,1526 @@ func TestGetByID_AutoEndExpiredActivity(t *testing.T) {
 		t.Errorf("status after List = %q, want 'ended'", activities[0].Status)
 	}
 }
+
+func TestRoleSlots_CreateSyncsRolesAndCapacity(t *testing.T) {
+	repo := newMockActivityRepo()
+	svc := service.NewActivityService(repo, newMockCommentRepo(), newMockRatingRepo(), newMockUserRepo(), newMockChatRepo(repo), newMockFollowRepo(), newMockWorkRepo(), "http://localhost:8080", "", newMockNotificationService(), "http://localhost:3000", testLinkSecret)
+
+	activity, err := svc.Create(1, service.CreateActivityInput{
+		Title: "Studio Shoot",
+		RoleSlots: []service.RoleSlotInput{
+			{Role: "model", Count: 2},
+			{Role: "makeup", Count: 1},
+		},
+	})
+	if err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+	if activity.MaxParticipants != 3 {
+		t.Errorf("MaxParticipants = %d, want 3", activity.MaxParticipants)
+	}
+	if len(activity.Roles) != 2 || activity.Roles[0] != "model" || activity.Roles[1] != "makeup" {
+		t.Errorf("Roles = %v, want [model makeup]", activity.Roles)
+	}
+
+	_, err = svc.Create(1, service.CreateActivityInput{
+		Title:     "Duplicate Roles",
+		RoleSlots: []service.RoleSlotInput{{Role: "model", Count: 1}, {Role: "model", Count: 2}},
+	})
+	if err == nil {
+		t.Fatal("duplicate roles should be rejected")
+	}
+}
+
+func TestRoleSlots_ApplyRequiresValidRole(t *testing.T) {
+	repo := newMockActivityRepo()
+	svc := service.NewActivityService(repo, newMockCommentRepo(), newMockRatingRepo(), newMockUserRepo(), newMockChatRepo(repo), newMockFollowRepo(), newMockWorkRepo(), "http://localhost:8080", "", newMockNotificationService(), "http://localhost:3000", testLinkSecret)
+
+	activity, _ := svc.Create(1, service.CreateActivityInput{
+		Title:     "Studio Shoot",
+		RoleSlots: []service.RoleSlotInput{{Role: "model", Count: 1}},
+	})
+
+	if err := svc.Apply(activity.ID, 2, service.ApplyInput{Message: "join"}); err == nil {
+		t.Fatal("apply without role should fail when role slots exist")
+	}
+	if err := svc.Apply(activity.ID, 2, service.ApplyInput{Role: "stylist"}); err == nil {
+		t.Fatal("apply with unknown role should fail")
+	}
+	if err := svc.Apply(activity.ID, 2, service.ApplyInput{Role: "model"}); err != nil {
+		t.Fatalf("apply with valid role failed: %v", err)
+	}
+}
+
+func TestRoleSlots_AcceptanceCheckedPerRole(t *testing.T) {
+	repo := newMockActivityRepo()
+	svc := service.NewActivityService(repo, newMockCommentRepo(), newMockRatingRepo(), newMockUserRepo(), newMockChatRepo(repo), newMockFollowRepo(), newMockWorkRepo(), "http://localhost:8080", "", newMockNotificationService(), "http://localhost:3000", testLinkSecret)
+
+	activity, _ := svc.Create(1, service.CreateActivityInput{
+		Title: "Studio Shoot",
+		RoleSlots: []service.RoleSlotInput{
+			{Role: "model", Count: 1},
+			{Role: "makeup", Count: 1},
+		},
+	})
+	_ = svc.Apply(activity.ID, 2, service.ApplyInput{Role: "model"})
+	_ = svc.Apply(activity.ID, 3, service.ApplyInput{Role: "model"})
+	_ = svc.Apply(activity.ID, 4, service.ApplyInput{Role: "makeup"})
+
+	if err := svc.UpdateApplicantStatus(activity.ID, 1, 2, "accepted"); err != nil {
+		t.Fatalf("accept first model failed: %v", err)
+	}
+	if err := svc.UpdateApplicantStatus(activity.ID, 1, 3, "accepted"); err == nil {
+		t.Fatal("accepting beyond the model slot count should fail")
+	}
+
+	// One role filled is not enough to mark the activity full
+	if a, _ := repo.GetByID(activity.ID); a.Status != "open" {
+		t.Errorf("Status = %s, want open while makeup slot is free", a.Status)
+	}
+
+	if err := svc.UpdateApplicantStatus(activity.ID, 1, 4, "accepted"); err != nil {
+		t.Fatalf("accept makeup failed: %v", err)
+	}
+	if a, _ := repo.GetByID(activity.ID); a.Status != "full" {
+		t.Errorf("Status = %s, want full once every role is filled", a.Status)
+	}
+
+	models, _ := svc.ListApplicants(activity.ID, 1, repository.ApplicantFilter{Role: "model"})
+	if len(models) != 2 {
+		t.Errorf("ListApplicants(role=model) = %d, want 2", len(models))
+	}
+}
+
+func TestRoleSlots_UpdateCannotDropFilledRole(t *testing.T) {
+	repo := newMockActivityRepo()
+	svc := service.NewActivityService(repo, newMockCommentRepo(), newMockRatingRepo(), newMockUserRepo(), newMockChatRepo(repo), newMockFollowRepo(), newMockWorkRepo(), "http://localhost:8080", "", newMockNotificationService(), "http://localhost:3000", testLinkSecret)
+
+	activity, _ := svc.Create(1, service.CreateActivityInput{
+		Title:     "Studio Shoot",
+		RoleSlots: []service.RoleSlotInput{{Role: "model", Count: 2}},
+	})
+	_ = svc.Apply(activity.ID, 2, service.ApplyInput{Role: "model"})
+	_ = svc.UpdateApplicantStatus(activity.ID, 1, 2, "accepted")
+
+	_, err := svc.Update(1, activity.ID, service.UpdateActivityInput{
+		RoleSlots: []service.RoleSlotInput{{Role: "makeup", Count: 1}},
+	})
+	if err == nil {
+		t.Fatal("removing a role with accepted participants should fail")
+	}
+
+	updated, err := svc.Update(1, activity.ID, service.UpdateActivityInput{
+		RoleSlots: []service.RoleSlotInput{{Role: "model", Count: 1}, {Role: "makeup", Count: 1}},
+	})
+	if err != nil {
+		t.Fatalf("valid slot update failed: %v", err)
+	}
+	if updated.MaxParticipants != 2 {
+		t.Errorf("MaxParticipants = %d, want 2", updated.MaxParticipants)
+	}
+}
+
+func TestApplicationForm_CreateValidatesQuestions(t *testing.T) {
+	repo := newMockActivityRepo()
+	svc := service.NewActivityService(repo, newMockCommentRepo(), newMockRatingRepo(), newMockUserRepo(), newMockChatRepo(repo), newMockFollowRepo(), newMockWorkRepo(), "http://localhost:8080", "", newMockNotificationService(), "http://localhost:3000", testLinkSecret)
+
+	cases := []struct {
+		name     string
+		question service.QuestionInput
+	}{
+		{"missing label", service.QuestionInput{Type: "short_text"}},
+		{"unknown type", service.QuestionInput{Type: "date", Label: "When?"}},
+		{"choice without options", service.QuestionInput{Type: "choice", Label: "Style", Options: []string{"Film"}}},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := svc.Create(1, service.CreateActivityInput{
+				Title:     "Studio Shoot",
+				Questions: []service.QuestionInput{tc.question},
+			})
+			if err == nil {
+				t.Fatal("expected validation error")
+			}
+		})
+	}
+}
+
+func TestApplicationForm_ApplyValidatesAnswers(t *testing.T) {
+	repo := newMockActivityRepo()
+	svc := service.NewActivityService(repo, newMockCommentRepo(), newMockRatingRepo(), newMockUserRepo(), newMockChatRepo(repo), newMockFollowRepo(), newMockWorkRepo(), "http://localhost:8080", "", newMockNotificationService(), "http://localhost:3000", testLinkSecret)
+
+	activity, err := svc.Create(1, service.CreateActivityInput{
+		Title: "Studio Shoot",
+		Questions: []service.QuestionInput{
+			{Type: "url", Label: "Portfolio", Required: true},
+			{Type: "choice", Label: "Style", Options: []string{"Film", "Digital"}},
+			{Type: "number", Label: "Height (cm)"},
+			{Type: "yes_no", Label: "Own transport?", Required: true},
+		},
+	})
+	if err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+	q := activity.Questions
+
+	cases := []struct {
+		name    string
+		answers []service.AnswerInput
+	}{
+		{"missing required", []service.AnswerInput{{QuestionID: q[3].ID, Value: "yes"}}},
+		{"bad url", []service.AnswerInput{{QuestionID: q[0].ID, Value: "not a link"}, {QuestionID: q[3].ID, Value: "yes"}}},
+		{"unknown option", []service.AnswerInput{{QuestionID: q[0].ID, Value: "https://a.b"}, {QuestionID: q[1].ID, Value: "Polaroid"}, {QuestionID: q[3].ID, Value: "no"}}},
+		{"not a number", []service.AnswerInput{{QuestionID: q[0].ID, Value: "https://a.b"}, {QuestionID: q[2].ID, Value: "tall"}, {QuestionID: q[3].ID, Value: "no"}}},
+		{"bad yes/no", []service.AnswerInput{{QuestionID: q[0].ID, Value: "https://a.b"}, {QuestionID: q[3].ID, Value: "maybe"}}},
+		{"unknown question", []service.AnswerInput{{QuestionID: q[0].ID, Value: "https://a.b"}, {QuestionID: q[3].ID, Value: "no"}, {QuestionID: 999, Value: "x"}}},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if err := svc.Apply(activity.ID, 2, service.ApplyInput{Answers: tc.answers}); err == nil {
+				t.Fatal("expected validation error")
+			}
+		})
+	}
+
+	err = svc.Apply(activity.ID, 2, service.ApplyInput{Answers: []service.AnswerInput{
+		{QuestionID: q[0].ID, Value: "https://portfolio.example.com"},
+		{QuestionID: q[1].ID, Value: "Film"},
+		{QuestionID: q[3].ID, Value: "TRUE"},
+	}})
+	if err != nil {
+		t.Fatalf("valid apply failed: %v", err)
+	}
+
+	applicants, _ := svc.ListApplicants(activity.ID, 1, repository.ApplicantFilter{})
+	if len(applicants) != 1 {
+		t.Fatalf("ListApplicants = %d, want 1", len(applicants))
+	}
+	answers := applicants[0].Answers
+	if len(answers) != 3 {
+		t.Fatalf("answers = %d, want 3", len(answers))
+	}
+	if answers[2].Label != "Own transport?" || answers[2].Value != "yes" {
+		t.Errorf("yes/no answer = %+v, want normalized 'yes'", answers[2])
+	}
+}
+
+func TestInvitation_AcceptFlow(t *testing.T) {
+	repo := newMockActivityRepo()
+	notif := newMockNotificationService()
+	users := newMockUserRepo()
+	_ = users.Create(&model.User{UserName: "host"})
+	_ = users.Create(&model.User{UserName: "model"})
+	_ = users.Create(&model.User{UserName: "stylist"})
+	svc := service.NewActivityService(repo, newMockCommentRepo(), newMockRatingRepo(), users, newMockChatRepo(repo), newMockFollowRepo(), newMockWorkRepo(), "http://localhost:8080", "", notif, "http://localhost:3000", testLinkSecret)
+
+	activity, _ := svc.Create(1, service.CreateActivityInput{
+		Title:           "Studio Shoot",
+		EventTime:       time.Now().Add(72 * time.Hour).UTC().Format(time.RFC3339),
+		MaxParticipants: 1,
+	})
+
+	if err := svc.InviteUser(activity.ID, 2, service.InviteInput{UserID: 3}); err == nil {
+		t.Fatal("non-host invite should fail")
+	}
+	if err := svc.InviteUser(activity.ID, 1, service.InviteInput{UserID: 99}); err == nil {
+		t.Fatal("inviting an unknown user should fail")
+	}
+	if err := svc.InviteUser(activity.ID, 1, service.InviteInput{UserID: 2}); err != nil {
+		t.Fatalf("InviteUser failed: %v", err)
+	}
+	if err := svc.InviteUser(activity.ID, 1, service.InviteInput{UserID: 2}); err == nil {
+		t.Fatal("duplicate invite should fail")
+	}
+
+	p, _ := repo.GetParticipant(activity.ID, 2)
+	if p.Status != "invited" || p.InviteExpiresAt == nil {
+		t.Fatalf("participant = %+v, want invited with expiry", p)
+	}
+	if !p.InviteExpiresAt.Equal(activity.EventTime) {
+		t.Errorf("InviteExpiresAt = %v, want capped at event time %v", p.InviteExpiresAt, activity.EventTime)
+	}
+	if err := svc.Apply(activity.ID, 2, service.ApplyInput{}); err == nil {
+		t.Fatal("invited user should accept the invitation instead of applying")
+	}
+
+	if err := svc.AcceptInvitation(activity.ID, 2); err != nil {
+		t.Fatalf("AcceptInvitation failed: %v", err)
+	}
+	if status, _ := svc.GetUserStatus(activity.ID, 2); status != "accepted" {
+		t.Errorf("status = %q, want accepted", status)
+	}
+	if !notif.sentTo(1, "invitation_accepted") {
+		t.Error("host should be notified of the acceptance")
+	}
+	if a, _ := repo.GetByID(activity.ID); a.Status != "full" {
+		t.Errorf("activity status = %q, want full", a.Status)
+	}
+
+	// A second invitee cannot accept once capacity is reached
+	_ = repo.CreateParticipant(&model.ActivityParticipant{ActivityID: activity.ID, UserID: 3, Status: "invited"})
+	if err := svc.AcceptInvitation(activity.ID, 3); err == nil {
+		t.Fatal("accepting beyond capacity should fail")
+	}
+}
+
+func TestInvitation_DeclineAndExpiry(t *testing.T) {
+	repo := newMockActivityRepo()
+	notif := newMockNotificationService()
+	users := newMockUserRepo()
+	_ = users.Create(&model.User{UserName: "host"})
+	_ = users.Create(&model.User{UserName: "model"})
+	svc := service.NewActivityService(repo, newMockCommentRepo(), newMockRatingRepo(), users, newMockChatRepo(repo), newMockFollowRepo(), newMockWorkRepo(), "http://localhost:8080", "", notif, "http://localhost:3000", testLinkSecret)
+
+	activity, _ := svc.Create(1, service.CreateActivityInput{Title: "Studio Shoot"})
+	_ = svc.InviteUser(activity.ID, 1, service.InviteInput{UserID: 2})
+
+	if err := svc.DeclineInvitation(activity.ID, 2); err != nil {
+		t.Fatalf("DeclineInvitation failed: %v", err)
+	}
+	if !notif.sentTo(1, "invitation_declined") {
+		t.Error("host should be notified of the decline")
+	}
+	if err := svc.AcceptInvitation(activity.ID, 2); err == nil {
+		t.Fatal("accepting a declined invitation should fail")
+	}
+
+	// The host may invite again after a decline
+	if err := svc.InviteUser(activity.ID, 1, service.InviteInput{UserID: 2}); err != nil {
+		t.Fatalf("re-invite after decline failed: %v", err)
+	}
+
+	past := time.Now().Add(-time.Hour)
+	p, _ := repo.GetParticipant(activity.ID, 2)
+	p.InviteExpiresAt = &past
+	if err := svc.AcceptInvitation(activity.ID, 2); err == nil {
+		t.Fatal("accepting an expired invitation should fail")
+	}
+	if status, _ := svc.GetUserStatus(activity.ID, 2); status != "idle" {
+		t.Errorf("status with expired invite = %q, want idle", status)
+	}
+	if err := svc.Apply(activity.ID, 2, service.ApplyInput{Message: "still keen"}); err != nil {
+		t.Fatalf("apply after expired invite failed: %v", err)
+	}
+	if status, _ := svc.GetUserStatus(activity.ID, 2); status != "pending" {
+		t.Errorf("status after apply = %q, want pending", status)
+	}
+}
+
+func TestInvitation_CoHostCannotInviteHosts(t *testing.T) {
+	repo := newMockActivityRepo()
+	users := newMockUserRepo()
+	for _, name := range []string{"host", "manager", "editor", "guest"} {
+		_ = users.Create(&model.User{UserName: name})
+	}
+	svc := service.NewActivityService(repo, newMockCommentRepo(), newMockRatingRepo(), users, newMockChatRepo(repo), newMockFollowRepo(), newMockWorkRepo(), "http://localhost:8080", "", newMockNotificationService(), "http://localhost:3000", testLinkSecret)
+
+	activity, _ := svc.Create(1, service.CreateActivityInput{Title: "Team Shoot"})
+	_, _ = svc.AddCoHost(activity.ID, 1, service.CoHostInput{UserID: 2, Permission: model.CoHostManageApplicants})
+	_, _ = svc.AddCoHost(activity.ID, 1, service.CoHostInput{UserID: 3, Permission: model.CoHostEditDetails})
+
+	if err := svc.InviteUser(activity.ID, 2, service.InviteInput{UserID: 1}); err == nil {
+		t.Error("a co-host should not be able to invite the host")
+	}
+	if err := svc.InviteUser(activity.ID, 2, service.InviteInput{UserID: 3}); err == nil {
+		t.Error("a co-host should not be able to invite another co-host")
+	}
+	if _, err := repo.GetParticipant(activity.ID, 1); err == nil {
+		t.Error("no participant row should be created for the host")
+	}
+	if err := svc.InviteUser(activity.ID, 2, service.InviteInput{UserID: 4}); err != nil {
+		t.Errorf("co-host invite of a guest failed: %v", err)
+	}
+}
+
+func TestVisibility_PrivateActivityHiddenFromOutsiders(t *testing.T) {
+	repo := newMockActivityRepo()
+	users := newMockUserRepo()
+	_ = users.Create(&model.User{UserName: "host"})
+	_ = users.Create(&model.User{UserName: "viewer"})
+	_ = users.Create(&model.User{UserName: "guest"})
+	svc := service.NewActivityService(repo, newMockCommentRepo(), newMockRatingRepo(), users, newMockChatRepo(repo), newMockFollowRepo(), newMockWorkRepo(), "http://localhost:8080", "", newMockNotificationService(), "http://localhost:3000", testLinkSecret)
+
+	public, _ := svc.Create(1, service.CreateActivityInput{Title: "Open Shoot"})
+	unlisted, _ := svc.Create(1, service.CreateActivityInput{Title: "Link Only", Visibility: "unlisted"})
+	private, _ := svc.Create(1, service.CreateActivityInput{Title: "Closed Shoot", Visibility: "private"})
+
+	if public.Visibility != "public" {
+		t.Errorf("default visibility = %q, want public", public.Visibility)
+	}
+	if _, err := svc.Create(1, service.CreateActivityInput{Title: "Bad", Visibility: "secret"}); err == nil {
+		t.Error("unknown visibility should be rejected")
+	}
+
+	listed, _, _ := svc.List(repository.ActivityFilter{})
+	if len(listed) != 1 || listed[0].ID != public.ID {
+		t.Errorf("List returned %d activities, want only the public one", len(listed))
+	}
+
+	if _, err := svc.GetByID(unlisted.ID, 0, ""); err != nil {
+		t.Errorf("unlisted activity should be viewable by ID: %v", err)
+	}
+	if _, err := svc.GetByID(private.ID, 2, ""); err == nil {
+		t.Error("private activity should be hidden from outsiders")
+	}
+	if _, err := svc.GetByID(private.ID, 1, ""); err != nil {
+		t.Errorf("host should see their private activity: %v", err)
+	}
+	if err := svc.Apply(private.ID, 2, service.ApplyInput{}); err == nil {
+		t.Error("applying to a private activity without a link should fail")
+	}
+
+	profile, _ := svc.GetByUserID(1, 2)
+	if len(profile) != 1 {
+		t.Errorf("profile shows %d activities to others, want 1", len(profile))
+	}
+	own, _ := svc.GetByUserID(1, 1)
+	if len(own) != 3 {
+		t.Errorf("profile shows %d activities to the host, want 3", len(own))
+	}
+
+	_ = svc.InviteUser(private.ID, 1, service.InviteInput{UserID: 3})
+	if _, err := svc.GetByID(private.ID, 3, ""); err != nil {
+		t.Errorf("invited user should see the private activity: %v", err)
+	}
+}
+
+func TestInviteLinks_ApplyAndAutoAccept(t *testing.T) {
+	repo := newMockActivityRepo()
+	notif := newMockNotificationService()
+	svc := service.NewActivityService(repo, newMockCommentRepo(), newMockRatingRepo(), newMockUserRepo(), newMockChatRepo(repo), newMockFollowRepo(), newMockWorkRepo(), "http://localhost:8080", "", notif, "http://localhost:3000", testLinkSecret)
+
+	activity, _ := svc.Create(1, service.CreateActivityInput{Title: "Closed Shoot", Visibility: "private"})
+
+	if _, err := svc.CreateInviteLink(activity.ID, 2, service.CreateInviteLinkInput{}); err == nil {
+		t.Fatal("non-host should not create invite links")
+	}
+	applyLink, err := svc.CreateInviteLink(activity.ID, 1, service.CreateInviteLinkInput{MaxUses: 1})
+	if err != nil {
+		t.Fatalf("CreateInviteLink failed: %v", err)
+	}
+	if applyLink.Token == "" || applyLink.URL == "" {
+		t.Fatalf("link = %+v, want token and URL", applyLink)
+	}
+
+	if _, err := svc.GetByID(activity.ID, 2, applyLink.Token); err != nil {
+		t.Errorf("link holder should see the private activity: %v", err)
+	}
+	if _, err := svc.GetByID(activity.ID, 2, applyLink.Token+"x"); err == nil {
+		t.Error("tampered token should not grant access")
+	}
+
+	if err := svc.Apply(activity.ID, 2, service.ApplyInput{InviteToken: applyLink.Token}); err != nil {
+		t.Fatalf("apply with link failed: %v", err)
+	}
+	if status, _ := svc.GetUserStatus(activity.ID, 2); status != "pending" {
+		t.Errorf("status = %q, want pending for an apply link", status)
+	}
+	if err := svc.Apply(activity.ID, 3, service.ApplyInput{InviteToken: applyLink.Token}); err == nil {
+		t.Error("link past its use limit should be rejected")
+	}
+
+	autoLink, _ := svc.CreateInviteLink(activity.ID, 1, service.CreateInviteLinkInput{Mode: "auto_accept"})
+	if err := svc.Apply(activity.ID, 3, service.ApplyInput{InviteToken: autoLink.Token}); err != nil {
+		t.Fatalf("apply with auto-accept link failed: %v", err)
+	}
+	if status, _ := svc.GetUserStatus(activity.ID, 3); status != "accepted" {
+		t.Errorf("status = %q, want accepted for an auto-accept link", status)
+	}
+	if !notif.sentTo(1, "participant_joined") {
+		t.Error("host should be notified when someone joins via link")
+	}
+
+	if err := svc.RevokeInviteLink(activity.ID, 1, autoLink.ID); err != nil {
+		t.Fatalf("RevokeInviteLink failed: %v", err)
+	}
+	if err := svc.Apply(activity.ID, 4, service.ApplyInput{InviteToken: autoLink.Token}); err == nil {
+		t.Error("revoked link should be rejected")
+	}
+
+	other, _ := svc.Create(1, service.CreateActivityInput{Title: "Another", Visibility: "private"})
+	if err := svc.Apply(other.ID, 4, service.ApplyInput{InviteToken: applyLink.Token}); err == nil {
+		t.Error("a link should only work for its own activity")
+	}
+}
+
+func TestInviteLinks_AutoAcceptConcurrentFill(t *testing.T) {
+	repo := newMockActivityRepo()
+	svc := service.NewActivityService(repo, newMockCommentRepo(), newMockRatingRepo(), newMockUserRepo(), newMockChatRepo(repo), newMockFollowRepo(), newMockWorkRepo(), "http://localhost:8080", "", newMockNotificationService(), "http://localhost:3000", testLinkSecret)
+
+	activity, _ := svc.Create(1, service.CreateActivityInput{
+		Title:     "Closed Shoot",
+		RoleSlots: []service.RoleSlotInput{{Role: "model", Count: 1}},
+	})
+	link, _ := svc.CreateInviteLink(activity.ID, 1, service.CreateInviteLinkInput{Mode: "auto_accept"})
+
+	// Someone else takes the last model spot after the activity was read
+	repo.beforeStatusUpdate = func() {
+		_ = repo.CreateParticipant(&model.ActivityParticipant{ActivityID: activity.ID, UserID: 3, Role: "model", Status: "accepted"})
+	}
+	if err := svc.Apply(activity.ID, 2, service.ApplyInput{InviteToken: link.Token, Role: "model"}); err == nil {
+		t.Fatal("auto-accept should fail once the role filled up concurrently")
+	}
+	if _, err := repo.GetParticipant(activity.ID, 2); err == nil {
+		t.Error("no participant should be saved for the rejected application")
+	}
+	if links, _ := repo.ListInviteLinks(activity.ID); links[0].Uses != 0 {
+		t.Errorf("link uses = %d, want 0 after the rejected application", links[0].Uses)
+	}
+}
+
+func TestSeries_CreateGeneratesOccurrences(t *testing.T) {
+	repo := newMockActivityRepo()
+	svc := service.NewActivityService(repo, newMockCommentRepo(), newMockRatingRepo(), newMockUserRepo(), newMockChatRepo(repo), newMockFollowRepo(), newMockWorkRepo(), "http://localhost:8080", "", newMockNotificationService(), "http://localhost:3000", testLinkSecret)
+
+	start := time.Now().Add(72 * time.Hour).UTC().Truncate(time.Second)
+	first, err := svc.Create(1, service.CreateActivityInput{
+		Title:      "Weekly Studio Session",
+		EventTime:  start.Format(time.RFC3339),
+		Recurrence: "FREQ=WEEKLY;COUNT=4",
+		RoleSlots:  []service.RoleSlotInput{{Role: "model", Count: 2}},
+	})
+	if err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+	if first.SeriesID == nil {
+		t.Fatal("first occurrence should belong to a series")
+	}
+
+	occurrences, _ := svc.GetSeriesOccurrences(first.ID, 0)
+	if len(occurrences) != 4 {
+		t.Fatalf("occurrences = %d, want 4", len(occurrences))
+	}
+	for i, occ := range occurrences {
+		want := start.AddDate(0, 0, 7*i)
+		if !occ.EventTime.Equal(want) {
+			t.Errorf("occurrence %d at %v, want %v", i, occ.EventTime, want)
+		}
+		if occ.Title != "Weekly Studio Session" || len(occ.RoleSlots) != 1 {
+			t.Errorf("occurrence %d = %+v, want copied details", i, occ)
+		}
+	}
+
+	// The rule is exhausted, so the background job adds nothing
+	if err := svc.ExtendSeries(); err != nil {
+		t.Fatalf("ExtendSeries failed: %v", err)
+	}
+	if all, _ := svc.GetSeriesOccurrences(first.ID, 0); len(all) != 4 {
+		t.Errorf("occurrences after ExtendSeries = %d, want 4", len(all))
+	}
+
+	if _, err := svc.Create(1, service.CreateActivityInput{Title: "Daily", EventTime: start.Format(time.RFC3339), Recurrence: "FREQ=DAILY"}); err == nil {
+		t.Error("unsupported frequency should be rejected")
+	}
+	if _, err := svc.Create(1, service.CreateActivityInput{Title: "No Time", Recurrence: "FREQ=WEEKLY"}); err == nil {
+		t.Error("recurring activity without event time should be rejected")
+	}
+}
+
+func TestSeries_UnboundedStaysWithinHorizon(t *testing.T) {
+	repo := newMockActivityRepo()
+	svc := service.NewActivityService(repo, newMockCommentRepo(), newMockRatingRepo(), newMockUserRepo(), newMockChatRepo(repo), newMockFollowRepo(), newMockWorkRepo(), "http://localhost:8080", "", newMockNotificationService(), "http://localhost:3000", testLinkSecret)
+
+	first, _ := svc.Create(1, service.CreateActivityInput{
+		Title:      "Open Ended",
+		EventTime:  time.Now().Add(24 * time.Hour).UTC().Format(time.RFC3339),
+		Recurrence: "FREQ=WEEKLY",
+	})
+
+	occurrences, _ := svc.GetSeriesOccurrences(first.ID, 0)
+	if len(occurrences) < 7 || len(occurrences) > 9 {
+		t.Errorf("occurrences = %d, want about 8 weeks' worth", len(occurrences))
+	}
+}
+
+func TestSeries_EditOneVersusWholeSeries(t *testing.T) {
+	repo := newMockActivityRepo()
+	svc := service.NewActivityService(repo, newMockCommentRepo(), newMockRatingRepo(), newMockUserRepo(), newMockChatRepo(repo), newMockFollowRepo(), newMockWorkRepo(), "http://localhost:8080", "", newMockNotificationService(), "http://localhost:3000", testLinkSecret)
+
+	start := time.Now().Add(72 * time.Hour).UTC().Truncate(time.Second)
+	first, _ := svc.Create(1, service.CreateActivityInput{
+		Title:      "Weekly Studio Session",
+		EventTime:  start.Format(time.RFC3339),
+		Recurrence: "FREQ=WEEKLY;COUNT=3",
+	})
+	occurrences, _ := svc.GetSeriesOccurrences(first.ID, 0)
+
+	if _, err := svc.Update(1, occurrences[1].ID, service.UpdateActivityInput{Title: "Special Edition"}); err != nil {
+		t.Fatalf("single update failed: %v", err)
+	}
+	occurrences, _ = svc.GetSeriesOccurrences(first.ID, 0)
+	if occurrences[0].Title != "Weekly Studio Session" || occurrences[1].Title != "Special Edition" {
+		t.Errorf("titles = %q/%q, want only the second changed", occurrences[0].Title, occurrences[1].Title)
+	}
+
+	moved := start.Add(time.Hour)
+	_, err := svc.Update(1, first.ID, service.UpdateActivityInput{
+		Title:     "Evening Session",
+		EventTime: moved.Format(time.RFC3339),
+		Scope:     "series",
+	})
+	if err != nil {
+		t.Fatalf("series update failed: %v", err)
+	}
+	occurrences, _ = svc.GetSeriesOccurrences(first.ID, 0)
+	for i, occ := range occurrences {
+		if occ.Title != "Evening Session" {
+			t.Errorf("occurrence %d title = %q, want Evening Session", i, occ.Title)
+		}
+		if want := moved.AddDate(0, 0, 7*i); !occ.EventTime.Equal(want) {
+			t.Errorf("occurrence %d at %v, want %v", i, occ.EventTime, want)
+		}
+	}
+
+	standalone, _ := svc.Create(1, service.CreateActivityInput{Title: "One-off"})
+	if _, err := svc.Update(1, standalone.ID, service.UpdateActivityInput{Title: "x", Scope: "series"}); err == nil {
+		t.Error("series update on a standalone activity should fail")
+	}
+}
+
+func TestSeries_ExtendUsesSeriesTemplate(t *testing.T) {
+	repo := newMockActivityRepo()
+	svc := service.NewActivityService(repo, newMockCommentRepo(), newMockRatingRepo(), newMockUserRepo(), newMockChatRepo(repo), newMockFollowRepo(), newMockWorkRepo(), "http://localhost:8080", "", newMockNotificationService(), "http://localhost:3000", testLinkSecret)
+
+	start := time.Now().Add(72 * time.Hour).UTC().Truncate(time.Second)
+	first, _ := svc.Create(1, service.CreateActivityInput{
+		Title:      "Weekly Studio Session",
+		EventTime:  start.Format(time.RFC3339),
+		Recurrence: "FREQ=WEEKLY;COUNT=4",
+	})
+	occurrences, _ := svc.GetSeriesOccurrences(first.ID, 0)
+
+	// Pretend the fourth slot has not been generated yet
+	series := repo.series[*first.SeriesID]
+	delete(repo.activities, occurrences[3].ID)
+	series.LastSlot = occurrences[2].EventTime
+
+	// Edit only the (now) latest occurrence and move it a day earlier
+	_, err := svc.Update(1, occurrences[2].ID, service.UpdateActivityInput{
+		Title:     "Special Edition",
+		EventTime: occurrences[2].EventTime.Add(-24 * time.Hour).Format(time.RFC3339),
+	})
+	if err != nil {
+		t.Fatalf("single update failed: %v", err)
+	}
+
+	if err := svc.ExtendSeries(); err != nil {
+		t.Fatalf("ExtendSeries failed: %v", err)
+	}
+	occurrences, _ = svc.GetSeriesOccurrences(first.ID, 0)
+	if len(occurrences) != 4 {
+		t.Fatalf("occurrences = %d, want 4 (no duplicate of the moved slot)", len(occurrences))
+	}
+	last := occurrences[3]
+	if want := start.AddDate(0, 0, 21); !last.EventTime.Equal(want) {
+		t.Errorf("new occurrence at %v, want %v", last.EventTime, want)
+	}
+	if last.Title != "Weekly Studio Session" {
+		t.Errorf("new occurrence title = %q, single edits should not carry forward", last.Title)
+	}
+
+	// Running again creates nothing
+	_ = svc.ExtendSeries()
+	if all, _ := svc.GetSeriesOccurrences(first.ID, 0); len(all) != 4 {
+		t.Errorf("occurrences after second run = %d, want 4", len(all))
+	}
+
+	// Series-wide edits do carry forward
+	if _, err := svc.Update(1, first.ID, service.UpdateActivityInput{Title: "Evening Session", Scope: "series"}); err != nil {
+		t.Fatalf("series update failed: %v", err)
+	}
+	if title := repo.series[*first.SeriesID].Title; title != "Evening Session" {
+		t.Errorf("series template title = %q, want Evening Session", title)
+	}
+}
+
+func TestSeries_CancelNotifiesParticipants(t *testing.T) {
+	repo := newMockActivityRepo()
+	notif := newMockNotificationService()
+	svc := service.NewActivityService(repo, newMockCommentRepo(), newMockRatingRepo(), newMockUserRepo(), newMockChatRepo(repo), newMockFollowRepo(), newMockWorkRepo(), "http://localhost:8080", "", notif, "http://localhost:3000", testLinkSecret)
+
+	first, _ := svc.Create(1, service.CreateActivityInput{
+		Title:      "Weekly Studio Session",
+		EventTime:  time.Now().Add(72 * time.Hour).UTC().Format(time.RFC3339),
+		Recurrence: "FREQ=WEEKLY;COUNT=3",
+	})
+	occurrences, _ := svc.GetSeriesOccurrences(first.ID, 0)
+	_ = svc.Apply(occurrences[2].ID, 2, service.ApplyInput{})
+	_ = svc.UpdateApplicantStatus(occurrences[2].ID, 1, 2, "accepted")
+
+	if err := svc.CancelSeries(2, first.ID, "studio closed"); err == nil {
+		t.Fatal("non-host should not cancel the series")
+	}
+	if err := svc.CancelSeries(1, first.ID, "studio closed"); err != nil {
+		t.Fatalf("CancelSeries failed: %v", err)
+	}
+
+	occurrences, _ = svc.GetSeriesOccurrences(first.ID, 0)
+	for i, occ := range occurrences {
+		if occ.Status != "cancelled" {
+			t.Errorf("occurrence %d status = %q, want cancelled", i, occ.Status)
+		}
+	}
+	if !notif.sentTo(2, "activity_cancelled") {
+		t.Error("participant should be notified of the cancellation")
+	}
+	if err := svc.CancelSeries(1, first.ID, ""); err == nil {
+		t.Error("cancelling twice should fail")
+	}
+}
+
+// --- Check-in ---
+
+func TestCheckIn_TokenAndManualFallback(t *testing.T) {
+	repo := newMockActivityRepo()
+	svc := service.NewActivityService(repo, newMockCommentRepo(), newMockRatingRepo(), newMockUserRepo(), newMockChatRepo(repo), newMockFollowRepo(), newMockWorkRepo(), "http://localhost:8080", "", newMockNotificationService(), "http://localhost:3000", testLinkSecret)
+
+	activity, err := svc.Create(1, service.CreateActivityInput{
+		Title:     "Studio Session",
+		EventTime: time.Now().Add(time.Hour).UTC().Format(time.RFC3339),
+	})
+	if err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+	for _, uid := range []uint{2, 3} {
+		if err := svc.Apply(activity.ID, uid, service.ApplyInput{}); err != nil {
+			t.Fatalf("Apply failed: %v", err)
+		}
+		if err := svc.UpdateApplicantStatus(activity.ID, 1, uid, "accepted"); err != nil {
+			t.Fatalf("UpdateApplicantStatus failed: %v", err)
+		}
+	}
+
+	// Only accepted participants get a ticket
+	if _, err := svc.GetCheckInTicket(activity.ID, 4); err == nil {
+		t.Fatal("non-participant should not get a check-in ticket")
+	}
+	ticket, err := svc.GetCheckInTicket(activity.ID, 2)
+	if err != nil {
+		t.Fatalf("GetCheckInTicket failed: %v", err)
+	}
+
+	// Only the host can scan, and tampered tokens are rejected
+	if _, err := svc.CheckIn(activity.ID, 2, service.CheckInInput{Token: ticket.Token}); err == nil {
+		t.Fatal("non-host should not be able to check in participants")
+	}
+	if _, err := svc.CheckIn(activity.ID, 1, service.CheckInInput{Token: ticket.Token + "x"}); err == nil {
+		t.Fatal("tampered token should be rejected")
+	}
+
+	p, err := svc.CheckIn(activity.ID, 1, service.CheckInInput{Token: ticket.Token})
+	if err != nil {
+		t.Fatalf("CheckIn failed: %v", err)
+	}
+	if p.UserID != 2 || p.CheckedInAt == nil {
+		t.Errorf("checked in participant = %+v", p)
+	}
+	if _, err := svc.CheckIn(activity.ID, 1, service.CheckInInput{Token: ticket.Token}); err == nil {
+		t.Fatal("second check-in should fail")
+	}
+
+	// A ticket for another activity does not work here
+	other, _ := svc.Create(1, service.CreateActivityInput{Title: "Other"})
+	if _, err := svc.CheckIn(other.ID, 1, service.CheckInInput{Token: ticket.Token}); err == nil {
+		t.Fatal("ticket should be bound to its activity")
+	}
+
+	// Manual fallback by user ID
+	if _, err := svc.CheckIn(activity.ID, 1, service.CheckInInput{UserID: 3}); err != nil {
+		t.Fatalf("manual CheckIn failed: %v", err)
+	}
+}
+
+func TestCheckIn_TokenExpires(t *testing.T) {
+	repo := newMockActivityRepo()
+	svc := service.NewActivityService(repo, newMockCommentRepo(), newMockRatingRepo(), newMockUserRepo(), newMockChatRepo(repo), newMockFollowRepo(), newMockWorkRepo(), "http://localhost:8080", "", newMockNotificationService(), "http://localhost:3000", testLinkSecret)
+
+	start := time.Now().Add(time.Hour).UTC().Truncate(time.Second)
+	activity, _ := svc.Create(1, service.CreateActivityInput{Title: "Studio Session", EventTime: start.Format(time.RFC3339)})
+	_ = svc.Apply(activity.ID, 2, service.ApplyInput{})
+	_ = svc.UpdateApplicantStatus(activity.ID, 1, 2, "accepted")
+
+	ticket, err := svc.GetCheckInTicket(activity.ID, 2)
+	if err != nil {
+		t.Fatalf("GetCheckInTicket failed: %v", err)
+	}
+	if !ticket.ExpiresAt.After(start) {
+		t.Errorf("ticket expires at %v, want after the event starts", ticket.ExpiresAt)
+	}
+
+	// A correctly signed but expired token is rejected
+	expired := auth.SignValue(fmt.Sprintf("checkin.%d.2.%d", activity.ID, time.Now().Add(-time.Hour).Unix()), testLinkSecret)
+	if _, err := svc.CheckIn(activity.ID, 1, service.CheckInInput{Token: expired}); err == nil {
+		t.Error("expired token should be rejected")
+	}
+
+	// Moving the event to another day invalidates tickets issued before
+	moved := start.AddDate(0, 0, -1)
+	if _, err := svc.Update(1, activity.ID, service.UpdateActivityInput{EventTime: moved.Format(time.RFC3339)}); err != nil {
+		t.Fatalf("Update failed: %v", err)
+	}
+	_, err = svc.CheckIn(activity.ID, 1, service.CheckInInput{Token: ticket.Token})
+	if err == nil || !strings.Contains(err.Error(), "rescheduled") {
+		t.Errorf("err = %v, want ticket from before the reschedule rejected", err)
+	}
+}
+
+func TestCheckIn_NotOpenYet(t *testing.T) {
+	repo := newMockActivityRepo()
+	svc := service.NewActivityService(repo, newMockCommentRepo(), newMockRatingRepo(), newMockUserRepo(), newMockChatRepo(repo), newMockFollowRepo(), newMockWorkRepo(), "http://localhost:8080", "", newMockNotificationService(), "http://localhost:3000", testLinkSecret)
+
+	activity, _ := svc.Create(1, service.CreateActivityInput{
+		Title:     "Next Week",
+		EventTime: time.Now().Add(7 * 24 * time.Hour).UTC().Format(time.RFC3339),
+	})
+	_ = svc.Apply(activity.ID, 2, service.ApplyInput{})
+	_ = svc.UpdateApplicantStatus(activity.ID, 1, 2, "accepted")
+
+	if _, err := svc.CheckIn(activity.ID, 1, service.CheckInInput{UserID: 2}); err == nil {
+		t.Fatal("check-in should not open a week early")
+	}
+}
+
+func TestCloseAttendance_MarksNoShows(t *testing.T) {
+	repo := newMockActivityRepo()
+	svc := service.NewActivityService(repo, newMockCommentRepo(), newMockRatingRepo(), newMockUserRepo(), newMockChatRepo(repo), newMockFollowRepo(), newMockWorkRepo(), "http://localhost:8080", "", newMockNotificationService(), "http://localhost:3000", testLinkSecret)
+
+	activity, _ := svc.Create(1, service.CreateActivityInput{
+		Title:     "Sunset Shoot",
+		EventTime: time.Now().Add(time.Hour).UTC().Format(time.RFC3339),
+	})
+	for _, uid := range []uint{2, 3} {
+		_ = svc.Apply(activity.ID, uid, service.ApplyInput{})
+		_ = svc.UpdateApplicantStatus(activity.ID, 1, uid, "accepted")
+	}
+	if _, err := svc.CheckIn(activity.ID, 1, service.CheckInInput{UserID: 2}); err != nil {
+		t.Fatalf("CheckIn failed: %v", err)
+	}
+
+	// Move the event past the grace period
+	repo.activities[activity.ID].EventTime = time.Now().Add(-13 * time.Hour)
+	if err := svc.CloseAttendance(); err != nil {
+		t.Fatalf("CloseAttendance failed: %v", err)
+	}
+
+	if p, _ := repo.GetParticipant(activity.ID, 2); p.Status != "accepted" {
+		t.Errorf("checked-in participant status = %q, want accepted", p.Status)
+	}
+	if p, _ := repo.GetParticipant(activity.ID, 3); p.Status != "no_show" {
+		t.Errorf("absent participant status = %q, want no_show", p.Status)
+	}
+	if repo.activities[activity.ID].AttendanceClosedAt == nil {
+		t.Error("attendance should be closed")
+	}
+
+	// QR check-in is closed, but the host can still correct a no-show manually
+	ticket, err := svc.GetCheckInTicket(activity.ID, 3)
+	if err != nil {
+		t.Fatalf("GetCheckInTicket failed: %v", err)
+	}
+	if _, err := svc.CheckIn(activity.ID, 1, service.CheckInInput{Token: ticket.Token}); err == nil {
+		t.Fatal("QR check-in should be closed")
+	}
+	p, err := svc.CheckIn(activity.ID, 1, service.CheckInInput{UserID: 3})
+	if err != nil {
+		t.Fatalf("manual CheckIn failed: %v", err)
+	}
+	if p.Status != "accepted" || p.CheckedInAt == nil {
+		t.Errorf("corrected participant = %+v", p)
+	}
+}
+
+// --- Cancellation policy ---
+
+func TestCancelApplication_FreeWithdrawal(t *testing.T) {
+	repo := newMockActivityRepo()
+	notif := newMockNotificationService()
+	svc := service.NewActivityService(repo, newMockCommentRepo(), newMockRatingRepo(), newMockUserRepo(), newMockChatRepo(repo), newMockFollowRepo(), newMockWorkRepo(), "http://localhost:8080", "", notif, "http://localhost:3000", testLinkSecret)
+
+	activity, _ := svc.Create(1, service.CreateActivityInput{
+		Title:           "Weekend Shoot",
+		MaxParticipants: 1,
+		EventTime:       time.Now().Add(72 * time.Hour).UTC().Format(time.RFC3339),
+	})
+	_ = svc.Apply(activity.ID, 2, service.ApplyInput{})
+	_ = svc.UpdateApplicantStatus(activity.ID, 1, 2, "accepted")
+	if got := repo.activities[activity.ID].Status; got != "full" {
+		t.Fatalf("status = %q, want full", got)
+	}
+
+	if err := svc.CancelApplication(activity.ID, 2); err != nil {
+		t.Fatalf("CancelApplication failed: %v", err)
+	}
+
+	p, err := repo.GetParticipant(activity.ID, 2)
+	if err != nil {
+		t.Fatal("participant record should be kept")
+	}
+	if p.Status != "withdrawn" || p.CancelledAt == nil {
+		t.Errorf("participant = %+v, want withdrawn with CancelledAt", p)
+	}
+	if got := repo.activities[activity.ID].Status; got != "open" {
+		t.Errorf("status = %q, want open after the seat is freed", got)
+	}
+	if !notif.sentTo(1, "application_withdrawn") {
+		t.Error("host should be notified of the withdrawal")
+	}
+
+	// A free withdrawal can re-apply
+	if err := svc.Apply(activity.ID, 2, service.ApplyInput{}); err != nil {
+		t.Fatalf("re-apply failed: %v", err)
+	}
+	// Cancelling twice is rejected once nothing is active
+	_ = svc.CancelApplication(activity.ID, 2)
+	if err := svc.CancelApplication(activity.ID, 2); err == nil {
+		t.Fatal("cancelling without an active application should fail")
+	}
+}
+
+func TestCancelApplication_LateCancel(t *testing.T) {
+	repo := newMockActivityRepo()
+	notif := newMockNotificationService()
+	svc := service.NewActivityService(repo, newMockCommentRepo(), newMockRatingRepo(), newMockUserRepo(), newMockChatRepo(repo), newMockFollowRepo(), newMockWorkRepo(), "http://localhost:8080", "", notif, "http://localhost:3000", testLinkSecret)
+
+	hours := 48
+	activity, err := svc.Create(1, service.CreateActivityInput{
+		Title:           "Tomorrow",
+		EventTime:       time.Now().Add(30 * time.Hour).UTC().Format(time.RFC3339),
+		FreeCancelHours: &hours,
+	})
+	if err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+	for _, uid := range []uint{2, 3} {
+		_ = svc.Apply(activity.ID, uid, service.ApplyInput{})
+	}
+	_ = svc.UpdateApplicantStatus(activity.ID, 1, 2, "accepted")
+
+	// Accepted participant inside the 48h window: late
+	if err := svc.CancelApplication(activity.ID, 2); err != nil {
+		t.Fatalf("CancelApplication failed: %v", err)
+	}
+	if p, _ := repo.GetParticipant(activity.ID, 2); p.Status != "late_cancelled" {
+		t.Errorf("status = %q, want late_cancelled", p.Status)
+	}
+	if !notif.sentTo(1, "late_cancellation") {
+		t.Error("host should be notified of the late cancellation")
+	}
+	if err := svc.Apply(activity.ID, 2, service.ApplyInput{}); err == nil {
+		t.Fatal("late cancellations should not be able to re-apply")
+	}
+
+	// Pending applicants are never late
+	if err := svc.CancelApplication(activity.ID, 3); err != nil {
+		t.Fatalf("CancelApplication failed: %v", err)
+	}
+	if p, _ := repo.GetParticipant(activity.ID, 3); p.Status != "withdrawn" {
+		t.Errorf("status = %q, want withdrawn", p.Status)
+	}
+
+	// The host sees the late-cancel count on another activity
+	other, _ := svc.Create(1, service.CreateActivityInput{Title: "Next Month"})
+	_ = svc.Apply(other.ID, 2, service.ApplyInput{})
+	applicants, err := svc.ListApplicants(other.ID, 1, repository.ApplicantFilter{})
+	if err != nil {
+		t.Fatalf("ListApplicants failed: %v", err)
+	}
+	if len(applicants) != 1 || applicants[0].User.LateCancelCount != 1 {
+		t.Errorf("applicants = %+v, want one with LateCancelCount 1", applicants)
+	}
+}
+
+func TestCreateActivity_InvalidFreeCancelHours(t *testing.T) {
+	repo := newMockActivityRepo()
+	svc := service.NewActivityService(repo, newMockCommentRepo(), newMockRatingRepo(), newMockUserRepo(), newMockChatRepo(repo), newMockFollowRepo(), newMockWorkRepo(), "http://localhost:8080", "", newMockNotificationService(), "http://localhost:3000", testLinkSecret)
+
+	hours := 0
+	if _, err := svc.Create(1, service.CreateActivityInput{Title: "Bad", FreeCancelHours: &hours}); err == nil {
+		t.Fatal("expected error for zero free-cancel window")
+	}
+}
+
+// --- Co-hosts ---
+
+func TestCoHost_PermissionLevels(t *testing.T) {
+	repo := newMockActivityRepo()
+	notif := newMockNotificationService()
+	svc := service.NewActivityService(repo, newMockCommentRepo(), newMockRatingRepo(), newMockUserRepo(), newMockChatRepo(repo), newMockFollowRepo(), newMockWorkRepo(), "http://localhost:8080", "", notif, "http://localhost:3000", testLinkSecret)
+
+	activity, _ := svc.Create(1, service.CreateActivityInput{
+		Title:     "Team Shoot",
+		EventTime: time.Now().Add(72 * time.Hour).UTC().Format(time.RFC3339),
+	})
+	// User 2 manages applicants, user 3 edits details, user 4 has full rights
+	for uid, perm := range map[uint]string{2: model.CoHostManageApplicants, 3: model.CoHostEditDetails, 4: model.CoHostFull} {
+		if _, err := svc.AddCoHost(activity.ID, 1, service.CoHostInput{UserID: uid, Permission: perm}); err != nil {
+			t.Fatalf("AddCoHost(%d) failed: %v", uid, err)
+		}
+		if !notif.sentTo(uid, "cohost_added") {
+			t.Errorf("user %d should be notified of being added", uid)
+		}
+	}
+
+	// Only the host manages co-hosts
+	if _, err := svc.AddCoHost(activity.ID, 4, service.CoHostInput{UserID: 9, Permission: model.CoHostFull}); err == nil {
+		t.Fatal("co-hosts should not be able to add co-hosts")
+	}
+	if _, err := svc.AddCoHost(activity.ID, 1, service.CoHostInput{UserID: 9, Permission: "admin"}); err == nil {
+		t.Fatal("unknown permission should be rejected")
+	}
+
+	// Applications notify the host and every co-host
+	if err := svc.Apply(activity.ID, 10, service.ApplyInput{}); err != nil {
+		t.Fatalf("Apply failed: %v", err)
+	}
+	for _, uid := range []uint{1, 2, 3, 4} {
+		if !notif.sentTo(uid, "join_request") {
+			t.Errorf("user %d should receive the join request", uid)
+		}
+	}
+	if err := svc.Apply(activity.ID, 2, service.ApplyInput{}); err == nil {
+		t.Fatal("co-hosts should not be able to apply")
+	}
+
+	// manage_applicants: review applicants but not edit
+	if err := svc.UpdateApplicantStatus(activity.ID, 2, 10, "accepted"); err != nil {
+		t.Errorf("manage_applicants co-host should accept applicants: %v", err)
+	}
+	if _, err := svc.Update(2, activity.ID, service.UpdateActivityInput{Title: "Nope"}); err == nil {
+		t.Error("manage_applicants co-host should not edit details")
+	}
+
+	// edit_details: edit but not review applicants or change status
+	if _, err := svc.Update(3, activity.ID, service.UpdateActivityInput{Title: "Renamed"}); err != nil {
+		t.Errorf("edit_details co-host should edit details: %v", err)
+	}
+	if _, err := svc.ListApplicants(activity.ID, 3, repository.ApplicantFilter{}); err == nil {
+		t.Error("edit_details co-host should not see applicants")
+	}
+	if _, err := svc.Update(3, activity.ID, service.UpdateActivityInput{Status: "ended"}); err == nil {
+		t.Error("edit_details co-host should not change the status")
+	}
+	if err := svc.Cancel(3, activity.ID, ""); err == nil {
+		t.Error("edit_details co-host should not cancel")
+	}
+
+	// full: everything but co-host management
+	if _, err := svc.ListApplicants(activity.ID, 4, repository.ApplicantFilter{}); err != nil {
+		t.Errorf("full co-host should see applicants: %v", err)
+	}
+	if status, _ := svc.GetUserStatus(activity.ID, 4); status != "cohost" {
+		t.Errorf("status = %q, want cohost", status)
+	}
+	if err := svc.Cancel(4, activity.ID, "weather"); err != nil {
+		t.Errorf("full co-host should cancel: %v", err)
+	}
+}
+
+func TestCoHost_Remove(t *testing.T) {
+	repo := newMockActivityRepo()
+	svc := service.NewActivityService(repo, newMockCommentRepo(), newMockRatingRepo(), newMockUserRepo(), newMockChatRepo(repo), newMockFollowRepo(), newMockWorkRepo(), "http://localhost:8080", "", newMockNotificationService(), "http://localhost:3000", testLinkSecret)
+
+	activity, _ := svc.Create(1, service.CreateActivityInput{Title: "Team Shoot"})
+	_, _ = svc.AddCoHost(activity.ID, 1, service.CoHostInput{UserID: 2, Permission: model.CoHostFull})
+	_, _ = svc.AddCoHost(activity.ID, 1, service.CoHostInput{UserID: 3, Permission: model.CoHostFull})
+
+	if err := svc.RemoveCoHost(activity.ID, 2, 3); err == nil {
+		t.Fatal("a co-host should not remove another co-host")
+	}
+	// A co-host can step down
+	if err := svc.RemoveCoHost(activity.ID, 2, 2); err != nil {
+		t.Fatalf("RemoveCoHost failed: %v", err)
+	}
+	if err := svc.RemoveCoHost(activity.ID, 1, 3); err != nil {
+		t.Fatalf("RemoveCoHost failed: %v", err)
+	}
+	if _, err := svc.ListApplicants(activity.ID, 3, repository.ApplicantFilter{}); err == nil {
+		t.Error("removed co-host should lose access")
+	}
+}
+
+// --- Drafts ---
+
+func TestDraft_VisibleOnlyToHosts(t *testing.T) {
+	repo := newMockActivityRepo()
+	svc := service.NewActivityService(repo, newMockCommentRepo(), newMockRatingRepo(), newMockUserRepo(), newMockChatRepo(repo), newMockFollowRepo(), newMockWorkRepo(), "http://localhost:8080", "", newMockNotificationService(), "http://localhost:3000", testLinkSecret)
+
+	activity, err := svc.Create(1, service.CreateActivityInput{Title: "Mood Board", Draft: true})
+	if err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+	if activity.Status != "draft" || activity.PublishedAt != nil {
+		t.Fatalf("activity = %+v, want unpublished draft", activity)
+	}
+	_, _ = svc.AddCoHost(activity.ID, 1, service.CoHostInput{UserID: 2, Permission: model.CoHostEditDetails})
+
+	if _, err := svc.GetByID(activity.ID, 1, ""); err != nil {
+		t.Errorf("host should see the draft: %v", err)
+	}
+	if _, err := svc.GetByID(activity.ID, 2, ""); err != nil {
+		t.Errorf("co-host should see the draft: %v", err)
+	}
+	if _, err := svc.GetByID(activity.ID, 3, ""); err == nil {
+		t.Error("other users should not see the draft")
+	}
+	if list, _ := svc.GetByUserID(1, 3); len(list) != 0 {
+		t.Errorf("profile should hide drafts from others, got %d", len(list))
+	}
+	if err := svc.Apply(activity.ID, 3, service.ApplyInput{}); err == nil {
+		t.Error("drafts should not accept applications")
+	}
+	if _, err := svc.Update(1, activity.ID, service.UpdateActivityInput{Status: "open"}); err == nil {
+		t.Error("drafts should only open through Publish")
+	}
+}
+
+func TestPublish_Validation(t *testing.T) {
+	repo := newMockActivityRepo()
+	svc := service.NewActivityService(repo, newMockCommentRepo(), newMockRatingRepo(), newMockUserRepo(), newMockChatRepo(repo), newMockFollowRepo(), newMockWorkRepo(), "http://localhost:8080", "", newMockNotificationService(), "http://localhost:3000", testLinkSecret)
+
+	activity, _ := svc.Create(1, service.CreateActivityInput{Title: "Incomplete", Draft: true})
+	if _, err := svc.Publish(activity.ID, 1, service.PublishInput{}); err == nil {
+		t.Fatal("incomplete draft should not publish")
+	}
+
+	// Fill in the missing details (stored image URLs are kept as-is)
+	maxP := 5
+	_, err := svc.Update(1, activity.ID, service.UpdateActivityInput{
+		EventTime:       time.Now().Add(48 * time.Hour).UTC().Format(time.RFC3339),
+		MaxParticipants: &maxP,
+		Images:          []string{"http://localhost:8080/uploads/activities/1/cover.jpg"},
+	})
+	if err != nil {
+		t.Fatalf("Update failed: %v", err)
+	}
+
+	// Only the host or full co-hosts can publish
+	if _, err := svc.Publish(activity.ID, 2, service.PublishInput{}); err == nil {
+		t.Fatal("other users should not publish")
+	}
+	published, err := svc.Publish(activity.ID, 1, service.PublishInput{})
+	if err != nil {
+		t.Fatalf("Publish failed: %v", err)
+	}
+	if published.Status != "open" || published.PublishedAt == nil {
+		t.Errorf("published = %+v, want open with PublishedAt", published)
+	}
+	if _, err := svc.Publish(activity.ID, 1, service.PublishInput{}); err == nil {
+		t.Error("publishing twice should fail")
+	}
+}
+
+func TestPublish_Scheduled(t *testing.T) {
+	repo := newMockActivityRepo()
+	svc := service.NewActivityService(repo, newMockCommentRepo(), newMockRatingRepo(), newMockUserRepo(), newMockChatRepo(repo), newMockFollowRepo(), newMockWorkRepo(), "http://localhost:8080", "", newMockNotificationService(), "http://localhost:3000", testLinkSecret)
+
+	eventTime := time.Now().Add(72 * time.Hour).UTC()
+	activity, _ := svc.Create(1, service.CreateActivityInput{
+		Title:           "Launch Later",
+		EventTime:       eventTime.Format(time.RFC3339),
+		MaxParticipants: 3,
+		Draft:           true,
+	})
+	repo.activities[activity.ID].Images = []string{"http://localhost:8080/uploads/activities/1/cover.jpg"}
+
+	// Publish time must be before the event
+	if _, err := svc.Publish(activity.ID, 1, service.PublishInput{PublishAt: eventTime.Add(time.Hour).Format(time.RFC3339)}); err == nil {
+		t.Fatal("publish time after the event should be rejected")
+	}
+	publishAt := time.Now().Add(time.Hour).UTC()
+	scheduled, err := svc.Publish(activity.ID, 1, service.PublishInput{PublishAt: publishAt.Format(time.RFC3339)})
+	if err != nil {
+		t.Fatalf("Publish failed: %v", err)
+	}
+	if scheduled.Status != "draft" || scheduled.PublishAt == nil {
+		t.Fatalf("scheduled = %+v, want draft with PublishAt", scheduled)
+	}
+
+	// Not due yet
+	if err := svc.PublishScheduled(); err != nil {
+		t.Fatalf("PublishScheduled failed: %v", err)
+	}
+	if repo.activities[activity.ID].Status != "draft" {
+		t.Fatal("draft should not publish before its time")
+	}
+
+	past := time.Now().Add(-time.Minute)
+	repo.activities[activity.ID].PublishAt = &past
+	if err := svc.PublishScheduled(); err != nil {
+		t.Fatalf("PublishScheduled failed: %v", err)
+	}
+	if got := repo.activities[activity.ID]; got.Status != "open" || got.PublishAt != nil {
+		t.Errorf("activity = %+v, want open and unscheduled", got)
+	}
+}
+
+func TestDuplicate_CopiesIntoDraft(t *testing.T) {
+	repo := newMockActivityRepo()
+	svc := service.NewActivityService(repo, newMockCommentRepo(), newMockRatingRepo(), newMockUserRepo(), newMockChatRepo(repo), newMockFollowRepo(), newMockWorkRepo(), "http://localhost:8080", "", newMockNotificationService(), "http://localhost:3000", testLinkSecret)
+
+	src, err := svc.Create(1, service.CreateActivityInput{
+		Title:     "Rooftop Portraits",
+		Location:  "Taipei",
+		EventTime: time.Now().Add(48 * time.Hour).UTC().Format(time.RFC3339),
+		Timezone:  "Asia/Tokyo",
+		Tags:      `["portrait"]`,
+		RoleSlots: []service.RoleSlotInput{{Role: "model", Count: 2}},
+		Questions: []service.QuestionInput{{Type: "short_text", Label: "Instagram", Required: true}},
+	})
+	if err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+	src.Images = []string{"http://localhost:8080/uploads/activities/1/cover.jpg"}
+	_ = repo.CreateParticipant(&model.ActivityParticipant{ActivityID: src.ID, UserID: 5, Status: "accepted"})
+
+	if _, err := svc.Duplicate(src.ID, 2, service.DuplicateInput{}); err == nil {
+		t.Fatal("non-hosts should not duplicate")
+	}
+
+	dup, err := svc.Duplicate(src.ID, 1, service.DuplicateInput{EventTime: "2030-03-01T10:00:00"})
+	if err != nil {
+		t.Fatalf("Duplicate failed: %v", err)
+	}
+	if dup.ID == src.ID || dup.Status != "draft" || dup.PublishedAt != nil {
+		t.Errorf("duplicate = %+v, want a new unpublished draft", dup)
+	}
+	if dup.Title != src.Title || dup.Location != src.Location || dup.Tags != src.Tags || dup.Timezone != "Asia/Tokyo" {
+		t.Errorf("details not copied: %+v", dup)
+	}
+	if len(dup.Images) != 1 || len(dup.RoleSlots) != 1 || len(dup.Questions) != 1 {
+		t.Errorf("images/slots/questions not copied: %+v", dup)
+	}
+	if want := time.Date(2030, 3, 1, 1, 0, 0, 0, time.UTC); !dup.EventTime.Equal(want) {
+		t.Errorf("EventTime = %v, want %v (read in the source timezone)", dup.EventTime, want)
+	}
+	if n, _ := repo.CountAccepted(dup.ID); n != 0 {
+		t.Errorf("participants should not be copied, got %d", n)
+	}
+}
+
+func TestTemplates_SaveAndCreateFrom(t *testing.T) {
+	repo := newMockActivityRepo()
+	svc := service.NewActivityService(repo, newMockCommentRepo(), newMockRatingRepo(), newMockUserRepo(), newMockChatRepo(repo), newMockFollowRepo(), newMockWorkRepo(), "http://localhost:8080", "", newMockNotificationService(), "http://localhost:3000", testLinkSecret)
+
+	hours := 48
+	src, _ := svc.Create(1, service.CreateActivityInput{
+		Title:           "Studio Session",
+		Description:     "Bring two outfits",
+		MaxParticipants: 4,
+		Roles:           []string{"model"},
+		FreeCancelHours: &hours,
+		Questions:       []service.QuestionInput{{Type: "yes_no", Label: "Own transport?"}},
+	})
+	src.Images = []string{"http://localhost:8080/uploads/activities/1/cover.jpg"}
+
+	if _, err := svc.SaveTemplate(src.ID, 2, service.SaveTemplateInput{Name: "Mine now"}); err == nil {
+		t.Fatal("non-hosts should not save templates of another's activity")
+	}
+	template, err := svc.SaveTemplate(src.ID, 1, service.SaveTemplateInput{Name: "Studio"})
+	if err != nil {
+		t.Fatalf("SaveTemplate failed: %v", err)
+	}
+
+	// Other users cannot create from someone else's template
+	if _, err := svc.Create(2, service.CreateActivityInput{TemplateID: template.ID}); err == nil {
+		t.Fatal("expected error using another user's template")
+	}
+
+	// Unset fields come from the template; explicit ones win
+	activity, err := svc.Create(1, service.CreateActivityInput{TemplateID: template.ID, Location: "Taichung"})
+	if err != nil {
+		t.Fatalf("Create from template failed: %v", err)
+	}
+	if activity.Title != "Studio Session" || activity.Description != "Bring two outfits" || activity.Location != "Taichung" {
+		t.Errorf("details = %+v", activity)
+	}
+	if activity.MaxParticipants != 4 || activity.FreeCancelHours != 48 || len(activity.Questions) != 1 {
+		t.Errorf("settings not applied: %+v", activity)
+	}
+	if len(activity.Images) != 1 || activity.Images[0] != src.Images[0] {
+		t.Errorf("Images = %v, want the template's images", activity.Images)
+	}
+
+	if _, err := svc.Create(1, service.CreateActivityInput{}); err == nil {
+		t.Error("expected error creating without a title or template")
+	}
+
+	templates, _ := svc.ListTemplates(1)
+	if len(templates) != 1 {
+		t.Fatalf("len(templates) = %d, want 1", len(templates))
+	}
+	if err := svc.DeleteTemplate(2, template.ID); err == nil {
+		t.Error("other users should not delete the template")
+	}
+	if err := svc.DeleteTemplate(1, template.ID); err != nil {
+		t.Errorf("DeleteTemplate failed: %v", err)
+	}
+}
+
+func TestAnnounce_DeliveryAndVisibility(t *testing.T) {
+	repo := newMockActivityRepo()
+	notif := newMockNotificationService()
+	svc := service.NewActivityService(repo, newMockCommentRepo(), newMockRatingRepo(), newMockUserRepo(), newMockChatRepo(repo), newMockFollowRepo(), newMockWorkRepo(), "http://localhost:8080", "", notif, "http://localhost:3000", testLinkSecret)
+
+	activity, _ := svc.Create(1, service.CreateActivityInput{
+		Title:           "Harbour Shoot",
+		EventTime:       time.Now().Add(48 * time.Hour).UTC().Format(time.RFC3339),
+		MaxParticipants: 5,
+	})
+	_ = repo.CreateParticipant(&model.ActivityParticipant{ActivityID: activity.ID, UserID: 2, Status: "accepted"})
+	_ = repo.CreateParticipant(&model.ActivityParticipant{ActivityID: activity.ID, UserID: 3, Status: "pending"})
+	_ = repo.CreateParticipant(&model.ActivityParticipant{ActivityID: activity.ID, UserID: 4, Status: "rejected"})
+
+	if _, err := svc.Announce(activity.ID, 2, service.AnnounceInput{Message: "hi"}); err == nil {
+		t.Fatal("participants should not announce")
+	}
+	if _, err := svc.Announce(activity.ID, 1, service.AnnounceInput{Message: "   "}); err == nil {
+		t.Fatal("expected error for an empty message")
+	}
+
+	a, err := svc.Announce(activity.ID, 1, service.AnnounceInput{Message: "Venue moved to Pier 2"})
+	if err != nil {
+		t.Fatalf("Announce failed: %v", err)
+	}
+	if a.RecipientCount != 1 {
+		t.Errorf("RecipientCount = %d, want 1", a.RecipientCount)
+	}
+	if _, err := svc.Announce(activity.ID, 1, service.AnnounceInput{Message: "Call time 9:00", IncludePending: true}); err != nil {
+		t.Fatalf("Announce failed: %v", err)
+	}
+
+	if got := len(notif.sentOf(2, "activity_announcement")); got != 2 {
+		t.Errorf("accepted participant got %d announcements, want 2", got)
+	}
+	if got := len(notif.sentOf(3, "activity_announcement")); got != 1 {
+		t.Errorf("pending applicant got %d announcements, want 1", got)
+	}
+	if got := len(notif.sentOf(4, "activity_announcement")); got != 0 {
+		t.Errorf("rejected applicant got %d announcements, want 0", got)
+	}
+
+	if list, err := svc.ListAnnouncements(activity.ID, 2); err != nil || len(list) != 2 {
+		t.Errorf("accepted participant sees %d announcements (err %v), want 2", len(list), err)
+	}
+	if list, err := svc.ListAnnouncements(activity.ID, 3); err != nil || len(list) != 1 {
+		t.Errorf("pending applicant sees %d announcements (err %v), want 1", len(list), err)
+	}
+	for _, viewer := range []uint{0, 4, 9} {
+		if _, err := svc.ListAnnouncements(activity.ID, viewer); err == nil {
+			t.Errorf("viewer %d should not see announcements", viewer)
+		}
+	}
+}
+
+func TestBatchUpdateApplicantStatus_CapacityIsAllOrNothing(t *testing.T) {
+	repo := newMockActivityRepo()
+	notif := newMockNotificationService()
+	svc := service.NewActivityService(repo, newMockCommentRepo(), newMockRatingRepo(), newMockUserRepo(), newMockChatRepo(repo), newMockFollowRepo(), newMockWorkRepo(), "http://localhost:8080", "", notif, "http://localhost:3000", testLinkSecret)
+
+	activity, _ := svc.Create(1, service.CreateActivityInput{Title: "Studio Shoot", MaxParticipants: 2})
+	for _, uid := range []uint{2, 3, 4} {
+		_ = svc.Apply(activity.ID, uid, service.ApplyInput{Message: "join"})
+	}
+
+	// Three acceptances exceed two spots: nothing changes
+	_, err := svc.BatchUpdateApplicantStatus(activity.ID, 1, service.BatchUpdateApplicantsInput{UserIDs: []uint{2, 3, 4}, Status: "accepted"})
+	if err == nil {
+		t.Fatal("batch exceeding capacity should fail")
+	}
+	for _, uid := range []uint{2, 3, 4} {
+		if p := repo.participants[participantKey(activity.ID, uid)]; p.Status != "pending" {
+			t.Errorf("user %d status = %s, want pending after failed batch", uid, p.Status)
+		}
+	}
+
+	// Unknown applicants also abort the whole batch
+	if _, err := svc.BatchUpdateApplicantStatus(activity.ID, 1, service.BatchUpdateApplicantsInput{UserIDs: []uint{2, 99}, Status: "accepted"}); err == nil {
+		t.Fatal("batch with unknown applicant should fail")
+	}
+
+	// Non-host cannot review
+	if _, err := svc.BatchUpdateApplicantStatus(activity.ID, 2, service.BatchUpdateApplicantsInput{UserIDs: []uint{3}, Status: "accepted"}); err == nil {
+		t.Fatal("non-host should not be able to batch review")
+	}
+
+	result, err := svc.BatchUpdateApplicantStatus(activity.ID, 1, service.BatchUpdateApplicantsInput{UserIDs: []uint{2, 3, 3}, Status: "accepted", Message: "See you at 10am"})
+	if err != nil {
+		t.Fatalf("batch accept failed: %v", err)
+	}
+	if len(result.Updated) != 2 || len(result.Skipped) != 0 {
+		t.Errorf("result = %+v, want 2 updated", result)
+	}
+	if got := repo.activities[activity.ID].Status; got != "full" {
+		t.Errorf("activity status = %s, want full", got)
+	}
+	sent := notif.sentOf(3, "accepted")
+	if len(sent) != 1 || !strings.Contains(sent[0].Content, "See you at 10am") {
+		t.Errorf("accepted notification = %+v, want custom message", sent)
+	}
+
+	// Re-accepting is a no-op; rejecting the rest still works when full
+	result, err = svc.BatchUpdateApplicantStatus(activity.ID, 1, service.BatchUpdateApplicantsInput{UserIDs: []uint{2}, Status: "accepted"})
+	if err != nil || len(result.Skipped) != 1 {
+		t.Errorf("re-accept = %+v, %v; want skipped", result, err)
+	}
+	if _, err := svc.BatchUpdateApplicantStatus(activity.ID, 1, service.BatchUpdateApplicantsInput{UserIDs: []uint{4}, Status: "rejected"}); err != nil {
+		t.Fatalf("batch reject failed: %v", err)
+	}
+	if len(notif.sentOf(4, "rejected")) != 1 {
+		t.Error("rejected applicant should be notified")
+	}
+}
+
+func TestBatchUpdateApplicantStatus_ConcurrentWithdrawal(t *testing.T) {
+	repo := newMockActivityRepo()
+	svc := service.NewActivityService(repo, newMockCommentRepo(), newMockRatingRepo(), newMockUserRepo(), newMockChatRepo(repo), newMockFollowRepo(), newMockWorkRepo(), "http://localhost:8080", "", newMockNotificationService(), "http://localhost:3000", testLinkSecret)
+
+	activity, _ := svc.Create(1, service.CreateActivityInput{Title: "Studio Shoot", MaxParticipants: 5})
+	for _, uid := range []uint{2, 3} {
+		_ = svc.Apply(activity.ID, uid, service.ApplyInput{Message: "join"})
+	}
+
+	// User 3 withdraws after the batch has been checked but before it is saved
+	repo.beforeStatusUpdate = func() {
+		repo.participants[participantKey(activity.ID, 3)].Status = "withdrawn"
+	}
+	if _, err := svc.BatchUpdateApplicantStatus(activity.ID, 1, service.BatchUpdateApplicantsInput{UserIDs: []uint{2, 3}, Status: "accepted"}); err == nil {
+		t.Fatal("batch should fail when an applicant withdrew in the meantime")
+	}
+	if got := repo.participants[participantKey(activity.ID, 2)].Status; got != "pending" {
+		t.Errorf("user 2 status = %s, want pending after failed batch", got)
+	}
+	if got := repo.participants[participantKey(activity.ID, 3)].Status; got != "withdrawn" {
+		t.Errorf("user 3 status = %s, want withdrawn kept", got)
+	}
+}
+
+func TestBatchUpdateApplicantStatus_RoleSlots(t *testing.T) {
+	repo := newMockActivityRepo()
+	svc := service.NewActivityService(repo, newMockCommentRepo(), newMockRatingRepo(), newMockUserRepo(), newMockChatRepo(repo), newMockFollowRepo(), newMockWorkRepo(), "http://localhost:8080", "", newMockNotificationService(), "http://localhost:3000", testLinkSecret)
+
+	activity, err := svc.Create(1, service.CreateActivityInput{
+		Title:     "Role Shoot",
+		RoleSlots: []service.RoleSlotInput{{Role: "model", Count: 1}, {Role: "makeup", Count: 2}},
+	})
+	if err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+	_ = svc.Apply(activity.ID, 2, service.ApplyInput{Role: "model"})
+	_ = svc.Apply(activity.ID, 3, service.ApplyInput{Role: "model"})
+	_ = svc.Apply(activity.ID, 4, service.ApplyInput{Role: "makeup"})
+
+	if _, err := svc.BatchUpdateApplicantStatus(activity.ID, 1, service.BatchUpdateApplicantsInput{UserIDs: []uint{2, 3}, Status: "accepted"}); err == nil {
+		t.Fatal("two models for one model slot should fail")
+	}
+	if _, err := svc.BatchUpdateApplicantStatus(activity.ID, 1, service.BatchUpdateApplicantsInput{UserIDs: []uint{2, 4}, Status: "accepted"}); err != nil {
+		t.Fatalf("one per role should fit: %v", err)
+	}
+}
+
+func TestListApplicants_SortAndFilter(t *testing.T) {
+	repo := newMockActivityRepo()
+	ratings := newMockRatingRepo()
+	follows := newMockFollowRepo()
+	svc := service.NewActivityService(repo, newMockCommentRepo(), ratings, newMockUserRepo(), newMockChatRepo(repo), follows, newMockWorkRepo(), "http://localhost:8080", "", newMockNotificationService(), "http://localhost:3000", testLinkSecret)
+
+	activity, _ := svc.Create(1, service.CreateActivityInput{Title: "Test Activity", MaxParticipants: 10})
+	base := time.Now().Add(-time.Hour)
+	for i, uid := range []uint{2, 3, 4} {
+		_ = svc.Apply(activity.ID, uid, service.ApplyInput{Message: "join"})
+		repo.participants[participantKey(activity.ID, uid)].AppliedAt = base.Add(time.Duration(i) * time.Minute)
+	}
+	_ = ratings.Create(&model.Rating{TargetID: 2, Score: 3})
+	_ = ratings.Create(&model.Rating{TargetID: 3, Score: 5})
+	_ = follows.Create(&model.Follow{FollowerID: 2, FollowingID: 1})
+
+	userIDs := func(list []model.ActivityParticipant) []uint {
+		ids := make([]uint, len(list))
+		for i := range list {
+			ids[i] = list[i].UserID
+		}
+		return ids
+	}
+
+	cases := []struct {
+		name   string
+		filter repository.ApplicantFilter
+		want   []uint
+	}{
+		{"default newest first", repository.ApplicantFilter{}, []uint{4, 3, 2}},
+		{"oldest first", repository.ApplicantFilter{Sort: "applied_asc"}, []uint{2, 3, 4}},
+		{"rating", repository.ApplicantFilter{Sort: "rating"}, []uint{3, 2, 4}},
+		{"followers first", repository.ApplicantFilter{Sort: "followers"}, []uint{2, 4, 3}},
+		{"min rating", repository.ApplicantFilter{MinRating: 4}, []uint{3}},
+	}
+	for _, tc := range cases {
+		list, err := svc.ListApplicants(activity.ID, 1, tc.filter)
+		if err != nil {
+			t.Fatalf("%s: %v", tc.name, err)
+		}
+		if got := userIDs(list); fmt.Sprint(got) != fmt.Sprint(tc.want) {
+			t.Errorf("%s: got %v, want %v", tc.name, got, tc.want)
+		}
+	}
+
+	notFollowing := false
+	list, _ := svc.ListApplicants(activity.ID, 1, repository.ApplicantFilter{FollowsHost: &notFollowing})
+	if got := userIDs(list); fmt.Sprint(got) != "[4 3]" {
+		t.Errorf("non-followers = %v, want [4 3]", got)
+	}
+
+	if _, err := svc.ListApplicants(activity.ID, 1, repository.ApplicantFilter{Sort: "height"}); err == nil {
+		t.Error("unsupported sort should be rejected")
+	}
+}
+
+func TestActivityWorks_GalleryOnceEnded(t *testing.T) {
+	repo := newMockActivityRepo()
+	works := newMockWorkRepo()
+	svc := service.NewActivityService(repo, newMockCommentRepo(), newMockRatingRepo(), newMockUserRepo(), newMockChatRepo(repo), newMockFollowRepo(), works, "http://localhost:8080", "", newMockNotificationService(), "http://localhost:3000", testLinkSecret)
+
+	activity, _ := svc.Create(1, service.CreateActivityInput{Title: "Forest shoot", EventTime: time.Now().Add(48 * time.Hour).UTC().Format(time.RFC3339)})
+	for i := 0; i < 14; i++ {
+		_ = works.Create(&model.Post{UserID: 2, ActivityID: &activity.ID, Visibility: "public"})
+	}
+	_ = works.Create(&model.Post{UserID: 2, ActivityID: &activity.ID, Visibility: "private"})
+
+	got, err := svc.GetByID(activity.ID, 0, "")
+	if err != nil {
+		t.Fatalf("GetByID failed: %v", err)
+	}
+	if len(got.Gallery) != 0 {
+		t.Errorf("gallery = %d works, want none before the activity ends", len(got.Gallery))
+	}
+
+	repo.activities[activity.ID].Status = "ended"
+	got, _ = svc.GetByID(activity.ID, 0, "")
+	if len(got.Gallery) != 12 || got.GalleryCount != 14 || got.Gallery[0].ID != 14 {
+		t.Errorf("gallery = %d works (count %d), want newest 12 of 14", len(got.Gallery), got.GalleryCount)
+	}
+
+	// Page through every public work, newest first
+	var ids []uint
+	query := service.ActivityWorksQuery{Limit: 5}
+	for pages := 0; pages < 5; pages++ {
+		page, err := svc.ListWorks(activity.ID, 0, query)
+		if err != nil {
+			t.Fatalf("ListWorks failed: %v", err)
+		}
+		for _, w := range page.Items {
+			ids = append(ids, w.ID)
+		}
+		if page.NextBefore == 0 {
+			break
+		}
+		query.BeforeID = page.NextBefore
+	}
+	if len(ids) != 14 || ids[0] != 14 || ids[13] != 1 {
+		t.Errorf("paged works = %v, want 14 down to 1", ids)
+	}
+
+	repo.activities[activity.ID].Visibility = "private"
+	if _, err := svc.ListWorks(activity.ID, 5, service.ActivityWorksQuery{}); err == nil {
+		t.Error("outsiders should not list works of a private activity")
+	}
+}
@@ -0,0 +1,343 @@
+package service
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"azure-magnetar/internal/model"
+	"azure-magnetar/internal/repository"
+	"azure-magnetar/pkg/apperror"
+	"azure-magnetar/pkg/pdf"
+)
+
+// maxAgreementLength caps the length of an agreement's body.
+const maxAgreementLength = 20000
+
+// AgreementService defines the interface for activity collaboration agreements.
+type AgreementService interface {
+	ListTemplates() []AgreementTemplate
+	SetAgreement(activityID, userID uint, input SetAgreementInput) (*model.ActivityAgreement, error)
+	GetAgreement(activityID, viewerID uint) (*AgreementView, error)
+	Sign(activityID, userID uint, input SignAgreementInput, ip string) (*model.AgreementSignature, error)
+	ListSignatureStatus(activityID, hostID uint) ([]SignatureStatus, error)
+	RenderPDF(activityID, viewerID, signerID uint) ([]byte, error)
+}
+
+// AgreementTemplate is a starting text for one agreement type. Body may contain
+// the placeholders {activity}, {date}, {location} and {host}.
+type AgreementTemplate struct {
+	Type  string `json:"type"`
+	Title string `json:"title"`
+	Body  string `json:"body"`
+}
+
+// SetAgreementInput attaches an agreement to an activity. Title and Body
+// default to the type's template filled in with the activity's details.
+type SetAgreementInput struct {
+	Type  string `json:"type" binding:"required"` // tfp, paid, commercial, portfolio_only
+	Title string `json:"title"`
+	Body  string `json:"body"`
+}
+
+// SignAgreementInput confirms the text being signed.
+type SignAgreementInput struct {
+	TextHash string `json:"textHash" binding:"required"` // Must match the current text
+}
+
+// AgreementView is an activity's agreement with the viewer's own signature.
+type AgreementView struct {
+	Agreement   *model.ActivityAgreement  `json:"agreement"`
+	MySignature *model.AgreementSignature `json:"mySignature,omitempty"`
+}
+
+// SignatureStatus tells the host whether an accepted participant has signed.
+type SignatureStatus struct {
+	UserID   uint       `json:"userId"`
+	User     model.User `json:"user"`
+	Signed   bool       `json:"signed"`
+	SignedAt *time.Time `json:"signedAt,omitempty"`
+}
+
+// agreementTemplates holds the built-in templates, in display order.
+var agreementTemplates = []AgreementTemplate{
+	{
+		Type:  model.AgreementTFP,
+		Title: "TFP 互惠拍攝協議",
+		Body: "本協議適用於 {host} 主辦之「{activity}」（{date}，{location}）。\n\n" +
+			"1. 本次拍攝為互惠合作（Time for Prints），雙方均不支付報酬。\n" +
+			"2. 攝影師應於拍攝後提供參與者經修圖之作品。\n" +
+			"3. 雙方皆可將作品用於個人作品集、社群媒體及非商業展示，並應標註對方。\n" +
+			"4. 任何一方如需將作品用於商業用途，須另行取得對方書面同意。",
+	},
+	{
+		Type:  model.AgreementPaid,
+		Title: "有償拍攝協議",
+		Body: "本協議適用於 {host} 主辦之「{activity}」（{date}，{location}）。\n\n" +
+			"1. 主辦方依雙方約定之金額支付參與者報酬。\n" +
+			"2. 作品之著作財產權及使用權歸主辦方所有，參與者同意其肖像用於本次拍攝之成果。\n" +
+			"3. 參與者得於取得主辦方同意後，將作品用於個人作品集。",
+	},
+	{
+		Type:  model.AgreementCommercial,
+		Title: "商業使用授權協議",
+		Body: "本協議適用於 {host} 主辦之「{activity}」（{date}，{location}）。\n\n" +
+			"1. 參與者授權主辦方將本次拍攝之作品用於廣告、行銷、販售等商業用途。\n" +
+			"2. 授權範圍不限地區與媒體，期限依雙方另行約定，未約定者為三年。\n" +
+			"3. 主辦方不得將作品用於有損參與者名譽之用途。",
+	},
+	{
+		Type:  model.AgreementPortfolioOnly,
+		Title: "作品集使用協議",
+		Body: "本協議適用於 {host} 主辦之「{activity}」（{date}，{location}）。\n\n" +
+			"1. 本次拍攝之作品僅限雙方用於個人作品集及社群媒體展示。\n" +
+			"2. 禁止任何商業用途、轉售或授權第三方使用。\n" +
+			"3. 任何一方得要求下架對方未經同意之修改版本。",
+	},
+}
+
+type agreementService struct {
+	repo         repository.AgreementRepository
+	activityRepo repository.ActivityRepository
+	userRepo     repository.UserRepository
+}
+
+// NewAgreementService creates a new AgreementService.
+func NewAgreementService(repo repository.AgreementRepository, activityRepo repository.ActivityRepository, userRepo repository.UserRepository) AgreementService {
+	return &agreementService{repo: repo, activityRepo: activityRepo, userRepo: userRepo}
+}
+
+func (s *agreementService) ListTemplates() []AgreementTemplate {
+	return agreementTemplates
+}
+
+// SetAgreement attaches or replaces an activity's agreement. The text cannot
+// change once someone has signed it.
+func (s *agreementService) SetAgreement(activityID, userID uint, input SetAgreementInput) (*model.ActivityAgreement, error) {
+	activity, err := s.activityRepo.GetByID(activityID)
+	if err != nil {
+		return nil, apperror.New(apperror.CodeNotFound, "activity not found")
+	}
+	if err := authorizeActivity(s.activityRepo, activity, userID, model.CoHostEditDetails); err != nil {
+		return nil, err
+	}
+
+	template := findAgreementTemplate(input.Type)
+	if template == nil {
+		return nil, apperror.Newf(apperror.CodeValidation, "unsupported agreement type %q", input.Type)
+	}
+
+	agreement, err := s.repo.Get(activityID)
+	if err != nil {
+		agreement = &model.ActivityAgreement{ActivityID: activityID}
+	} else if signatures, err := s.repo.ListSignatures(agreement.ID); err != nil {
+		return nil, fmt.Errorf("failed to list signatures: %w", err)
+	} else if len(signatures) > 0 {
+		return nil, apperror.New(apperror.CodeConflict, "the agreement has been signed and can no longer be changed")
+	}
+
+	title := strings.TrimSpace(input.Title)
+	if title == "" {
+		title = template.Title
+	}
+	body := strings.TrimSpace(input.Body)
+	if body == "" {
+		body = fillAgreementTemplate(template.Body, activity)
+	}
+	if utf8.RuneCountInString(title) > 255 || utf8.RuneCountInString(body) > maxAgreementLength {
+		return nil, apperror.Newf(apperror.CodeValidation, "agreement title or text is too long (max %d characters)", maxAgreementLength)
+	}
+
+	agreement.Type = input.Type
+	agreement.Title = title
+	agreement.Body = body
+	agreement.TextHash = hashAgreementText(agreementText(agreement))
+	if err := s.repo.Save(agreement); err != nil {
+		return nil, fmt.Errorf("failed to save agreement: %w", err)
+	}
+	return agreement, nil
+}
+
+// GetAgreement returns the agreement to anyone who can see the activity.
+func (s *agreementService) GetAgreement(activityID, viewerID uint) (*AgreementView, error) {
+	activity, err := s.activityRepo.GetByID(activityID)
+	if err != nil || !canViewActivity(s.activityRepo, activity, viewerID) {
+		return nil, apperror.New(apperror.CodeNotFound, "activity not found")
+	}
+	agreement, err := s.repo.Get(activityID)
+	if err != nil {
+		return nil, apperror.New(apperror.CodeNotFound, "this activity has no agreement")
+	}
+
+	view := &AgreementView{Agreement: agreement}
+	if viewerID != 0 {
+		if signature, err := s.repo.GetSignature(agreement.ID, viewerID); err == nil {
+			view.MySignature = signature
+		}
+	}
+	return view, nil
+}
+
+// Sign records an accepted participant's signature over the current text.
+func (s *agreementService) Sign(activityID, userID uint, input SignAgreementInput, ip string) (*model.AgreementSignature, error) {
+	agreement, err := s.repo.Get(activityID)
+	if err != nil {
+		return nil, apperror.New(apperror.CodeNotFound, "this activity has no agreement")
+	}
+	participant, err := s.activityRepo.GetParticipant(activityID, userID)
+	if err != nil || participant.Status != "accepted" {
+		return nil, apperror.New(apperror.CodeForbidden, "only accepted participants can sign the agreement")
+	}
+	if input.TextHash != agreement.TextHash {
+		return nil, apperror.New(apperror.CodeConflict, "the agreement has changed; please review it again")
+	}
+	if _, err := s.repo.GetSignature(agreement.ID, userID); err == nil {
+		return nil, apperror.New(apperror.CodeConflict, "you have already signed this agreement")
+	}
+
+	text := agreementText(agreement)
+	signature := &model.AgreementSignature{
+		AgreementID: agreement.ID,
+		ActivityID:  activityID,
+		UserID:      userID,
+		SignerName:  s.displayName(userID),
+		SignedText:  text,
+		TextHash:    hashAgreementText(text),
+		IP:          ip,
+		SignedAt:    time.Now().UTC(),
+	}
+	if err := s.repo.CreateSignature(signature); err != nil {
+		return nil, fmt.Errorf("failed to sign agreement: %w", err)
+	}
+	return signature, nil
+}
+
+// ListSignatureStatus lists every accepted participant and whether they have signed.
+func (s *agreementService) ListSignatureStatus(activityID, hostID uint) ([]SignatureStatus, error) {
+	activity, err := s.activityRepo.GetByID(activityID)
+	if err != nil {
+		return nil, apperror.New(apperror.CodeNotFound, "activity not found")
+	}
+	if err := authorizeActivity(s.activityRepo, activity, hostID, model.CoHostManageApplicants); err != nil {
+		return nil, err
+	}
+	agreement, err := s.repo.Get(activityID)
+	if err != nil {
+		return nil, apperror.New(apperror.CodeNotFound, "this activity has no agreement")
+	}
+
+	participants, err := s.activityRepo.ListParticipants(activityID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list participants: %w", err)
+	}
+	signatures, err := s.repo.ListSignatures(agreement.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list signatures: %w", err)
+	}
+	signedAt := make(map[uint]time.Time, len(signatures))
+	for _, sig := range signatures {
+		signedAt[sig.UserID] = sig.SignedAt
+	}
+
+	statuses := make([]SignatureStatus, 0, len(participants))
+	for _, p := range participants {
+		status := SignatureStatus{UserID: p.UserID, User: p.User}
+		if at, ok := signedAt[p.UserID]; ok {
+			status.Signed = true
+			status.SignedAt = &at
+		}
+		statuses = append(statuses, status)
+	}
+	return statuses, nil
+}
+
+// RenderPDF renders signerID's signed agreement. Signers can get their own
+// copy; the host side can get anyone's. signerID 0 means the viewer.
+func (s *agreementService) RenderPDF(activityID, viewerID, signerID uint) ([]byte, error) {
+	if signerID == 0 {
+		signerID = viewerID
+	}
+	activity, err := s.activityRepo.GetByID(activityID)
+	if err != nil {
+		return nil, apperror.New(apperror.CodeNotFound, "activity not found")
+	}
+	if signerID != viewerID {
+		if err := authorizeActivity(s.activityRepo, activity, viewerID, model.CoHostManageApplicants); err != nil {
+			return nil, err
+		}
+	}
+	agreement, err := s.repo.Get(activityID)
+	if err != nil {
+		return nil, apperror.New(apperror.CodeNotFound, "this activity has no agreement")
+	}
+	signature, err := s.repo.GetSignature(agreement.ID, signerID)
+	if err != nil {
+		return nil, apperror.New(apperror.CodeNotFound, "signature not found")
+	}
+
+	doc := pdf.New()
+	doc.Heading(agreement.Title)
+	doc.Note(fmt.Sprintf("活動：%s（編號 %d）", activity.Title, activity.ID))
+	doc.Space()
+	// SignedText starts with the title, already shown as the heading
+	doc.Paragraph(strings.TrimPrefix(signature.SignedText, agreement.Title+"\n\n"))
+	doc.Space()
+	doc.Paragraph("電子簽署紀錄")
+	doc.Note(fmt.Sprintf("簽署人：%s（使用者編號 %d）", signature.SignerName, signature.UserID))
+	doc.Note(fmt.Sprintf("簽署時間：%s（%s）", signature.SignedAt.UTC().Format(time.RFC3339),
+		activity.LocalTime(signature.SignedAt).Format("2006-01-02 15:04 MST")))
+	doc.Note("IP 位址：" + signature.IP)
+	doc.Note("文件雜湊（SHA-256）：" + signature.TextHash)
+	return doc.Bytes(), nil
+}
+
+// displayName returns the user's profile name, falling back to the username.
+func (s *agreementService) displayName(userID uint) string {
+	if profile, err := s.userRepo.GetProfileByUserID(userID); err == nil && profile.DisplayName != "" {
+		return profile.DisplayName
+	}
+	if user, err := s.userRepo.GetByID(userID); err == nil {
+		return user.UserName
+	}
+	return ""
+}
+
+func findAgreementTemplate(agreementType string) *AgreementTemplate {
+	for i := range agreementTemplates {
+		if agreementTemplates[i].Type == agreementType {
+			return &agreementTemplates[i]
+		}
+	}
+	return nil
+}
+
+// fillAgreementTemplate substitutes the activity's details into a template body.
+func fillAgreementTemplate(body string, activity *model.Activity) string {
+	date := "日期未定"
+	if !activity.EventTime.IsZero() {
+		date = activity.LocalTime(activity.EventTime).Format("2006-01-02 15:04 MST")
+	}
+	location := activity.Location
+	if location == "" {
+		location = "地點未定"
+	}
+	return strings.NewReplacer(
+		"{activity}", activity.Title,
+		"{date}", date,
+		"{location}", location,
+		"{host}", activityStaffName(activity, activity.HostID),
+	).Replace(body)
+}
+
+// agreementText is the exact text a participant signs.
+func agreementText(agreement *model.ActivityAgreement) string {
+	return agreement.Title + "\n\n" + agreement.Body
+}
+
+func hashAgreementText(text string) string {
+	sum := sha256.Sum256([]byte(text))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,202 @@
+package service_test
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"azure-magnetar/internal/model"
+	"azure-magnetar/internal/service"
+)
+
+// --- Mock Agreement Repository ---
+
+type mockAgreementRepo struct {
+	agreements []*model.ActivityAgreement
+	signatures []*model.AgreementSignature
+}
+
+func newMockAgreementRepo() *mockAgreementRepo {
+	return &mockAgreementRepo{}
+}
+
+func (r *mockAgreementRepo) Get(activityID uint) (*model.ActivityAgreement, error) {
+	for _, a := range r.agreements {
+		if a.ActivityID == activityID {
+			return a, nil
+		}
+	}
+	return nil, errors.New("not found")
+}
+
+func (r *mockAgreementRepo) Save(agreement *model.ActivityAgreement) error {
+	if agreement.ID == 0 {
+		agreement.ID = uint(len(r.agreements) + 1)
+		r.agreements = append(r.agreements, agreement)
+	}
+	return nil
+}
+
+func (r *mockAgreementRepo) CreateSignature(signature *model.AgreementSignature) error {
+	signature.ID = uint(len(r.signatures) + 1)
+	r.signatures = append(r.signatures, signature)
+	return nil
+}
+
+func (r *mockAgreementRepo) GetSignature(agreementID, userID uint) (*model.AgreementSignature, error) {
+	for _, sig := range r.signatures {
+		if sig.AgreementID == agreementID && sig.UserID == userID {
+			return sig, nil
+		}
+	}
+	return nil, errors.New("not found")
+}
+
+func (r *mockAgreementRepo) ListSignatures(agreementID uint) ([]model.AgreementSignature, error) {
+	var result []model.AgreementSignature
+	for _, sig := range r.signatures {
+		if sig.AgreementID == agreementID {
+			result = append(result, *sig)
+		}
+	}
+	return result, nil
+}
+
+func setupAgreementTest() (service.AgreementService, *mockActivityRepo, *mockUserRepo) {
+	activityRepo := newMockActivityRepo()
+	userRepo := newMockUserRepo()
+	svc := service.NewAgreementService(newMockAgreementRepo(), activityRepo, userRepo)
+	return svc, activityRepo, userRepo
+}
+
+func TestAgreement_SetFromTemplate(t *testing.T) {
+	svc, activities, _ := setupAgreementTest()
+	activity := &model.Activity{
+		HostID:    1,
+		Title:     "Forest Portraits",
+		Location:  "Yangmingshan",
+		EventTime: time.Date(2030, 5, 1, 1, 0, 0, 0, time.UTC),
+		Timezone:  "Asia/Taipei",
+	}
+	_ = activities.Create(activity)
+
+	if len(svc.ListTemplates()) != 4 {
+		t.Fatalf("templates = %d, want 4", len(svc.ListTemplates()))
+	}
+	if _, err := svc.SetAgreement(activity.ID, 2, service.SetAgreementInput{Type: model.AgreementTFP}); err == nil {
+		t.Fatal("participants should not set the agreement")
+	}
+	if _, err := svc.SetAgreement(activity.ID, 1, service.SetAgreementInput{Type: "forever"}); err == nil {
+		t.Fatal("expected error for an unknown type")
+	}
+
+	agreement, err := svc.SetAgreement(activity.ID, 1, service.SetAgreementInput{Type: model.AgreementPortfolioOnly})
+	if err != nil {
+		t.Fatalf("SetAgreement failed: %v", err)
+	}
+	if !strings.Contains(agreement.Body, "Forest Portraits") || !strings.Contains(agreement.Body, "2030-05-01 09:00 CST") ||
+		!strings.Contains(agreement.Body, "Yangmingshan") || strings.Contains(agreement.Body, "{") {
+		t.Errorf("template not filled in: %q", agreement.Body)
+	}
+	sum := sha256.Sum256([]byte(agreement.Title + "\n\n" + agreement.Body))
+	if agreement.TextHash != hex.EncodeToString(sum[:]) {
+		t.Error("TextHash does not match the agreement text")
+	}
+}
+
+func TestAgreement_SignAndTrackStatus(t *testing.T) {
+	svc, activities, users := setupAgreementTest()
+	users.users[2] = &model.User{ID: 2, UserName: "model_amy"}
+	activity := &model.Activity{HostID: 1, Title: "Forest Portraits"}
+	_ = activities.Create(activity)
+	_ = activities.CreateParticipant(&model.ActivityParticipant{ActivityID: activity.ID, UserID: 2, Status: "accepted"})
+	_ = activities.CreateParticipant(&model.ActivityParticipant{ActivityID: activity.ID, UserID: 3, Status: "accepted"})
+	_ = activities.CreateParticipant(&model.ActivityParticipant{ActivityID: activity.ID, UserID: 4, Status: "pending"})
+	agreement, _ := svc.SetAgreement(activity.ID, 1, service.SetAgreementInput{Type: model.AgreementTFP})
+
+	if _, err := svc.Sign(activity.ID, 4, service.SignAgreementInput{TextHash: agreement.TextHash}, "203.0.113.9"); err == nil {
+		t.Fatal("pending applicants should not sign")
+	}
+	if _, err := svc.Sign(activity.ID, 2, service.SignAgreementInput{}, "203.0.113.9"); err == nil {
+		t.Fatal("expected error without the hash of the text read")
+	}
+	if _, err := svc.Sign(activity.ID, 2, service.SignAgreementInput{TextHash: "stale"}, "203.0.113.9"); err == nil {
+		t.Fatal("expected error for a stale text hash")
+	}
+
+	sig, err := svc.Sign(activity.ID, 2, service.SignAgreementInput{TextHash: agreement.TextHash}, "203.0.113.9")
+	if err != nil {
+		t.Fatalf("Sign failed: %v", err)
+	}
+	if sig.IP != "203.0.113.9" || sig.TextHash != agreement.TextHash || sig.SignerName != "model_amy" ||
+		time.Since(sig.SignedAt) > time.Minute {
+		t.Errorf("signature = %+v", sig)
+	}
+	if _, err := svc.Sign(activity.ID, 2, service.SignAgreementInput{TextHash: agreement.TextHash}, "203.0.113.9"); err == nil {
+		t.Error("signing twice should fail")
+	}
+
+	// The text is locked once signed
+	if _, err := svc.SetAgreement(activity.ID, 1, service.SetAgreementInput{Type: model.AgreementPaid}); err == nil {
+		t.Error("expected error changing a signed agreement")
+	}
+
+	if _, err := svc.ListSignatureStatus(activity.ID, 2); err == nil {
+		t.Fatal("participants should not see the signature list")
+	}
+	statuses, err := svc.ListSignatureStatus(activity.ID, 1)
+	if err != nil {
+		t.Fatalf("ListSignatureStatus failed: %v", err)
+	}
+	signed := map[uint]bool{}
+	for _, st := range statuses {
+		signed[st.UserID] = st.Signed
+	}
+	if len(statuses) != 2 || !signed[2] || signed[3] {
+		t.Errorf("statuses = %+v, want user 2 signed and user 3 not", statuses)
+	}
+
+	view, _ := svc.GetAgreement(activity.ID, 2)
+	if view.MySignature == nil {
+		t.Error("GetAgreement should include the viewer's signature")
+	}
+}
+
+func TestAgreement_RenderPDF(t *testing.T) {
+	svc, activities, _ := setupAgreementTest()
+	activity := &model.Activity{HostID: 1, Title: "Forest Portraits"}
+	_ = activities.Create(activity)
+	_ = activities.CreateParticipant(&model.ActivityParticipant{ActivityID: activity.ID, UserID: 2, Status: "accepted"})
+	agreement, _ := svc.SetAgreement(activity.ID, 1, service.SetAgreementInput{Type: model.AgreementCommercial})
+	sig, _ := svc.Sign(activity.ID, 2, service.SignAgreementInput{TextHash: agreement.TextHash}, "198.51.100.7")
+
+	own, err := svc.RenderPDF(activity.ID, 2, 0)
+	if err != nil {
+		t.Fatalf("RenderPDF failed: %v", err)
+	}
+	if !bytes.HasPrefix(own, []byte("%PDF-")) {
+		t.Fatal("output is not a PDF")
+	}
+	// The hash is printed in the signature block (ASCII digits are UCS-2 encoded)
+	var hashHex strings.Builder
+	for _, r := range sig.TextHash[:8] {
+		hashHex.WriteString(strings.ToUpper(hex.EncodeToString([]byte{0, byte(r)})))
+	}
+	if !bytes.Contains(own, []byte(hashHex.String())) {
+		t.Error("PDF should contain the text hash")
+	}
+
+	if _, err := svc.RenderPDF(activity.ID, 1, 2); err != nil {
+		t.Errorf("host RenderPDF failed: %v", err)
+	}
+	if _, err := svc.RenderPDF(activity.ID, 3, 2); err == nil {
+		t.Error("participants should not get someone else's PDF")
+	}
+	if _, err := svc.RenderPDF(activity.ID, 1, 3); err == nil {
+		t.Error("expected error for an unsigned participant")
+	}
+}
@@ -0,0 +1,332 @@
+package service
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"azure-magnetar/internal/model"
+	"azure-magnetar/internal/repository"
+	"azure-magnetar/pkg/apperror"
+)
+
+// Portfolio limits.
+const (
+	maxAlbumsPerUser    = 50
+	maxAlbumWorks       = 200
+	maxAlbumTitleLength = 100
+	maxPinnedWorks      = 6
+)
+
+// AlbumService defines the interface for organizing works into albums.
+type AlbumService interface {
+	ListByUser(userID, viewerID uint) ([]model.Album, error)
+	Get(albumID, viewerID uint) (*model.Album, error)
+	Create(userID uint, input CreateAlbumInput) (*model.Album, error)
+	Update(userID, albumID uint, input UpdateAlbumInput) (*model.Album, error)
+	Delete(userID, albumID uint) error
+	SetWorks(userID, albumID uint, input AlbumWorksInput) (*model.Album, error)
+	Reorder(userID uint, input ReorderAlbumsInput) ([]model.Album, error)
+}
+
+// CreateAlbumInput represents the data for creating an album.
+type CreateAlbumInput struct {
+	Title       string `json:"title" binding:"required"`
+	WorkIDs     []uint `json:"workIds"`     // Initial works, in order
+	CoverWorkID *uint  `json:"coverWorkId"` // Must be one of WorkIDs; defaults to the first
+}
+
+// UpdateAlbumInput represents the data for updating an album. A CoverWorkID
+// of 0 resets the cover to the first work.
+type UpdateAlbumInput struct {
+	Title       string `json:"title"`
+	CoverWorkID *uint  `json:"coverWorkId"`
+}
+
+// AlbumWorksInput replaces an album's works, in order.
+type AlbumWorksInput struct {
+	WorkIDs []uint `json:"workIds"`
+}
+
+// ReorderAlbumsInput sets the profile order of the caller's albums. Albums not
+// listed keep their relative order after the listed ones.
+type ReorderAlbumsInput struct {
+	AlbumIDs []uint `json:"albumIds" binding:"required"`
+}
+
+type albumService struct {
+	repo     repository.AlbumRepository
+	workRepo repository.WorkRepository
+}
+
+// NewAlbumService creates a new AlbumService.
+func NewAlbumService(repo repository.AlbumRepository, workRepo repository.WorkRepository) AlbumService {
+	return &albumService{repo: repo, workRepo: workRepo}
+}
+
+// ListByUser returns userID's albums, hiding works viewerID may not see
+// listed.
+func (s *albumService) ListByUser(userID, viewerID uint) ([]model.Album, error) {
+	albums, err := s.repo.List(userID)
+	if err != nil {
+		return nil, err
+	}
+	allowed := listedVisibilities(s.workRepo, userID, viewerID)
+	for i := range albums {
+		filterAlbumWorks(&albums[i], allowed)
+		setAlbumCover(&albums[i])
+	}
+	return albums, nil
+}
+
+func (s *albumService) Get(albumID, viewerID uint) (*model.Album, error) {
+	album, err := s.repo.Get(albumID)
+	if err != nil {
+		return nil, apperror.New(apperror.CodeNotFound, "album not found")
+	}
+	filterAlbumWorks(album, listedVisibilities(s.workRepo, album.UserID, viewerID))
+	setAlbumCover(album)
+	return album, nil
+}
+
+func (s *albumService) Create(userID uint, input CreateAlbumInput) (*model.Album, error) {
+	title, err := validateAlbumTitle(input.Title)
+	if err != nil {
+		return nil, err
+	}
+
+	count, err := s.repo.Count(userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count albums: %w", err)
+	}
+	if count >= maxAlbumsPerUser {
+		return nil, apperror.Newf(apperror.CodeValidation, "you can have at most %d albums", maxAlbumsPerUser)
+	}
+
+	workIDs, err := ownWorkIDs(s.workRepo, userID, input.WorkIDs, maxAlbumWorks)
+	if err != nil {
+		return nil, err
+	}
+
+	album := &model.Album{UserID: userID, Title: title}
+	if input.CoverWorkID != nil && *input.CoverWorkID != 0 {
+		if !containsID(workIDs, *input.CoverWorkID) {
+			return nil, apperror.New(apperror.CodeValidation, "cover must be one of the album's works")
+		}
+		album.CoverWorkID = input.CoverWorkID
+	}
+
+	if err := s.repo.Create(album, workIDs); err != nil {
+		return nil, fmt.Errorf("failed to create album: %w", err)
+	}
+	return s.Get(album.ID, userID)
+}
+
+func (s *albumService) Update(userID, albumID uint, input UpdateAlbumInput) (*model.Album, error) {
+	album, err := s.ownAlbum(userID, albumID)
+	if err != nil {
+		return nil, err
+	}
+
+	if input.Title != "" {
+		title, err := validateAlbumTitle(input.Title)
+		if err != nil {
+			return nil, err
+		}
+		album.Title = title
+	}
+
+	if input.CoverWorkID != nil {
+		if *input.CoverWorkID == 0 {
+			album.CoverWorkID = nil
+		} else {
+			if !albumHasWork(album, *input.CoverWorkID) {
+				return nil, apperror.New(apperror.CodeValidation, "cover must be one of the album's works")
+			}
+			album.CoverWorkID = input.CoverWorkID
+		}
+	}
+
+	if err := s.repo.Update(album); err != nil {
+		return nil, fmt.Errorf("failed to update album: %w", err)
+	}
+	setAlbumCover(album)
+	return album, nil
+}
+
+func (s *albumService) Delete(userID, albumID uint) error {
+	if _, err := s.ownAlbum(userID, albumID); err != nil {
+		return err
+	}
+	return s.repo.Delete(albumID)
+}
+
+// SetWorks replaces an album's works, which adds, removes and reorders them in
+// one call. A cover that is no longer in the album falls back to the first work.
+func (s *albumService) SetWorks(userID, albumID uint, input AlbumWorksInput) (*model.Album, error) {
+	album, err := s.ownAlbum(userID, albumID)
+	if err != nil {
+		return nil, err
+	}
+
+	workIDs, err := ownWorkIDs(s.workRepo, userID, input.WorkIDs, maxAlbumWorks)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.SetWorks(albumID, workIDs); err != nil {
+		return nil, fmt.Errorf("failed to update album works: %w", err)
+	}
+	if album.CoverWorkID != nil && !containsID(workIDs, *album.CoverWorkID) {
+		album.CoverWorkID = nil
+		if err := s.repo.Update(album); err != nil {
+			return nil, fmt.Errorf("failed to update album: %w", err)
+		}
+	}
+
+	return s.Get(albumID, userID)
+}
+
+func (s *albumService) Reorder(userID uint, input ReorderAlbumsInput) ([]model.Album, error) {
+	albums, err := s.repo.List(userID)
+	if err != nil {
+		return nil, err
+	}
+	owned := make(map[uint]bool, len(albums))
+	for _, a := range albums {
+		owned[a.ID] = true
+	}
+
+	order := make([]uint, 0, len(albums))
+	listed := make(map[uint]bool, len(input.AlbumIDs))
+	for _, id := range input.AlbumIDs {
+		if !owned[id] {
+			return nil, apperror.Newf(apperror.CodeNotFound, "album %d not found", id)
+		}
+		if !listed[id] {
+			listed[id] = true
+			order = append(order, id)
+		}
+	}
+	for _, a := range albums {
+		if !listed[a.ID] {
+			order = append(order, a.ID)
+		}
+	}
+
+	if err := s.repo.Reorder(userID, order); err != nil {
+		return nil, fmt.Errorf("failed to reorder albums: %w", err)
+	}
+	return s.ListByUser(userID, userID)
+}
+
+// ownAlbum loads an album and checks that userID owns it.
+func (s *albumService) ownAlbum(userID, albumID uint) (*model.Album, error) {
+	album, err := s.repo.Get(albumID)
+	if err != nil {
+		return nil, apperror.New(apperror.CodeNotFound, "album not found")
+	}
+	if album.UserID != userID {
+		return nil, apperror.New(apperror.CodeForbidden, "only the owner can edit this album")
+	}
+	return album, nil
+}
+
+func validateAlbumTitle(title string) (string, error) {
+	title = strings.TrimSpace(title)
+	if title == "" {
+		return "", apperror.New(apperror.CodeValidation, "title is required")
+	}
+	if utf8.RuneCountInString(title) > maxAlbumTitleLength {
+		return "", apperror.Newf(apperror.CodeValidation, "title must be at most %d characters", maxAlbumTitleLength)
+	}
+	return title, nil
+}
+
+// ownWorkIDs dedupes ids, keeping the first occurrence, and checks that there
+// are at most limit of them and that userID authored each one.
+func ownWorkIDs(repo repository.WorkRepository, userID uint, ids []uint, limit int) ([]uint, error) {
+	result := make([]uint, 0, len(ids))
+	seen := make(map[uint]bool, len(ids))
+	for _, id := range ids {
+		if !seen[id] {
+			seen[id] = true
+			result = append(result, id)
+		}
+	}
+	if len(result) > limit {
+		return nil, apperror.Newf(apperror.CodeValidation, "at most %d works are allowed", limit)
+	}
+	if len(result) == 0 {
+		return result, nil
+	}
+
+	works, err := repo.GetByIDs(result)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load works: %w", err)
+	}
+	authored := make(map[uint]bool, len(works))
+	for _, w := range works {
+		if w.UserID == userID {
+			authored[w.ID] = true
+		}
+	}
+	for _, id := range result {
+		if !authored[id] {
+			return nil, apperror.Newf(apperror.CodeNotFound, "work %d not found", id)
+		}
+	}
+	return result, nil
+}
+
+// filterAlbumWorks drops works whose visibility is not in allowed; nil keeps
+// everything.
+func filterAlbumWorks(album *model.Album, allowed []string) {
+	if allowed == nil {
+		return
+	}
+	ok := make(map[string]bool, len(allowed))
+	for _, v := range allowed {
+		ok[v] = true
+	}
+	works := album.Works[:0]
+	for _, w := range album.Works {
+		if ok[w.Visibility] || (w.Visibility == "" && ok["public"]) {
+			works = append(works, w)
+		}
+	}
+	album.Works = works
+}
+
+// setAlbumCover fills CoverURL from the chosen cover work or, when unset, the
+// first work in the album.
+func setAlbumCover(album *model.Album) {
+	album.CoverURL = ""
+	for _, w := range album.Works {
+		if album.CoverWorkID == nil || w.ID == *album.CoverWorkID {
+			album.CoverURL = w.ImageURL
+			return
+		}
+	}
+	if len(album.Works) > 0 {
+		album.CoverURL = album.Works[0].ImageURL
+	}
+}
+
+func albumHasWork(album *model.Album, workID uint) bool {
+	for _, w := range album.Works {
+		if w.ID == workID {
+			return true
+		}
+	}
+	return false
+}
+
+func containsID(ids []uint, id uint) bool {
+	for _, v := range ids {
+		if v == id {
+			return true
+		}
+	}
+	return false
+}
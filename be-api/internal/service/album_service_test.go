@@ -0,0 +1,217 @@
+package service_test
+
+import (
+	"sort"
+	"testing"
+
+	"azure-magnetar/internal/model"
+	"azure-magnetar/internal/service"
+)
+
+// --- Mock Album Repository ---
+
+type mockAlbumRepo struct {
+	albums  map[uint]*model.Album
+	entries map[uint][]uint // key: albumID, ordered work IDs
+	works   *mockWorkRepo
+}
+
+func newMockAlbumRepo(works *mockWorkRepo) *mockAlbumRepo {
+	return &mockAlbumRepo{
+		albums:  make(map[uint]*model.Album),
+		entries: make(map[uint][]uint),
+		works:   works,
+	}
+}
+
+func (r *mockAlbumRepo) Create(album *model.Album, workIDs []uint) error {
+	album.ID = uint(len(r.albums) + 1)
+	album.Position = len(r.albums) + 1
+	stored := *album
+	r.albums[album.ID] = &stored
+	r.entries[album.ID] = append([]uint(nil), workIDs...)
+	return nil
+}
+
+func (r *mockAlbumRepo) Get(id uint) (*model.Album, error) {
+	a, ok := r.albums[id]
+	if !ok {
+		return nil, errNotFound
+	}
+	album := *a
+	album.Works = []model.Post{}
+	for _, wid := range r.entries[id] {
+		if p, ok := r.works.works[wid]; ok {
+			album.Works = append(album.Works, *p)
+		}
+	}
+	return &album, nil
+}
+
+func (r *mockAlbumRepo) List(userID uint) ([]model.Album, error) {
+	var result []model.Album
+	for id, a := range r.albums {
+		if a.UserID == userID {
+			album, _ := r.Get(id)
+			result = append(result, *album)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Position < result[j].Position })
+	return result, nil
+}
+
+func (r *mockAlbumRepo) Count(userID uint) (int64, error) {
+	var count int64
+	for _, a := range r.albums {
+		if a.UserID == userID {
+			count++
+		}
+	}
+	return count, nil
+}
+
+func (r *mockAlbumRepo) Update(album *model.Album) error {
+	stored := *album
+	stored.Works = nil
+	r.albums[album.ID] = &stored
+	return nil
+}
+
+func (r *mockAlbumRepo) Delete(id uint) error {
+	delete(r.albums, id)
+	delete(r.entries, id)
+	return nil
+}
+
+func (r *mockAlbumRepo) SetWorks(albumID uint, workIDs []uint) error {
+	r.entries[albumID] = append([]uint(nil), workIDs...)
+	return nil
+}
+
+func (r *mockAlbumRepo) Reorder(userID uint, albumIDs []uint) error {
+	for i, id := range albumIDs {
+		if a, ok := r.albums[id]; ok && a.UserID == userID {
+			a.Position = i + 1
+		}
+	}
+	return nil
+}
+
+// seedWorks creates n works for userID directly in the mock repo.
+func seedWorks(repo *mockWorkRepo, userID uint, n int) []uint {
+	ids := make([]uint, n)
+	for i := range ids {
+		p := &model.Post{UserID: userID, ImageURL: "https://img.example/" + string(rune('a'+i)) + ".jpg"}
+		_ = repo.Create(p)
+		ids[i] = p.ID
+	}
+	return ids
+}
+
+func TestAlbum_CreateOrderAndCover(t *testing.T) {
+	repo := newMockWorkRepo()
+	svc := service.NewAlbumService(newMockAlbumRepo(repo), repo)
+	works := seedWorks(repo, 1, 3)
+	others := seedWorks(repo, 2, 1)
+
+	if _, err := svc.Create(1, service.CreateAlbumInput{Title: "Street", WorkIDs: []uint{works[0], others[0]}}); err == nil {
+		t.Fatal("albums should only hold the owner's works")
+	}
+
+	album, err := svc.Create(1, service.CreateAlbumInput{Title: " Street ", WorkIDs: []uint{works[2], works[0], works[2]}})
+	if err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+	if album.Title != "Street" || len(album.Works) != 2 || album.Works[0].ID != works[2] {
+		t.Errorf("album = %+v, want trimmed title and deduped works in given order", album)
+	}
+	if album.CoverURL != album.Works[0].ImageURL {
+		t.Errorf("CoverURL = %s, want first work's image", album.CoverURL)
+	}
+
+	// A work can be in several albums
+	if _, err := svc.Create(1, service.CreateAlbumInput{Title: "Best of", WorkIDs: []uint{works[0]}}); err != nil {
+		t.Fatalf("second album failed: %v", err)
+	}
+
+	cover := works[0]
+	album, err = svc.Update(1, album.ID, service.UpdateAlbumInput{CoverWorkID: &cover})
+	if err != nil {
+		t.Fatalf("Update cover failed: %v", err)
+	}
+	if album.CoverURL != repo.works[works[0]].ImageURL {
+		t.Errorf("CoverURL = %s, want chosen cover", album.CoverURL)
+	}
+
+	// Reorder and drop the cover: it falls back to the new first work
+	album, err = svc.SetWorks(1, album.ID, service.AlbumWorksInput{WorkIDs: []uint{works[1], works[2]}})
+	if err != nil {
+		t.Fatalf("SetWorks failed: %v", err)
+	}
+	if album.CoverWorkID != nil || album.CoverURL != repo.works[works[1]].ImageURL {
+		t.Errorf("cover = %v %s, want reset to first work", album.CoverWorkID, album.CoverURL)
+	}
+
+	if _, err := svc.SetWorks(2, album.ID, service.AlbumWorksInput{}); err == nil {
+		t.Error("non-owner should not edit the album")
+	}
+	if err := svc.Delete(2, album.ID); err == nil {
+		t.Error("non-owner should not delete the album")
+	}
+}
+
+func TestAlbum_Reorder(t *testing.T) {
+	repo := newMockWorkRepo()
+	svc := service.NewAlbumService(newMockAlbumRepo(repo), repo)
+
+	var ids []uint
+	for _, title := range []string{"A", "B", "C"} {
+		a, err := svc.Create(1, service.CreateAlbumInput{Title: title})
+		if err != nil {
+			t.Fatalf("Create failed: %v", err)
+		}
+		ids = append(ids, a.ID)
+	}
+	other, _ := svc.Create(2, service.CreateAlbumInput{Title: "Other"})
+
+	if _, err := svc.Reorder(1, service.ReorderAlbumsInput{AlbumIDs: []uint{other.ID}}); err == nil {
+		t.Fatal("reordering someone else's album should fail")
+	}
+
+	albums, err := svc.Reorder(1, service.ReorderAlbumsInput{AlbumIDs: []uint{ids[2]}})
+	if err != nil {
+		t.Fatalf("Reorder failed: %v", err)
+	}
+	var got []string
+	for _, a := range albums {
+		got = append(got, a.Title)
+	}
+	if len(got) != 3 || got[0] != "C" || got[1] != "A" || got[2] != "B" {
+		t.Errorf("order = %v, want [C A B]", got)
+	}
+}
+
+func TestSetPinnedWorks(t *testing.T) {
+	repo := newMockWorkRepo()
+	svc := service.NewWorkService(repo, newMockActivityRepo(), "http://localhost:8080", "")
+	works := seedWorks(repo, 1, 8)
+
+	if _, err := svc.SetPinned(1, service.PinWorksInput{WorkIDs: works[:7]}); err == nil {
+		t.Fatal("pinning more than the limit should fail")
+	}
+
+	list, err := svc.SetPinned(1, service.PinWorksInput{WorkIDs: []uint{works[3], works[1]}})
+	if err != nil {
+		t.Fatalf("SetPinned failed: %v", err)
+	}
+	if list[0].ID != works[3] || list[1].ID != works[1] || list[2].PinOrder != 0 {
+		t.Errorf("pinned works should lead in pin order, got %d, %d", list[0].ID, list[1].ID)
+	}
+
+	list, _ = svc.SetPinned(1, service.PinWorksInput{})
+	for _, w := range list {
+		if w.PinOrder != 0 {
+			t.Errorf("work %d still pinned after clearing", w.ID)
+		}
+	}
+}
@@ -0,0 +1,249 @@
+package service
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"azure-magnetar/internal/model"
+	"azure-magnetar/internal/repository"
+	"azure-magnetar/pkg/apperror"
+)
+
+// Bookmark limits and page sizes.
+const (
+	maxBookmarkBoards       = 50
+	maxBoardNameLength      = 50
+	defaultBookmarkPageSize = 20
+	maxBookmarkPageSize     = 50
+)
+
+// BookmarkService defines the interface for private bookmarks of works and activities.
+type BookmarkService interface {
+	ListBoards(userID uint) ([]model.BookmarkBoard, error)
+	CreateBoard(userID uint, input BookmarkBoardInput) (*model.BookmarkBoard, error)
+	RenameBoard(userID, boardID uint, input BookmarkBoardInput) (*model.BookmarkBoard, error)
+	DeleteBoard(userID, boardID uint) error
+
+	Save(userID uint, targetType string, targetID uint, input SaveBookmarkInput) (*model.Bookmark, error)
+	Unsave(userID uint, targetType string, targetID uint) error
+	List(userID uint, query BookmarkQuery) (*BookmarkPage, error)
+}
+
+// BookmarkBoardInput names a bookmark board.
+type BookmarkBoardInput struct {
+	Name string `json:"name" binding:"required"`
+}
+
+// SaveBookmarkInput optionally files a saved item under one of the caller's boards.
+type SaveBookmarkInput struct {
+	BoardID *uint `json:"boardId"`
+}
+
+// BookmarkQuery selects a page of the caller's bookmarks.
+type BookmarkQuery struct {
+	Type     string // work, activity; empty for both
+	BoardID  *uint
+	BeforeID uint
+	Limit    int
+}
+
+// BookmarkPage is a page of bookmarks, newest first. Pass NextBefore as
+// ?before= to load older items; it is 0 when there are none.
+type BookmarkPage struct {
+	Items      []model.Bookmark `json:"items"`
+	NextBefore uint             `json:"nextBefore"`
+}
+
+type bookmarkService struct {
+	repo         repository.BookmarkRepository
+	workRepo     repository.WorkRepository
+	activityRepo repository.ActivityRepository
+}
+
+// NewBookmarkService creates a new BookmarkService.
+func NewBookmarkService(repo repository.BookmarkRepository, workRepo repository.WorkRepository, activityRepo repository.ActivityRepository) BookmarkService {
+	return &bookmarkService{
+		repo:         repo,
+		workRepo:     workRepo,
+		activityRepo: activityRepo,
+	}
+}
+
+// --- Boards ---
+
+func (s *bookmarkService) ListBoards(userID uint) ([]model.BookmarkBoard, error) {
+	return s.repo.ListBoards(userID)
+}
+
+func (s *bookmarkService) CreateBoard(userID uint, input BookmarkBoardInput) (*model.BookmarkBoard, error) {
+	boards, err := s.repo.ListBoards(userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list boards: %w", err)
+	}
+	if len(boards) >= maxBookmarkBoards {
+		return nil, apperror.Newf(apperror.CodeValidation, "you can have at most %d boards", maxBookmarkBoards)
+	}
+
+	name, err := validateBoardName(boards, 0, input.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	board := &model.BookmarkBoard{UserID: userID, Name: name}
+	if err := s.repo.CreateBoard(board); err != nil {
+		return nil, fmt.Errorf("failed to create board: %w", err)
+	}
+	return board, nil
+}
+
+func (s *bookmarkService) RenameBoard(userID, boardID uint, input BookmarkBoardInput) (*model.BookmarkBoard, error) {
+	board, err := s.ownBoard(userID, boardID)
+	if err != nil {
+		return nil, err
+	}
+
+	boards, err := s.repo.ListBoards(userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list boards: %w", err)
+	}
+	name, err := validateBoardName(boards, boardID, input.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	board.Name = name
+	if err := s.repo.UpdateBoard(board); err != nil {
+		return nil, fmt.Errorf("failed to rename board: %w", err)
+	}
+	return board, nil
+}
+
+// DeleteBoard removes a board; its items stay saved.
+func (s *bookmarkService) DeleteBoard(userID, boardID uint) error {
+	if _, err := s.ownBoard(userID, boardID); err != nil {
+		return err
+	}
+	return s.repo.DeleteBoard(boardID)
+}
+
+// ownBoard loads a board and checks that userID owns it. Other users' boards
+// are reported as missing since boards are private.
+func (s *bookmarkService) ownBoard(userID, boardID uint) (*model.BookmarkBoard, error) {
+	board, err := s.repo.GetBoard(boardID)
+	if err != nil || board.UserID != userID {
+		return nil, apperror.New(apperror.CodeNotFound, "board not found")
+	}
+	return board, nil
+}
+
+// validateBoardName trims name and checks its length and that no other board
+// of the user (other than exceptID) already uses it.
+func validateBoardName(boards []model.BookmarkBoard, exceptID uint, name string) (string, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return "", apperror.New(apperror.CodeValidation, "name is required")
+	}
+	if utf8.RuneCountInString(name) > maxBoardNameLength {
+		return "", apperror.Newf(apperror.CodeValidation, "name must be at most %d characters", maxBoardNameLength)
+	}
+	for _, b := range boards {
+		if b.ID != exceptID && strings.EqualFold(b.Name, name) {
+			return "", apperror.New(apperror.CodeConflict, "a board with this name already exists")
+		}
+	}
+	return name, nil
+}
+
+// --- Bookmarks ---
+
+// Save bookmarks a work or activity the caller can see. Saving an item that is
+// already saved moves it to the given board (or off any board).
+func (s *bookmarkService) Save(userID uint, targetType string, targetID uint, input SaveBookmarkInput) (*model.Bookmark, error) {
+	switch targetType {
+	case model.BookmarkWork:
+		work, err := s.workRepo.GetByID(targetID, 0)
+		if err != nil || !canViewWork(s.workRepo, work, userID) {
+			return nil, apperror.New(apperror.CodeNotFound, "work not found")
+		}
+	case model.BookmarkActivity:
+		activity, err := s.activityRepo.GetByID(targetID)
+		if err != nil || !canViewActivity(s.activityRepo, activity, userID) {
+			return nil, apperror.New(apperror.CodeNotFound, "activity not found")
+		}
+	default:
+		return nil, apperror.New(apperror.CodeValidation, "type must be 'work' or 'activity'")
+	}
+
+	bookmark := &model.Bookmark{UserID: userID, TargetType: targetType, TargetID: targetID}
+	if input.BoardID != nil && *input.BoardID != 0 {
+		if _, err := s.ownBoard(userID, *input.BoardID); err != nil {
+			return nil, err
+		}
+		bookmark.BoardID = input.BoardID
+	}
+
+	if err := s.repo.Save(bookmark); err != nil {
+		return nil, fmt.Errorf("failed to save bookmark: %w", err)
+	}
+	return bookmark, nil
+}
+
+func (s *bookmarkService) Unsave(userID uint, targetType string, targetID uint) error {
+	removed, err := s.repo.Delete(userID, targetType, targetID)
+	if err != nil {
+		return fmt.Errorf("failed to remove bookmark: %w", err)
+	}
+	if !removed {
+		return apperror.New(apperror.CodeNotFound, "not saved")
+	}
+	return nil
+}
+
+// List returns a page of the caller's bookmarks. Items whose target was
+// deleted, or a work or activity the caller can no longer see, are left out.
+func (s *bookmarkService) List(userID uint, query BookmarkQuery) (*BookmarkPage, error) {
+	if query.Type != "" && query.Type != model.BookmarkWork && query.Type != model.BookmarkActivity {
+		return nil, apperror.New(apperror.CodeValidation, "type must be 'work' or 'activity'")
+	}
+	if query.BoardID != nil {
+		if _, err := s.ownBoard(userID, *query.BoardID); err != nil {
+			return nil, err
+		}
+	}
+
+	limit := query.Limit
+	if limit <= 0 {
+		limit = defaultBookmarkPageSize
+	}
+	if limit > maxBookmarkPageSize {
+		limit = maxBookmarkPageSize
+	}
+
+	// Fetch one extra to learn whether older items remain
+	bookmarks, err := s.repo.List(userID, repository.BookmarkFilter{
+		TargetType: query.Type,
+		BoardID:    query.BoardID,
+		BeforeID:   query.BeforeID,
+		Limit:      limit + 1,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list bookmarks: %w", err)
+	}
+
+	page := &BookmarkPage{Items: []model.Bookmark{}}
+	if len(bookmarks) > limit {
+		bookmarks = bookmarks[:limit]
+		page.NextBefore = bookmarks[limit-1].ID
+	}
+	for _, b := range bookmarks {
+		switch {
+		case b.Work != nil && canViewWork(s.workRepo, b.Work, userID):
+		case b.Activity != nil && canViewActivity(s.activityRepo, b.Activity, userID):
+		default:
+			continue
+		}
+		page.Items = append(page.Items, b)
+	}
+	return page, nil
+}
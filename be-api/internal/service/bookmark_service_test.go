@@ -0,0 +1,230 @@
+package service_test
+
+import (
+	"sort"
+	"testing"
+
+	"azure-magnetar/internal/model"
+	"azure-magnetar/internal/repository"
+	"azure-magnetar/internal/service"
+)
+
+// --- Mock Bookmark Repository ---
+
+type mockBookmarkRepo struct {
+	boards     map[uint]*model.BookmarkBoard
+	bookmarks  []*model.Bookmark
+	nextID     uint
+	works      *mockWorkRepo
+	activities *mockActivityRepo
+}
+
+func newMockBookmarkRepo(works *mockWorkRepo, activities *mockActivityRepo) *mockBookmarkRepo {
+	return &mockBookmarkRepo{
+		boards:     make(map[uint]*model.BookmarkBoard),
+		nextID:     1,
+		works:      works,
+		activities: activities,
+	}
+}
+
+func (r *mockBookmarkRepo) CreateBoard(board *model.BookmarkBoard) error {
+	board.ID = uint(len(r.boards) + 1)
+	r.boards[board.ID] = board
+	return nil
+}
+
+func (r *mockBookmarkRepo) GetBoard(id uint) (*model.BookmarkBoard, error) {
+	b, ok := r.boards[id]
+	if !ok {
+		return nil, errNotFound
+	}
+	return b, nil
+}
+
+func (r *mockBookmarkRepo) ListBoards(userID uint) ([]model.BookmarkBoard, error) {
+	var result []model.BookmarkBoard
+	for _, b := range r.boards {
+		if b.UserID == userID {
+			board := *b
+			for _, bm := range r.bookmarks {
+				if bm.BoardID != nil && *bm.BoardID == b.ID {
+					board.ItemCount++
+				}
+			}
+			result = append(result, board)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].ID < result[j].ID })
+	return result, nil
+}
+
+func (r *mockBookmarkRepo) UpdateBoard(board *model.BookmarkBoard) error {
+	r.boards[board.ID] = board
+	return nil
+}
+
+func (r *mockBookmarkRepo) DeleteBoard(id uint) error {
+	for _, bm := range r.bookmarks {
+		if bm.BoardID != nil && *bm.BoardID == id {
+			bm.BoardID = nil
+		}
+	}
+	delete(r.boards, id)
+	return nil
+}
+
+func (r *mockBookmarkRepo) Save(bookmark *model.Bookmark) error {
+	for _, bm := range r.bookmarks {
+		if bm.UserID == bookmark.UserID && bm.TargetType == bookmark.TargetType && bm.TargetID == bookmark.TargetID {
+			bm.BoardID = bookmark.BoardID
+			bookmark.ID = bm.ID
+			return nil
+		}
+	}
+	bookmark.ID = r.nextID
+	r.nextID++
+	stored := *bookmark
+	r.bookmarks = append(r.bookmarks, &stored)
+	return nil
+}
+
+func (r *mockBookmarkRepo) Delete(userID uint, targetType string, targetID uint) (bool, error) {
+	for i, bm := range r.bookmarks {
+		if bm.UserID == userID && bm.TargetType == targetType && bm.TargetID == targetID {
+			r.bookmarks = append(r.bookmarks[:i], r.bookmarks[i+1:]...)
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (r *mockBookmarkRepo) List(userID uint, filter repository.BookmarkFilter) ([]model.Bookmark, error) {
+	var result []model.Bookmark
+	for i := len(r.bookmarks) - 1; i >= 0 && len(result) < filter.Limit; i-- {
+		bm := *r.bookmarks[i]
+		if bm.UserID != userID ||
+			(filter.TargetType != "" && bm.TargetType != filter.TargetType) ||
+			(filter.BoardID != nil && (bm.BoardID == nil || *bm.BoardID != *filter.BoardID)) ||
+			(filter.BeforeID > 0 && bm.ID >= filter.BeforeID) {
+			continue
+		}
+		switch bm.TargetType {
+		case model.BookmarkWork:
+			bm.Work = r.works.works[bm.TargetID]
+		case model.BookmarkActivity:
+			bm.Activity = r.activities.activities[bm.TargetID]
+		}
+		result = append(result, bm)
+	}
+	return result, nil
+}
+
+// --- Bookmark Service Tests ---
+
+func TestBookmark_SaveMoveAndPaginate(t *testing.T) {
+	works := newMockWorkRepo()
+	activities := newMockActivityRepo()
+	repo := newMockBookmarkRepo(works, activities)
+	svc := service.NewBookmarkService(repo, works, activities)
+	workIDs := seedWorks(works, 2, 3)
+
+	board, err := svc.CreateBoard(1, service.BookmarkBoardInput{Name: "Lighting refs"})
+	if err != nil {
+		t.Fatalf("CreateBoard failed: %v", err)
+	}
+	if _, err := svc.CreateBoard(1, service.BookmarkBoardInput{Name: "lighting refs"}); err == nil {
+		t.Error("duplicate board names should be rejected")
+	}
+
+	for _, id := range workIDs {
+		if _, err := svc.Save(1, model.BookmarkWork, id, service.SaveBookmarkInput{}); err != nil {
+			t.Fatalf("Save failed: %v", err)
+		}
+	}
+	if _, err := svc.Save(1, model.BookmarkWork, 999, service.SaveBookmarkInput{}); err == nil {
+		t.Error("saving a missing work should fail")
+	}
+
+	// Saving again files the item under a board instead of duplicating it
+	if _, err := svc.Save(1, model.BookmarkWork, workIDs[0], service.SaveBookmarkInput{BoardID: &board.ID}); err != nil {
+		t.Fatalf("move to board failed: %v", err)
+	}
+	if len(repo.bookmarks) != 3 {
+		t.Errorf("bookmarks = %d, want 3", len(repo.bookmarks))
+	}
+
+	page, err := svc.List(1, service.BookmarkQuery{Limit: 2})
+	if err != nil {
+		t.Fatalf("List failed: %v", err)
+	}
+	if len(page.Items) != 2 || page.NextBefore == 0 || page.Items[0].Work.ID != workIDs[2] {
+		t.Fatalf("first page = %+v, want newest two with a cursor", page)
+	}
+	page, _ = svc.List(1, service.BookmarkQuery{Limit: 2, BeforeID: page.NextBefore})
+	if len(page.Items) != 1 || page.NextBefore != 0 {
+		t.Errorf("second page = %d items, next %d; want 1 and no cursor", len(page.Items), page.NextBefore)
+	}
+
+	page, _ = svc.List(1, service.BookmarkQuery{BoardID: &board.ID})
+	if len(page.Items) != 1 || page.Items[0].TargetID != workIDs[0] {
+		t.Errorf("board items = %+v, want only the moved work", page.Items)
+	}
+
+	// Boards are private
+	if _, err := svc.List(2, service.BookmarkQuery{BoardID: &board.ID}); err == nil {
+		t.Error("other users should not list someone else's board")
+	}
+	if _, err := svc.Save(2, model.BookmarkWork, workIDs[0], service.SaveBookmarkInput{BoardID: &board.ID}); err == nil {
+		t.Error("other users should not save to someone else's board")
+	}
+
+	if err := svc.DeleteBoard(1, board.ID); err != nil {
+		t.Fatalf("DeleteBoard failed: %v", err)
+	}
+	if page, _ := svc.List(1, service.BookmarkQuery{}); len(page.Items) != 3 {
+		t.Errorf("items after deleting board = %d, want 3", len(page.Items))
+	}
+
+	if err := svc.Unsave(1, model.BookmarkWork, workIDs[1]); err != nil {
+		t.Fatalf("Unsave failed: %v", err)
+	}
+	if err := svc.Unsave(1, model.BookmarkWork, workIDs[1]); err == nil {
+		t.Error("unsaving twice should fail")
+	}
+}
+
+func TestBookmark_PrivateActivities(t *testing.T) {
+	works := newMockWorkRepo()
+	activities := newMockActivityRepo()
+	repo := newMockBookmarkRepo(works, activities)
+	svc := service.NewBookmarkService(repo, works, activities)
+
+	public := &model.Activity{HostID: 1, Title: "Open shoot", Status: "open", Visibility: "public"}
+	private := &model.Activity{HostID: 1, Title: "Closed shoot", Status: "open", Visibility: "private"}
+	_ = activities.Create(public)
+	_ = activities.Create(private)
+
+	if _, err := svc.Save(2, model.BookmarkActivity, public.ID, service.SaveBookmarkInput{}); err != nil {
+		t.Fatalf("Save public activity failed: %v", err)
+	}
+	if _, err := svc.Save(2, model.BookmarkActivity, private.ID, service.SaveBookmarkInput{}); err == nil {
+		t.Error("outsiders should not save a private activity")
+	}
+	if _, err := svc.Save(1, model.BookmarkActivity, private.ID, service.SaveBookmarkInput{}); err != nil {
+		t.Fatalf("host save failed: %v", err)
+	}
+
+	// An activity that turns private drops out of an outsider's list
+	public.Visibility = "private"
+	page, err := svc.List(2, service.BookmarkQuery{Type: model.BookmarkActivity})
+	if err != nil {
+		t.Fatalf("List failed: %v", err)
+	}
+	if len(page.Items) != 0 {
+		t.Errorf("items = %d, want 0 once the activity is private", len(page.Items))
+	}
+	if _, err := svc.List(2, service.BookmarkQuery{Type: "comment"}); err == nil {
+		t.Error("unknown type should be rejected")
+	}
+}
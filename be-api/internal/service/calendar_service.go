@@ -0,0 +1,168 @@
+package service
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"azure-magnetar/internal/model"
+	"azure-magnetar/internal/repository"
+	"azure-magnetar/pkg/apperror"
+	"azure-magnetar/pkg/ical"
+	"azure-magnetar/pkg/utils"
+)
+
+// CalendarService renders activities as iCalendar documents for export and
+// calendar app subscriptions.
+type CalendarService interface {
+	ActivityCalendar(activityID, viewerID uint) ([]byte, error)
+	GetSubscription(userID uint) (*CalendarSubscription, error)
+	ResetSubscription(userID uint) (*CalendarSubscription, error)
+	UserCalendar(token string) ([]byte, error)
+}
+
+// CalendarSubscription is the secret feed URL a user adds to their calendar app.
+type CalendarSubscription struct {
+	URL       string `json:"url"`
+	WebcalURL string `json:"webcalUrl"`
+}
+
+// calendarName is the feed's name in calendar apps, the product name used in
+// notification emails.
+const calendarName = "拍揪"
+
+// defaultEventDuration is used for DTEND since activities only store a start time.
+const defaultEventDuration = 2 * time.Hour
+
+type calendarService struct {
+	activityRepo repository.ActivityRepository
+	userRepo     repository.UserRepository
+	apiBaseURL   string
+	frontendURL  string
+}
+
+// NewCalendarService creates a new CalendarService.
+func NewCalendarService(activityRepo repository.ActivityRepository, userRepo repository.UserRepository, apiBaseURL, frontendURL string) CalendarService {
+	return &calendarService{
+		activityRepo: activityRepo,
+		userRepo:     userRepo,
+		apiBaseURL:   apiBaseURL,
+		frontendURL:  frontendURL,
+	}
+}
+
+func (s *calendarService) ActivityCalendar(activityID, viewerID uint) ([]byte, error) {
+	activity, err := s.activityRepo.GetByID(activityID)
+	if err != nil || !canViewActivity(s.activityRepo, activity, viewerID) {
+		return nil, apperror.New(apperror.CodeNotFound, "activity not found")
+	}
+	if activity.EventTime.IsZero() {
+		return nil, apperror.New(apperror.CodeValidation, "activity has no event time yet")
+	}
+
+	return ical.Marshal(ical.Calendar{
+		Name:   activity.Title,
+		Events: []ical.Event{s.toEvent(activity)},
+	}), nil
+}
+
+func (s *calendarService) GetSubscription(userID uint) (*CalendarSubscription, error) {
+	user, err := s.userRepo.GetByID(userID)
+	if err != nil {
+		return nil, apperror.New(apperror.CodeNotFound, "user not found")
+	}
+	if user.CalendarToken == "" {
+		if err := s.rotateToken(user); err != nil {
+			return nil, err
+		}
+	}
+	return s.subscription(user.CalendarToken), nil
+}
+
+// ResetSubscription issues a new feed token, invalidating the old URL.
+func (s *calendarService) ResetSubscription(userID uint) (*CalendarSubscription, error) {
+	user, err := s.userRepo.GetByID(userID)
+	if err != nil {
+		return nil, apperror.New(apperror.CodeNotFound, "user not found")
+	}
+	if err := s.rotateToken(user); err != nil {
+		return nil, err
+	}
+	return s.subscription(user.CalendarToken), nil
+}
+
+// UserCalendar renders the feed of activities the token's owner hosts or has
+// been accepted to. Cancelled activities stay in the feed with STATUS:CANCELLED
+// so subscribed calendars remove them.
+func (s *calendarService) UserCalendar(token string) ([]byte, error) {
+	if token == "" {
+		return nil, apperror.New(apperror.CodeNotFound, "calendar not found")
+	}
+	user, err := s.userRepo.GetByCalendarToken(token)
+	if err != nil {
+		return nil, apperror.New(apperror.CodeNotFound, "calendar not found")
+	}
+
+	activities, err := s.activityRepo.GetByUserID(user.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	events := make([]ical.Event, 0, len(activities))
+	for i := range activities {
+		if activities[i].EventTime.IsZero() {
+			continue
+		}
+		events = append(events, s.toEvent(&activities[i]))
+	}
+
+	return ical.Marshal(ical.Calendar{Name: calendarName, Events: events}), nil
+}
+
+func (s *calendarService) rotateToken(user *model.User) error {
+	token, err := utils.GenerateSecureToken(32)
+	if err != nil {
+		return err
+	}
+	user.CalendarToken = token
+	if err := s.userRepo.UpdateUser(user); err != nil {
+		return fmt.Errorf("failed to save calendar token: %w", err)
+	}
+	return nil
+}
+
+func (s *calendarService) subscription(token string) *CalendarSubscription {
+	feedURL := fmt.Sprintf("%s/api/v1/users/me/calendar.ics?token=%s", s.apiBaseURL, token)
+	webcal := feedURL
+	if i := strings.Index(webcal, "://"); i >= 0 {
+		webcal = "webcal" + webcal[i:]
+	}
+	return &CalendarSubscription{URL: feedURL, WebcalURL: webcal}
+}
+
+func (s *calendarService) toEvent(activity *model.Activity) ical.Event {
+	link := fmt.Sprintf("%s/activities/%d", s.frontendURL, activity.ID)
+
+	status := ical.StatusConfirmed
+	if activity.Status == "cancelled" {
+		status = ical.StatusCancelled
+	}
+
+	description := link
+	if activity.Description != "" {
+		description = activity.Description + "\n\n" + link
+	}
+
+	return ical.Event{
+		UID:          fmt.Sprintf("activity-%d@azure-magnetar", activity.ID),
+		Sequence:     activity.Sequence,
+		Start:        activity.EventTime,
+		End:          activity.EventTime.Add(defaultEventDuration),
+		Summary:      activity.Title,
+		Description:  description,
+		Location:     activity.Location,
+		URL:          link,
+		Status:       status,
+		LastModified: activity.UpdatedAt,
+	}
+}
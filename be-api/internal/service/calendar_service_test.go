@@ -0,0 +1,93 @@
+package service_test
+
+import (
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"azure-magnetar/internal/model"
+	"azure-magnetar/internal/service"
+)
+
+func TestCalendar_SubscriptionFeed(t *testing.T) {
+	activityRepo := newMockActivityRepo()
+	userRepo := newMockUserRepo()
+	user := &model.User{UserName: "host", Email: "host@example.com"}
+	_ = userRepo.Create(user)
+
+	activitySvc := service.NewActivityService(activityRepo, newMockCommentRepo(), newMockRatingRepo(), newMockUserRepo(), newMockChatRepo(activityRepo), newMockFollowRepo(), newMockWorkRepo(), "http://localhost:8080", "", newMockNotificationService(), "http://localhost:3000", testLinkSecret)
+	calendarSvc := service.NewCalendarService(activityRepo, userRepo, "http://localhost:8080", "http://localhost:3000")
+
+	activity, _ := activitySvc.Create(user.ID, service.CreateActivityInput{
+		Title:     "Rooftop Shoot",
+		EventTime: time.Now().Add(72 * time.Hour).UTC().Format(time.RFC3339),
+	})
+
+	sub, err := calendarSvc.GetSubscription(user.ID)
+	if err != nil {
+		t.Fatalf("GetSubscription failed: %v", err)
+	}
+	if !strings.HasPrefix(sub.WebcalURL, "webcal://") {
+		t.Errorf("WebcalURL = %q, want webcal scheme", sub.WebcalURL)
+	}
+	again, _ := calendarSvc.GetSubscription(user.ID)
+	if again.URL != sub.URL {
+		t.Error("GetSubscription should keep the existing token")
+	}
+
+	parsed, _ := url.Parse(sub.URL)
+	token := parsed.Query().Get("token")
+
+	feed, err := calendarSvc.UserCalendar(token)
+	if err != nil {
+		t.Fatalf("UserCalendar failed: %v", err)
+	}
+	if !strings.Contains(string(feed), "SUMMARY:Rooftop Shoot") || !strings.Contains(string(feed), "SEQUENCE:0") {
+		t.Errorf("feed missing the hosted activity:\n%s", feed)
+	}
+	if !strings.Contains(string(feed), "X-WR-CALNAME:拍揪\r\n") {
+		t.Errorf("feed should be named after the product:\n%s", feed)
+	}
+
+	// Cancelling bumps SEQUENCE and marks the event cancelled
+	if err := activitySvc.Cancel(user.ID, activity.ID, ""); err != nil {
+		t.Fatalf("Cancel failed: %v", err)
+	}
+	feed, _ = calendarSvc.UserCalendar(token)
+	if !strings.Contains(string(feed), "STATUS:CANCELLED") || !strings.Contains(string(feed), "SEQUENCE:1") {
+		t.Errorf("feed should mark the cancellation:\n%s", feed)
+	}
+
+	reset, _ := calendarSvc.ResetSubscription(user.ID)
+	if reset.URL == sub.URL {
+		t.Error("ResetSubscription should issue a new URL")
+	}
+	if _, err := calendarSvc.UserCalendar(token); err == nil {
+		t.Error("old token should stop working after reset")
+	}
+}
+
+func TestCalendar_ActivityExportRespectsVisibility(t *testing.T) {
+	activityRepo := newMockActivityRepo()
+	activitySvc := service.NewActivityService(activityRepo, newMockCommentRepo(), newMockRatingRepo(), newMockUserRepo(), newMockChatRepo(activityRepo), newMockFollowRepo(), newMockWorkRepo(), "http://localhost:8080", "", newMockNotificationService(), "http://localhost:3000", testLinkSecret)
+	calendarSvc := service.NewCalendarService(activityRepo, newMockUserRepo(), "http://localhost:8080", "http://localhost:3000")
+
+	eventTime := time.Date(2030, 5, 1, 11, 0, 0, 0, time.UTC)
+	private, _ := activitySvc.Create(1, service.CreateActivityInput{
+		Title:      "Closed Shoot",
+		EventTime:  eventTime.Format(time.RFC3339),
+		Visibility: "private",
+	})
+
+	if _, err := calendarSvc.ActivityCalendar(private.ID, 2); err == nil {
+		t.Error("outsiders should not export a private activity")
+	}
+	body, err := calendarSvc.ActivityCalendar(private.ID, 1)
+	if err != nil {
+		t.Fatalf("ActivityCalendar failed: %v", err)
+	}
+	if !strings.Contains(string(body), "DTSTART:20300501T110000Z") {
+		t.Errorf("export missing UTC start time:\n%s", body)
+	}
+}
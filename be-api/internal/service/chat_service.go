@@ -0,0 +1,252 @@
+package service
+
+import (
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"azure-magnetar/internal/model"
+	"azure-magnetar/internal/repository"
+	"azure-magnetar/pkg/apperror"
+	"azure-magnetar/pkg/realtime"
+	"azure-magnetar/pkg/storage"
+)
+
+const (
+	// defaultChatPageSize and maxChatPageSize bound a page of chat messages.
+	defaultChatPageSize = 30
+	maxChatPageSize     = 100
+	// maxChatMessageLength caps the text of a single message.
+	maxChatMessageLength = 2000
+	// maxChatImages caps the image attachments of a single message.
+	maxChatImages = 4
+)
+
+// ChatService defines the interface for activity group chats.
+type ChatService interface {
+	ListRooms(userID uint) ([]ChatRoomSummary, error)
+	ListMessages(activityID, userID, beforeID uint, limit int) (*ChatMessagePage, error)
+	SendMessage(activityID, userID uint, input SendChatMessageInput) (*model.ChatMessage, error)
+	MarkRead(activityID, userID, messageID uint) error
+
+	// Realtime
+	Subscribe(activityID, userID uint) (<-chan any, func(), error)
+	IsMember(activityID, userID uint) bool
+}
+
+// SendChatMessageInput is a new chat message. Images are base64-encoded.
+type SendChatMessageInput struct {
+	Body   string   `json:"body"`
+	Images []string `json:"images"`
+}
+
+// MarkChatReadInput marks a room read up to a message.
+type MarkChatReadInput struct {
+	MessageID uint `json:"messageId"` // Optional; defaults to the newest message
+}
+
+// ChatRoomSummary is one entry of a user's chat list.
+type ChatRoomSummary struct {
+	RoomID        uint       `json:"roomId"`
+	ActivityID    uint       `json:"activityId"`
+	ActivityTitle string     `json:"activityTitle"`
+	LastMessageAt *time.Time `json:"lastMessageAt,omitempty"`
+	UnreadCount   int64      `json:"unreadCount"`
+}
+
+// ChatMessagePage is a page of messages, newest first. Pass NextBefore as
+// ?before= to load older messages; it is 0 when there are none.
+type ChatMessagePage struct {
+	Messages    []model.ChatMessage `json:"messages"`
+	NextBefore  uint                `json:"nextBefore"`
+	UnreadCount int64               `json:"unreadCount"`
+}
+
+type chatService struct {
+	repo         repository.ChatRepository
+	activityRepo repository.ActivityRepository
+	hub          *realtime.Hub
+	apiBaseURL   string
+	gcsBucket    string
+}
+
+// NewChatService creates a new ChatService. New messages are published to hub
+// for realtime delivery.
+func NewChatService(repo repository.ChatRepository, activityRepo repository.ActivityRepository, hub *realtime.Hub, apiBaseURL, gcsBucket string) ChatService {
+	return &chatService{
+		repo:         repo,
+		activityRepo: activityRepo,
+		hub:          hub,
+		apiBaseURL:   apiBaseURL,
+		gcsBucket:    gcsBucket,
+	}
+}
+
+func (s *chatService) ListRooms(userID uint) ([]ChatRoomSummary, error) {
+	rooms, err := s.repo.ListRooms(userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list chat rooms: %w", err)
+	}
+
+	roomIDs := make([]uint, len(rooms))
+	for i, r := range rooms {
+		roomIDs[i] = r.ID
+	}
+	unread, err := s.repo.CountUnread(userID, roomIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count unread messages: %w", err)
+	}
+
+	summaries := make([]ChatRoomSummary, 0, len(rooms))
+	for _, r := range rooms {
+		summaries = append(summaries, ChatRoomSummary{
+			RoomID:        r.ID,
+			ActivityID:    r.ActivityID,
+			ActivityTitle: r.Activity.Title,
+			LastMessageAt: r.LastMessageAt,
+			UnreadCount:   unread[r.ID],
+		})
+	}
+	return summaries, nil
+}
+
+func (s *chatService) ListMessages(activityID, userID, beforeID uint, limit int) (*ChatMessagePage, error) {
+	room, err := s.memberRoom(activityID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if limit <= 0 {
+		limit = defaultChatPageSize
+	}
+	if limit > maxChatPageSize {
+		limit = maxChatPageSize
+	}
+
+	// Fetch one extra to learn whether older messages remain
+	messages, err := s.repo.ListMessages(room.ID, beforeID, limit+1)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list messages: %w", err)
+	}
+	page := &ChatMessagePage{Messages: messages}
+	if len(messages) > limit {
+		page.Messages = messages[:limit]
+		page.NextBefore = page.Messages[limit-1].ID
+	}
+
+	unread, err := s.repo.CountUnread(userID, []uint{room.ID})
+	if err != nil {
+		return nil, fmt.Errorf("failed to count unread messages: %w", err)
+	}
+	page.UnreadCount = unread[room.ID]
+	return page, nil
+}
+
+func (s *chatService) SendMessage(activityID, userID uint, input SendChatMessageInput) (*model.ChatMessage, error) {
+	room, err := s.memberRoom(activityID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	body := strings.TrimSpace(input.Body)
+	if body == "" && len(input.Images) == 0 {
+		return nil, apperror.New(apperror.CodeValidation, "message needs text or an image")
+	}
+	if utf8.RuneCountInString(body) > maxChatMessageLength {
+		return nil, apperror.Newf(apperror.CodeValidation, "message must be at most %d characters", maxChatMessageLength)
+	}
+	if len(input.Images) > maxChatImages {
+		return nil, apperror.Newf(apperror.CodeValidation, "at most %d images per message", maxChatImages)
+	}
+
+	var imageURLs []string
+	for i, imgBase64 := range input.Images {
+		url, err := storage.SaveBase64Image(s.apiBaseURL, s.gcsBucket, "chat", userID, imgBase64, i)
+		if err != nil {
+			return nil, fmt.Errorf("failed to save image %d: %w", i, err)
+		}
+		imageURLs = append(imageURLs, url)
+	}
+
+	msg := &model.ChatMessage{
+		RoomID:    room.ID,
+		SenderID:  userID,
+		Body:      body,
+		Images:    imageURLs,
+		CreatedAt: time.Now(),
+	}
+	if err := s.repo.CreateMessage(msg); err != nil {
+		return nil, fmt.Errorf("failed to send message: %w", err)
+	}
+
+	// The sender has read their own message
+	_ = s.repo.MarkRead(room.ID, userID, msg.ID)
+	s.hub.Publish(chatTopic(room.ID), msg)
+
+	return msg, nil
+}
+
+func (s *chatService) MarkRead(activityID, userID, messageID uint) error {
+	room, err := s.memberRoom(activityID, userID)
+	if err != nil {
+		return err
+	}
+
+	if messageID == 0 {
+		latest, err := s.repo.ListMessages(room.ID, 0, 1)
+		if err != nil {
+			return fmt.Errorf("failed to load latest message: %w", err)
+		}
+		if len(latest) == 0 {
+			return nil
+		}
+		messageID = latest[0].ID
+	}
+
+	if err := s.repo.MarkRead(room.ID, userID, messageID); err != nil {
+		return fmt.Errorf("failed to mark chat read: %w", err)
+	}
+	return nil
+}
+
+// Subscribe returns a channel of new messages in the activity's room. The
+// caller must call the returned cancel function when done.
+func (s *chatService) Subscribe(activityID, userID uint) (<-chan any, func(), error) {
+	room, err := s.memberRoom(activityID, userID)
+	if err != nil {
+		return nil, nil, err
+	}
+	events, cancel := s.hub.Subscribe(chatTopic(room.ID))
+	return events, cancel, nil
+}
+
+// IsMember reports whether userID may currently use the activity's chat. Open
+// streams re-check it so people who leave lose access.
+func (s *chatService) IsMember(activityID, userID uint) bool {
+	_, err := s.memberRoom(activityID, userID)
+	return err == nil
+}
+
+// memberRoom returns the activity's chat room if userID is a member.
+func (s *chatService) memberRoom(activityID, userID uint) (*model.ChatRoom, error) {
+	activity, err := s.activityRepo.GetByID(activityID)
+	if err != nil {
+		return nil, apperror.New(apperror.CodeNotFound, "activity not found")
+	}
+	// Membership follows the participant status, so rejected or cancelled
+	// participants drop out automatically
+	if !isActivityCrew(s.activityRepo, activity, userID) {
+		return nil, apperror.New(apperror.CodeForbidden, "chat is only open to the host and accepted participants")
+	}
+
+	room, err := s.repo.GetRoom(activityID)
+	if err != nil {
+		return nil, apperror.New(apperror.CodeNotFound, "chat opens once the first participant is accepted")
+	}
+	return room, nil
+}
+
+func chatTopic(roomID uint) string {
+	return fmt.Sprintf("chat:%d", roomID)
+}
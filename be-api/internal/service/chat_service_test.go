@@ -0,0 +1,216 @@
+package service_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"azure-magnetar/internal/model"
+	"azure-magnetar/internal/service"
+	"azure-magnetar/pkg/realtime"
+)
+
+// --- Mock Chat Repository ---
+
+type mockChatRepo struct {
+	rooms      []*model.ChatRoom
+	messages   []*model.ChatMessage
+	reads      map[string]uint // key: "roomID-userID"
+	activities *mockActivityRepo
+}
+
+func newMockChatRepo(activities *mockActivityRepo) *mockChatRepo {
+	return &mockChatRepo{
+		reads:      make(map[string]uint),
+		activities: activities,
+	}
+}
+
+func (r *mockChatRepo) EnsureRoom(activityID uint) (*model.ChatRoom, error) {
+	if room, err := r.GetRoom(activityID); err == nil {
+		return room, nil
+	}
+	room := &model.ChatRoom{ID: uint(len(r.rooms) + 1), ActivityID: activityID}
+	r.rooms = append(r.rooms, room)
+	return room, nil
+}
+
+func (r *mockChatRepo) GetRoom(activityID uint) (*model.ChatRoom, error) {
+	for _, room := range r.rooms {
+		if room.ActivityID == activityID {
+			return room, nil
+		}
+	}
+	return nil, errors.New("not found")
+}
+
+func (r *mockChatRepo) ListRooms(userID uint) ([]model.ChatRoom, error) {
+	var result []model.ChatRoom
+	for _, room := range r.rooms {
+		a := r.activities.activities[room.ActivityID]
+		p, err := r.activities.GetParticipant(room.ActivityID, userID)
+		if a.HostID == userID || (err == nil && p.Status == "accepted") {
+			c := *room
+			c.Activity = *a
+			result = append(result, c)
+		}
+	}
+	return result, nil
+}
+
+func (r *mockChatRepo) CreateMessage(msg *model.ChatMessage) error {
+	msg.ID = uint(len(r.messages) + 1)
+	r.messages = append(r.messages, msg)
+	return nil
+}
+
+func (r *mockChatRepo) ListMessages(roomID, beforeID uint, limit int) ([]model.ChatMessage, error) {
+	var result []model.ChatMessage
+	for i := len(r.messages) - 1; i >= 0 && len(result) < limit; i-- {
+		m := r.messages[i]
+		if m.RoomID == roomID && (beforeID == 0 || m.ID < beforeID) {
+			result = append(result, *m)
+		}
+	}
+	return result, nil
+}
+
+func (r *mockChatRepo) MarkRead(roomID, userID, messageID uint) error {
+	key := fmt.Sprintf("%d-%d", roomID, userID)
+	if messageID > r.reads[key] {
+		r.reads[key] = messageID
+	}
+	return nil
+}
+
+func (r *mockChatRepo) CountUnread(userID uint, roomIDs []uint) (map[uint]int64, error) {
+	result := make(map[uint]int64)
+	for _, roomID := range roomIDs {
+		lastRead := r.reads[fmt.Sprintf("%d-%d", roomID, userID)]
+		for _, m := range r.messages {
+			if m.RoomID == roomID && m.SenderID != userID && m.ID > lastRead {
+				result[roomID]++
+			}
+		}
+	}
+	return result, nil
+}
+
+func setupChatTest() (service.ChatService, service.ActivityService) {
+	activityRepo := newMockActivityRepo()
+	chatRepo := newMockChatRepo(activityRepo)
+	activitySvc := service.NewActivityService(activityRepo, newMockCommentRepo(), newMockRatingRepo(), newMockUserRepo(), chatRepo, newMockFollowRepo(), newMockWorkRepo(), "http://localhost:8080", "", newMockNotificationService(), "http://localhost:3000", testLinkSecret)
+	chatSvc := service.NewChatService(chatRepo, activityRepo, realtime.NewHub(), "http://localhost:8080", "")
+	return chatSvc, activitySvc
+}
+
+func TestChat_RoomOpensOnFirstAcceptance(t *testing.T) {
+	chatSvc, activitySvc := setupChatTest()
+	activity, _ := activitySvc.Create(1, service.CreateActivityInput{Title: "Night Market Walk", MaxParticipants: 5})
+	_ = activitySvc.Apply(activity.ID, 2, service.ApplyInput{})
+	_ = activitySvc.Apply(activity.ID, 3, service.ApplyInput{})
+
+	if _, err := chatSvc.ListMessages(activity.ID, 1, 0, 0); err == nil {
+		t.Fatal("chat should not exist before anyone is accepted")
+	}
+
+	if err := activitySvc.UpdateApplicantStatus(activity.ID, 1, 2, "accepted"); err != nil {
+		t.Fatalf("UpdateApplicantStatus failed: %v", err)
+	}
+	if _, err := chatSvc.SendMessage(activity.ID, 1, service.SendChatMessageInput{Body: "Welcome!"}); err != nil {
+		t.Fatalf("host SendMessage failed: %v", err)
+	}
+	if _, err := chatSvc.SendMessage(activity.ID, 2, service.SendChatMessageInput{Body: "Thanks"}); err != nil {
+		t.Fatalf("participant SendMessage failed: %v", err)
+	}
+
+	// Pending applicants and strangers are not members
+	for _, userID := range []uint{0, 3, 9} {
+		if _, err := chatSvc.ListMessages(activity.ID, userID, 0, 0); err == nil {
+			t.Errorf("user %d should not read the chat", userID)
+		}
+	}
+
+	// Membership follows the participant status
+	if err := activitySvc.UpdateApplicantStatus(activity.ID, 1, 2, "rejected"); err != nil {
+		t.Fatalf("UpdateApplicantStatus failed: %v", err)
+	}
+	if _, err := chatSvc.SendMessage(activity.ID, 2, service.SendChatMessageInput{Body: "Still here?"}); err == nil {
+		t.Error("rejected participants should lose chat access")
+	}
+	if chatSvc.IsMember(activity.ID, 2) {
+		t.Error("IsMember should be false after rejection")
+	}
+}
+
+func TestChat_PaginationAndUnreadCounts(t *testing.T) {
+	chatSvc, activitySvc := setupChatTest()
+	activity, _ := activitySvc.Create(1, service.CreateActivityInput{Title: "Night Market Walk", MaxParticipants: 5})
+	_ = activitySvc.Apply(activity.ID, 2, service.ApplyInput{})
+	_ = activitySvc.UpdateApplicantStatus(activity.ID, 1, 2, "accepted")
+
+	if _, err := chatSvc.SendMessage(activity.ID, 2, service.SendChatMessageInput{Body: "  "}); err == nil {
+		t.Fatal("expected error for an empty message")
+	}
+	for _, body := range []string{"one", "two", "three"} {
+		if _, err := chatSvc.SendMessage(activity.ID, 2, service.SendChatMessageInput{Body: body}); err != nil {
+			t.Fatalf("SendMessage failed: %v", err)
+		}
+	}
+
+	page, err := chatSvc.ListMessages(activity.ID, 1, 0, 2)
+	if err != nil {
+		t.Fatalf("ListMessages failed: %v", err)
+	}
+	if len(page.Messages) != 2 || page.Messages[0].Body != "three" || page.NextBefore == 0 {
+		t.Fatalf("first page = %+v, want two newest with a cursor", page)
+	}
+	if page.UnreadCount != 3 {
+		t.Errorf("UnreadCount = %d, want 3", page.UnreadCount)
+	}
+	older, _ := chatSvc.ListMessages(activity.ID, 1, page.NextBefore, 2)
+	if len(older.Messages) != 1 || older.Messages[0].Body != "one" || older.NextBefore != 0 {
+		t.Errorf("second page = %+v, want the oldest message and no cursor", older)
+	}
+
+	// The sender has nothing unread; the host has three until marking read
+	rooms, _ := chatSvc.ListRooms(2)
+	if len(rooms) != 1 || rooms[0].UnreadCount != 0 {
+		t.Errorf("sender rooms = %+v, want one room with nothing unread", rooms)
+	}
+	if err := chatSvc.MarkRead(activity.ID, 1, 0); err != nil {
+		t.Fatalf("MarkRead failed: %v", err)
+	}
+	rooms, _ = chatSvc.ListRooms(1)
+	if len(rooms) != 1 || rooms[0].UnreadCount != 0 || rooms[0].ActivityTitle != "Night Market Walk" {
+		t.Errorf("host rooms = %+v, want one read room", rooms)
+	}
+}
+
+func TestChat_SubscribeDeliversNewMessages(t *testing.T) {
+	chatSvc, activitySvc := setupChatTest()
+	activity, _ := activitySvc.Create(1, service.CreateActivityInput{Title: "Night Market Walk", MaxParticipants: 5})
+	_ = activitySvc.Apply(activity.ID, 2, service.ApplyInput{})
+	_ = activitySvc.UpdateApplicantStatus(activity.ID, 1, 2, "accepted")
+
+	if _, _, err := chatSvc.Subscribe(activity.ID, 3); err == nil {
+		t.Fatal("non-members should not subscribe")
+	}
+	events, cancel, err := chatSvc.Subscribe(activity.ID, 1)
+	if err != nil {
+		t.Fatalf("Subscribe failed: %v", err)
+	}
+	defer cancel()
+
+	sent, _ := chatSvc.SendMessage(activity.ID, 2, service.SendChatMessageInput{Body: "On my way"})
+	select {
+	case event := <-events:
+		msg, ok := event.(*model.ChatMessage)
+		if !ok || msg.ID != sent.ID {
+			t.Errorf("event = %#v, want the sent message", event)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("no realtime event received")
+	}
+}
@@ -1,7 +1,6 @@
 package service
 
 import (
-	"errors"
 	"fmt"
 	"strconv"
 
@@ -15,8 +14,8 @@ import (
 type CommentService interface {
 	CreateForActivity(activityID, userID uint, content string) (*model.Comment, error)
 	CreateForWork(workID, userID uint, content string) (*model.Comment, error)
-	GetByActivityID(activityID uint) ([]model.Comment, error)
-	GetByWorkID(workID uint) ([]model.Comment, error)
+	GetByActivityID(activityID, viewerID uint) ([]model.Comment, error)
+	GetByWorkID(workID, viewerID uint) ([]model.Comment, error)
 	Delete(commentID, userID uint) error
 }
 
@@ -55,6 +54,11 @@ func (s *commentService) CreateForActivity(activityID, userID uint, content stri
 		return nil, apperror.New(apperror.CodeValidation, "comment content is required")
 	}
 
+	activity, err := s.activityRepo.GetByID(activityID)
+	if err != nil || !canViewActivity(s.activityRepo, activity, userID) {
+		return nil, apperror.New(apperror.CodeNotFound, "activity not found")
+	}
+
 	comment := &model.Comment{
 		ActivityID: &activityID,
 		UserID:     userID,
@@ -65,38 +69,41 @@ func (s *commentService) CreateForActivity(activityID, userID uint, content stri
 		return nil, fmt.Errorf("failed to create comment: %w", err)
 	}
 
-	// Fetch activity to notify the host
-	activity, err := s.activityRepo.GetByID(activityID)
-	if err == nil {
-		notifyUserIDs := make(map[uint]bool)
-
-		// 1. Notify the host (if not the commenter)
-		if activity.HostID != userID {
-			notifyUserIDs[activity.HostID] = true
-		}
+	notifyUserIDs := make(map[uint]bool)
 
-		// 2. Notify all accepted participants.
-		// ListParticipants already filters by status='accepted'; no need to re-check here.
-		participants, err := s.activityRepo.ListParticipants(activityID)
-		if err == nil {
-			for _, p := range participants {
-				if p.UserID != userID {
-					notifyUserIDs[p.UserID] = true
-				}
+	// 1. Notify the host and co-hosts (if not the commenter)
+	if activity.HostID != userID {
+		notifyUserIDs[activity.HostID] = true
+	}
+	if coHosts, err := s.activityRepo.ListCoHosts(activityID); err == nil {
+		for _, c := range coHosts {
+			if c.UserID != userID {
+				notifyUserIDs[c.UserID] = true
 			}
-		} else {
-			logger.Warn("failed to fetch participants for notification", "activityID", activityID, "error", err)
 		}
+	} else {
+		logger.Warn("failed to fetch co-hosts for notification", "activityID", activityID, "error", err)
+	}
 
-		// Send notifications
-		activityIDStr := strconv.FormatUint(uint64(activityID), 10)
-		for toUserID := range notifyUserIDs {
-			if notifErr := s.notifService.SendNotification(toUserID, userID, "activity_comment", activityIDStr, "有人在您參與的活動中留言了！"); notifErr != nil {
-				logger.Warn("failed to send activity comment notification", "toUserID", toUserID, "error", notifErr)
+	// 2. Notify all accepted participants.
+	// ListParticipants already filters by status='accepted'; no need to re-check here.
+	participants, err := s.activityRepo.ListParticipants(activityID)
+	if err == nil {
+		for _, p := range participants {
+			if p.UserID != userID {
+				notifyUserIDs[p.UserID] = true
 			}
 		}
 	} else {
-		logger.Warn("failed to fetch activity to send comment notification", "activityID", activityID, "error", err)
+		logger.Warn("failed to fetch participants for notification", "activityID", activityID, "error", err)
+	}
+
+	// Send notifications
+	activityIDStr := strconv.FormatUint(uint64(activityID), 10)
+	for toUserID := range notifyUserIDs {
+		if notifErr := s.notifService.SendNotification(toUserID, userID, "activity_comment", activityIDStr, "有人在您參與的活動中留言了！"); notifErr != nil {
+			logger.Warn("failed to send activity comment notification", "toUserID", toUserID, "error", notifErr)
+		}
 	}
 
 	return s.commentRepo.GetByID(comment.ID)
@@ -104,7 +111,12 @@ func (s *commentService) CreateForActivity(activityID, userID uint, content stri
 
 func (s *commentService) CreateForWork(workID, userID uint, content string) (*model.Comment, error) {
 	if content == "" {
-		return nil, errors.New("comment content is required")
+		return nil, apperror.New(apperror.CodeValidation, "comment content is required")
+	}
+
+	work, err := s.workRepo.GetByID(workID, 0)
+	if err != nil || !canViewWork(s.workRepo, work, userID) {
+		return nil, apperror.New(apperror.CodeNotFound, "work not found")
 	}
 
 	comment := &model.Comment{
@@ -123,8 +135,7 @@ func (s *commentService) CreateForWork(workID, userID uint, content string) (*mo
 	}
 
 	// Notify work author
-	work, err := s.workRepo.GetByID(workID, 0)
-	if err == nil && work.UserID != userID {
+	if work.UserID != userID {
 		workIDStr := fmt.Sprintf("%d", workID)
 		if notifErr := s.notifService.SendNotification(work.UserID, userID, "work_comment", workIDStr, "有人在您的作品留言了！"); notifErr != nil {
 			logger.Warn("failed to send comment notification", "error", notifErr)
@@ -134,7 +145,12 @@ func (s *commentService) CreateForWork(workID, userID uint, content string) (*mo
 	return s.commentRepo.GetByID(comment.ID)
 }
 
-func (s *commentService) GetByActivityID(activityID uint) ([]model.Comment, error) {
+func (s *commentService) GetByActivityID(activityID, viewerID uint) ([]model.Comment, error) {
+	activity, err := s.activityRepo.GetByID(activityID)
+	if err != nil || !canViewActivity(s.activityRepo, activity, viewerID) {
+		return nil, apperror.New(apperror.CodeNotFound, "activity not found")
+	}
+
 	comments, err := s.commentRepo.GetByActivityID(activityID)
 	if err != nil {
 		return nil, err
@@ -144,7 +160,12 @@ func (s *commentService) GetByActivityID(activityID uint) ([]model.Comment, erro
 	return comments, nil
 }
 
-func (s *commentService) GetByWorkID(workID uint) ([]model.Comment, error) {
+func (s *commentService) GetByWorkID(workID, viewerID uint) ([]model.Comment, error) {
+	work, err := s.workRepo.GetByID(workID, 0)
+	if err != nil || !canViewWork(s.workRepo, work, viewerID) {
+		return nil, apperror.New(apperror.CodeNotFound, "work not found")
+	}
+
 	comments, err := s.commentRepo.GetByWorkID(workID)
 	if err != nil {
 		return nil, err
@@ -0,0 +1,206 @@
+package service
+
+import (
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"azure-magnetar/internal/model"
+	"azure-magnetar/internal/repository"
+	"azure-magnetar/pkg/apperror"
+)
+
+// Credit limits.
+const (
+	maxCreditsPerWork   = 20
+	maxCreditRoleLength = 50
+)
+
+// CreditService defines the interface for collaborator credits on works.
+type CreditService interface {
+	ListForWork(workID, viewerID uint) ([]model.WorkCredit, error)
+	Add(workID, authorID uint, input AddCreditInput) (*model.WorkCredit, error)
+	Remove(workID, userID, creditedUserID uint) error
+	ListMine(userID uint, status string) ([]model.WorkCredit, error)
+	Respond(creditID, userID uint, input RespondCreditInput) (*model.WorkCredit, error)
+	GetCreditedWorks(userID uint) ([]model.Post, error)
+}
+
+// AddCreditInput tags a collaborator on a work. ActivityID optionally links the
+// shoot the work came from; both the author and the collaborator must have
+// taken part in it.
+type AddCreditInput struct {
+	UserID     uint   `json:"userId" binding:"required"`
+	Role       string `json:"role" binding:"required"`
+	ActivityID *uint  `json:"activityId"`
+}
+
+// RespondCreditInput approves or declines a pending credit.
+type RespondCreditInput struct {
+	Status string `json:"status" binding:"required"` // approved, declined
+}
+
+type creditService struct {
+	repo         repository.CreditRepository
+	workRepo     repository.WorkRepository
+	activityRepo repository.ActivityRepository
+	userRepo     repository.UserRepository
+	notifService NotificationService
+}
+
+// NewCreditService creates a new CreditService.
+func NewCreditService(repo repository.CreditRepository, workRepo repository.WorkRepository, activityRepo repository.ActivityRepository, userRepo repository.UserRepository, notifService NotificationService) CreditService {
+	return &creditService{
+		repo:         repo,
+		workRepo:     workRepo,
+		activityRepo: activityRepo,
+		userRepo:     userRepo,
+		notifService: notifService,
+	}
+}
+
+// ListForWork returns a work's approved credits. The author also sees pending
+// and declined ones.
+func (s *creditService) ListForWork(workID, viewerID uint) ([]model.WorkCredit, error) {
+	work, err := s.workRepo.GetByID(workID, 0)
+	if err != nil || !canViewWork(s.workRepo, work, viewerID) {
+		return nil, apperror.New(apperror.CodeNotFound, "work not found")
+	}
+
+	status := model.CreditApproved
+	if viewerID != 0 && viewerID == work.UserID {
+		status = ""
+	}
+	return s.repo.ListByWork(workID, status)
+}
+
+// Add credits a collaborator on the author's work and asks them to approve it.
+func (s *creditService) Add(workID, authorID uint, input AddCreditInput) (*model.WorkCredit, error) {
+	work, err := s.workRepo.GetByID(workID, 0)
+	if err != nil {
+		return nil, apperror.New(apperror.CodeNotFound, "work not found")
+	}
+	if work.UserID != authorID {
+		return nil, apperror.New(apperror.CodeForbidden, "only the author can credit collaborators")
+	}
+
+	role := strings.TrimSpace(input.Role)
+	if role == "" {
+		return nil, apperror.New(apperror.CodeValidation, "role is required")
+	}
+	if utf8.RuneCountInString(role) > maxCreditRoleLength {
+		return nil, apperror.Newf(apperror.CodeValidation, "role must be at most %d characters", maxCreditRoleLength)
+	}
+	if input.UserID == authorID {
+		return nil, apperror.New(apperror.CodeValidation, "you cannot credit yourself")
+	}
+	if _, err := s.userRepo.GetByID(input.UserID); err != nil {
+		return nil, apperror.New(apperror.CodeNotFound, "user not found")
+	}
+	if _, err := s.repo.GetByUser(workID, input.UserID); err == nil {
+		return nil, apperror.New(apperror.CodeConflict, "this user is already credited on the work")
+	}
+
+	count, err := s.repo.CountByWork(workID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count credits: %w", err)
+	}
+	if count >= maxCreditsPerWork {
+		return nil, apperror.Newf(apperror.CodeValidation, "a work can have at most %d credits", maxCreditsPerWork)
+	}
+
+	credit := &model.WorkCredit{
+		WorkID: workID,
+		UserID: input.UserID,
+		Role:   role,
+		Status: model.CreditPending,
+	}
+	if input.ActivityID != nil && *input.ActivityID != 0 {
+		activity, err := s.activityRepo.GetByID(*input.ActivityID)
+		if err != nil {
+			return nil, apperror.New(apperror.CodeNotFound, "activity not found")
+		}
+		if !isActivityCrew(s.activityRepo, activity, authorID) || !isActivityCrew(s.activityRepo, activity, input.UserID) {
+			return nil, apperror.New(apperror.CodeValidation, "both you and the collaborator must have taken part in the activity")
+		}
+		credit.ActivityID = input.ActivityID
+	}
+
+	if err := s.repo.Create(credit); err != nil {
+		return nil, fmt.Errorf("failed to add credit: %w", err)
+	}
+
+	_ = s.notifService.SendNotification(input.UserID, authorID, "credit_request", fmt.Sprintf("%d", workID), creditNotificationContent(work, role))
+	return credit, nil
+}
+
+// Remove deletes a credit. The author can remove any credit on their work and
+// a collaborator can remove their own.
+func (s *creditService) Remove(workID, userID, creditedUserID uint) error {
+	work, err := s.workRepo.GetByID(workID, 0)
+	if err != nil {
+		return apperror.New(apperror.CodeNotFound, "work not found")
+	}
+	if userID != work.UserID && userID != creditedUserID {
+		return apperror.New(apperror.CodeForbidden, "only the author or the credited user can remove a credit")
+	}
+
+	credit, err := s.repo.GetByUser(workID, creditedUserID)
+	if err != nil {
+		return apperror.New(apperror.CodeNotFound, "credit not found")
+	}
+	return s.repo.Delete(credit.ID)
+}
+
+// ListMine returns the credits the user was tagged in, optionally filtered by status.
+func (s *creditService) ListMine(userID uint, status string) ([]model.WorkCredit, error) {
+	switch status {
+	case "", model.CreditPending, model.CreditApproved, model.CreditDeclined:
+	default:
+		return nil, apperror.New(apperror.CodeValidation, "status must be 'pending', 'approved' or 'declined'")
+	}
+	return s.repo.ListByUser(userID, status)
+}
+
+// Respond lets the tagged user approve or decline a pending credit. The author
+// is notified when it is approved.
+func (s *creditService) Respond(creditID, userID uint, input RespondCreditInput) (*model.WorkCredit, error) {
+	if input.Status != model.CreditApproved && input.Status != model.CreditDeclined {
+		return nil, apperror.New(apperror.CodeValidation, "status must be 'approved' or 'declined'")
+	}
+
+	credit, err := s.repo.Get(creditID)
+	if err != nil || credit.UserID != userID {
+		return nil, apperror.New(apperror.CodeNotFound, "credit not found")
+	}
+	if credit.Status != model.CreditPending {
+		return nil, apperror.Newf(apperror.CodeConflict, "credit is already %s", credit.Status)
+	}
+
+	now := time.Now()
+	credit.Status = input.Status
+	credit.RespondedAt = &now
+	if err := s.repo.Update(credit); err != nil {
+		return nil, fmt.Errorf("failed to update credit: %w", err)
+	}
+
+	if credit.Status == model.CreditApproved && credit.Work != nil {
+		_ = s.notifService.SendNotification(credit.Work.UserID, userID, "credit_approved", fmt.Sprintf("%d", credit.WorkID), creditNotificationContent(credit.Work, credit.Role))
+	}
+	return credit, nil
+}
+
+// GetCreditedWorks returns works the user is credited on, for their profile.
+func (s *creditService) GetCreditedWorks(userID uint) ([]model.Post, error) {
+	return s.repo.ListCreditedWorks(userID)
+}
+
+// creditNotificationContent describes a credit as "<work title> (<role>)".
+func creditNotificationContent(work *model.Post, role string) string {
+	title := work.Title
+	if title == "" {
+		title = "Untitled work"
+	}
+	return fmt.Sprintf("%s (%s)", title, role)
+}
@@ -0,0 +1,200 @@
+package service_test
+
+import (
+	"testing"
+
+	"azure-magnetar/internal/model"
+	"azure-magnetar/internal/service"
+)
+
+// --- Mock Credit Repository ---
+
+type mockCreditRepo struct {
+	credits []*model.WorkCredit
+	works   *mockWorkRepo
+}
+
+func newMockCreditRepo(works *mockWorkRepo) *mockCreditRepo {
+	return &mockCreditRepo{works: works}
+}
+
+func (r *mockCreditRepo) Create(credit *model.WorkCredit) error {
+	credit.ID = uint(len(r.credits) + 1)
+	r.credits = append(r.credits, credit)
+	return nil
+}
+
+func (r *mockCreditRepo) Get(id uint) (*model.WorkCredit, error) {
+	for _, c := range r.credits {
+		if c.ID == id {
+			credit := *c
+			credit.Work = r.works.works[c.WorkID]
+			return &credit, nil
+		}
+	}
+	return nil, errNotFound
+}
+
+func (r *mockCreditRepo) GetByUser(workID, userID uint) (*model.WorkCredit, error) {
+	for _, c := range r.credits {
+		if c.WorkID == workID && c.UserID == userID {
+			return c, nil
+		}
+	}
+	return nil, errNotFound
+}
+
+func (r *mockCreditRepo) ListByWork(workID uint, status string) ([]model.WorkCredit, error) {
+	var result []model.WorkCredit
+	for _, c := range r.credits {
+		if c.WorkID == workID && (status == "" || c.Status == status) {
+			result = append(result, *c)
+		}
+	}
+	return result, nil
+}
+
+func (r *mockCreditRepo) CountByWork(workID uint) (int64, error) {
+	credits, _ := r.ListByWork(workID, "")
+	return int64(len(credits)), nil
+}
+
+func (r *mockCreditRepo) Update(credit *model.WorkCredit) error {
+	for _, c := range r.credits {
+		if c.ID == credit.ID {
+			c.Status = credit.Status
+			c.RespondedAt = credit.RespondedAt
+		}
+	}
+	return nil
+}
+
+func (r *mockCreditRepo) Delete(id uint) error {
+	for i, c := range r.credits {
+		if c.ID == id {
+			r.credits = append(r.credits[:i], r.credits[i+1:]...)
+			return nil
+		}
+	}
+	return nil
+}
+
+func (r *mockCreditRepo) ListByUser(userID uint, status string) ([]model.WorkCredit, error) {
+	var result []model.WorkCredit
+	for _, c := range r.credits {
+		if c.UserID == userID && (status == "" || c.Status == status) {
+			result = append(result, *c)
+		}
+	}
+	return result, nil
+}
+
+func (r *mockCreditRepo) ListCreditedWorks(userID uint) ([]model.Post, error) {
+	var result []model.Post
+	for _, c := range r.credits {
+		if c.UserID == userID && c.Status == model.CreditApproved {
+			result = append(result, *r.works.works[c.WorkID])
+		}
+	}
+	return result, nil
+}
+
+func TestCredit_RequiresApproval(t *testing.T) {
+	works := newMockWorkRepo()
+	users := newMockUserRepo()
+	notif := newMockNotificationService()
+	svc := service.NewCreditService(newMockCreditRepo(works), works, newMockActivityRepo(), users, notif)
+	for _, name := range []string{"author", "model", "stylist"} {
+		_ = users.Create(&model.User{UserName: name})
+	}
+	work := &model.Post{UserID: 1, Title: "Golden hour"}
+	_ = works.Create(work)
+
+	if _, err := svc.Add(work.ID, 2, service.AddCreditInput{UserID: 3, Role: "model"}); err == nil {
+		t.Fatal("only the author should add credits")
+	}
+	if _, err := svc.Add(work.ID, 1, service.AddCreditInput{UserID: 1, Role: "photographer"}); err == nil {
+		t.Fatal("authors should not credit themselves")
+	}
+
+	credit, err := svc.Add(work.ID, 1, service.AddCreditInput{UserID: 2, Role: " model "})
+	if err != nil {
+		t.Fatalf("Add failed: %v", err)
+	}
+	if credit.Status != model.CreditPending || credit.Role != "model" {
+		t.Errorf("credit = %+v, want pending model credit", credit)
+	}
+	if len(notif.sentOf(2, "credit_request")) != 1 {
+		t.Error("tagged user should be notified")
+	}
+	if _, err := svc.Add(work.ID, 1, service.AddCreditInput{UserID: 2, Role: "stylist"}); err == nil {
+		t.Error("crediting the same user twice should conflict")
+	}
+
+	// Pending credits are hidden from everyone but the author
+	if list, _ := svc.ListForWork(work.ID, 0); len(list) != 0 {
+		t.Errorf("public credits = %d, want 0 before approval", len(list))
+	}
+	if list, _ := svc.ListForWork(work.ID, 1); len(list) != 1 {
+		t.Errorf("author credits = %d, want 1", len(list))
+	}
+	if works, _ := svc.GetCreditedWorks(2); len(works) != 0 {
+		t.Error("unapproved credit should not appear on the profile")
+	}
+
+	if _, err := svc.Respond(credit.ID, 3, service.RespondCreditInput{Status: model.CreditApproved}); err == nil {
+		t.Fatal("only the tagged user can respond")
+	}
+	if _, err := svc.Respond(credit.ID, 2, service.RespondCreditInput{Status: model.CreditApproved}); err != nil {
+		t.Fatalf("Respond failed: %v", err)
+	}
+	if len(notif.sentOf(1, "credit_approved")) != 1 {
+		t.Error("author should be notified of the approval")
+	}
+	if _, err := svc.Respond(credit.ID, 2, service.RespondCreditInput{Status: model.CreditDeclined}); err == nil {
+		t.Error("a credit can only be answered once")
+	}
+
+	if list, _ := svc.ListForWork(work.ID, 0); len(list) != 1 {
+		t.Errorf("public credits = %d, want 1 after approval", len(list))
+	}
+	if works, _ := svc.GetCreditedWorks(2); len(works) != 1 || works[0].ID != work.ID {
+		t.Errorf("credited works = %+v, want the approved work", works)
+	}
+
+	// The collaborator can take their own credit down
+	if err := svc.Remove(work.ID, 3, 2); err == nil {
+		t.Error("unrelated users should not remove credits")
+	}
+	if err := svc.Remove(work.ID, 2, 2); err != nil {
+		t.Fatalf("Remove failed: %v", err)
+	}
+}
+
+func TestCredit_ActivityLink(t *testing.T) {
+	works := newMockWorkRepo()
+	activities := newMockActivityRepo()
+	users := newMockUserRepo()
+	svc := service.NewCreditService(newMockCreditRepo(works), works, activities, users, newMockNotificationService())
+	for _, name := range []string{"author", "model", "makeup"} {
+		_ = users.Create(&model.User{UserName: name})
+	}
+	work := &model.Post{UserID: 1}
+	_ = works.Create(work)
+
+	activity := &model.Activity{HostID: 1, Title: "Beach shoot", Status: "open"}
+	_ = activities.Create(activity)
+	_ = activities.CreateParticipant(&model.ActivityParticipant{ActivityID: activity.ID, UserID: 2, Status: "accepted"})
+	_ = activities.CreateParticipant(&model.ActivityParticipant{ActivityID: activity.ID, UserID: 3, Status: "pending"})
+
+	if _, err := svc.Add(work.ID, 1, service.AddCreditInput{UserID: 3, Role: "makeup", ActivityID: &activity.ID}); err == nil {
+		t.Error("collaborators who did not take part should not be linked to the activity")
+	}
+	credit, err := svc.Add(work.ID, 1, service.AddCreditInput{UserID: 2, Role: "model", ActivityID: &activity.ID})
+	if err != nil {
+		t.Fatalf("Add with activity failed: %v", err)
+	}
+	if credit.ActivityID == nil || *credit.ActivityID != activity.ID {
+		t.Errorf("ActivityID = %v, want %d", credit.ActivityID, activity.ID)
+	}
+}
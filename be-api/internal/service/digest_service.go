@@ -0,0 +1,212 @@
+package service
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"azure-magnetar/internal/model"
+	"azure-magnetar/internal/repository"
+	"azure-magnetar/pkg/email"
+	"azure-magnetar/pkg/logger"
+)
+
+const (
+	// digestPeriod is how far back a digest looks for new works.
+	digestPeriod = 7 * 24 * time.Hour
+	// digestResendGuard prevents a second digest when the job runs twice
+	// (e.g. on multiple instances or after a restart).
+	digestResendGuard = 24 * time.Hour
+	// digestSectionLimit caps the number of entries per digest section.
+	digestSectionLimit = 5
+)
+
+// DigestService defines the interface for the weekly email digest.
+type DigestService interface {
+	SendWeeklyDigests() error
+}
+
+// DigestSender delivers one user's digest, e.g. email.SendWeeklyDigestEmail.
+type DigestSender func(toEmail string, digest email.WeeklyDigest) error
+
+type digestService struct {
+	userRepo     repository.UserRepository
+	notifRepo    repository.NotificationRepository
+	workRepo     repository.WorkRepository
+	activityRepo repository.ActivityRepository
+	apiBaseURL   string
+	frontendURL  string
+	send         DigestSender
+}
+
+// NewDigestService creates a new DigestService.
+func NewDigestService(
+	userRepo repository.UserRepository,
+	notifRepo repository.NotificationRepository,
+	workRepo repository.WorkRepository,
+	activityRepo repository.ActivityRepository,
+	apiBaseURL, frontendURL string,
+	send DigestSender,
+) DigestService {
+	return &digestService{
+		userRepo:     userRepo,
+		notifRepo:    notifRepo,
+		workRepo:     workRepo,
+		activityRepo: activityRepo,
+		apiBaseURL:   apiBaseURL,
+		frontendURL:  strings.TrimSuffix(frontendURL, "/"),
+		send:         send,
+	}
+}
+
+// SendWeeklyDigests sends one digest email to every verified, subscribed user
+// who has something to report. Per-user failures are logged and skipped.
+func (s *digestService) SendWeeklyDigests() error {
+	users, err := s.userRepo.GetAll()
+	if err != nil {
+		return fmt.Errorf("failed to list users: %w", err)
+	}
+
+	now := time.Now().UTC()
+	sent := 0
+	for i := range users {
+		if !users[i].IsVerified {
+			continue
+		}
+		ok, err := s.sendDigest(&users[i], now)
+		if err != nil {
+			logger.Error("failed to send weekly digest", "userID", users[i].ID, "error", err)
+			continue
+		}
+		if ok {
+			sent++
+		}
+	}
+
+	logger.Info("weekly digests sent", "count", sent)
+	return nil
+}
+
+// sendDigest builds and sends a single user's digest. It reports whether an
+// email was actually sent.
+func (s *digestService) sendDigest(user *model.User, now time.Time) (bool, error) {
+	pref, err := ensurePreference(s.notifRepo, user.ID)
+	if err != nil {
+		return false, err
+	}
+	if !pref.EmailDigest {
+		return false, nil
+	}
+	if pref.LastDigestSentAt != nil && now.Sub(*pref.LastDigestSentAt) < digestResendGuard {
+		return false, nil
+	}
+
+	digest := s.buildDigest(user, pref, now)
+	if digest == nil {
+		return false, nil
+	}
+
+	if err := s.send(user.Email, *digest); err != nil {
+		return false, err
+	}
+
+	pref.LastDigestSentAt = &now
+	if err := s.notifRepo.SavePreference(pref); err != nil {
+		return true, fmt.Errorf("failed to record digest send time: %w", err)
+	}
+	return true, nil
+}
+
+// buildDigest collects unread notifications, new works from followed users and
+// upcoming activities in the user's city. Returns nil when all sections are empty.
+func (s *digestService) buildDigest(user *model.User, pref *model.NotificationPreference, now time.Time) *email.WeeklyDigest {
+	digest := &email.WeeklyDigest{
+		DisplayName:     user.UserName,
+		AppLink:         s.frontendURL,
+		UnsubscribeLink: fmt.Sprintf("%s/api/v1/notifications/unsubscribe?token=%s", s.apiBaseURL, pref.UnsubscribeToken),
+	}
+
+	profile, err := s.userRepo.GetProfileByUserID(user.ID)
+	if err == nil {
+		if profile.DisplayName != "" {
+			digest.DisplayName = profile.DisplayName
+		}
+		digest.City = profile.City
+	}
+
+	// 1. Unread notifications
+	if count, err := s.notifRepo.GetUnreadCount(user.ID); err == nil && count > 0 {
+		digest.UnreadCount = count
+		notifications, err := s.notifRepo.GetByUserID(user.ID)
+		if err == nil {
+			for _, n := range notifications {
+				if n.IsRead {
+					continue
+				}
+				digest.Notifications = append(digest.Notifications, email.DigestItem{
+					Title:    notificationActorName(n),
+					Subtitle: n.Content,
+				})
+				if len(digest.Notifications) >= digestSectionLimit {
+					break
+				}
+			}
+		}
+	}
+
+	// 2. New works from followed users
+	works, err := s.workRepo.GetFollowingSince(user.ID, now.Add(-digestPeriod), digestSectionLimit)
+	if err != nil {
+		logger.Warn("failed to load followed works for digest", "userID", user.ID, "error", err)
+	}
+	for _, w := range works {
+		title := w.Title
+		if title == "" {
+			title = "新作品"
+		}
+		digest.Works = append(digest.Works, email.DigestItem{
+			Title:    title,
+			Subtitle: w.Author.UserName,
+			Link:     fmt.Sprintf("%s/profile/%d", s.frontendURL, w.UserID),
+		})
+	}
+
+	// 3. Upcoming open activities near the user's city
+	if digest.City != "" {
+		activities, _, err := s.activityRepo.List(repository.ActivityFilter{
+			Location: digest.City,
+			DateFrom: now.Format("2006-01-02 15:04:05"),
+			Status:   "open",
+			Limit:    digestSectionLimit,
+		})
+		if err != nil {
+			logger.Warn("failed to load nearby activities for digest", "userID", user.ID, "error", err)
+		}
+		for _, a := range activities {
+			if a.HostID == user.ID {
+				continue
+			}
+			digest.Activities = append(digest.Activities, email.DigestItem{
+				Title:    a.Title,
+				Subtitle: fmt.Sprintf("%s・%s", a.Location, a.LocalTime(a.EventTime).Format("01/02 15:04")),
+				Link:     fmt.Sprintf("%s/activities", s.frontendURL),
+			})
+		}
+	}
+
+	if digest.UnreadCount == 0 && len(digest.Works) == 0 && len(digest.Activities) == 0 {
+		return nil
+	}
+	return digest
+}
+
+// notificationActorName returns the best display name for a notification's actor.
+func notificationActorName(n model.Notification) string {
+	if n.Actor.Profile.DisplayName != "" {
+		return n.Actor.Profile.DisplayName
+	}
+	if n.Actor.UserName != "" {
+		return n.Actor.UserName
+	}
+	return "拍揪"
+}
@@ -0,0 +1,167 @@
+package service_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"azure-magnetar/internal/model"
+	"azure-magnetar/internal/service"
+	"azure-magnetar/pkg/email"
+)
+
+// recordingSender captures digests instead of emailing them.
+type recordingSender struct {
+	sent map[string]email.WeeklyDigest // key: recipient email
+	fail bool
+}
+
+func (r *recordingSender) send(toEmail string, digest email.WeeklyDigest) error {
+	if r.fail {
+		return errors.New("send failed")
+	}
+	r.sent[toEmail] = digest
+	return nil
+}
+
+func createDigestUser(users *mockUserRepo, name string, verified bool) *model.User {
+	user := &model.User{UserName: name, Email: name + "@example.com", IsVerified: verified}
+	_ = users.Create(user)
+	return user
+}
+
+func TestSendWeeklyDigests_Content(t *testing.T) {
+	users := newMockUserRepo()
+	notifs := newMockNotificationRepo()
+	works := newMockWorkRepo()
+	activities := newMockActivityRepo()
+	sender := &recordingSender{sent: make(map[string]email.WeeklyDigest)}
+	svc := service.NewDigestService(users, notifs, works, activities, "http://localhost:8080", "http://localhost:3000/", sender.send)
+
+	reader := createDigestUser(users, "reader", true)
+	author := createDigestUser(users, "author", true)
+	stranger := createDigestUser(users, "stranger", true)
+	users.profiles[reader.ID] = &model.UserProfile{UserID: reader.ID, DisplayName: "Reader", City: "台北"}
+
+	// Six unread notifications (capped at five) and one already read
+	for i := 0; i < 6; i++ {
+		_ = notifs.Create(&model.Notification{UserID: reader.ID, ActorID: author.ID, Content: "liked your work"})
+	}
+	_ = notifs.Create(&model.Notification{UserID: reader.ID, ActorID: author.ID, Content: "old", IsRead: true})
+
+	// New works from followed users only, within the last week
+	works.follows[[2]uint{reader.ID, author.ID}] = true
+	_ = works.Create(&model.Post{UserID: author.ID, Title: "Fresh", CreatedAt: time.Now().Add(-time.Hour)})
+	_ = works.Create(&model.Post{UserID: author.ID, Title: "Stale", CreatedAt: time.Now().AddDate(0, 0, -10)})
+	_ = works.Create(&model.Post{UserID: stranger.ID, Title: "Unfollowed", CreatedAt: time.Now()})
+
+	// Open activities, leaving out the reader's own
+	_ = activities.Create(&model.Activity{HostID: author.ID, Title: "Night Shoot", Location: "台北", Status: "open", EventTime: time.Now().Add(48 * time.Hour)})
+	_ = activities.Create(&model.Activity{HostID: reader.ID, Title: "My Own", Location: "台北", Status: "open", EventTime: time.Now().Add(48 * time.Hour)})
+
+	if err := svc.SendWeeklyDigests(); err != nil {
+		t.Fatalf("SendWeeklyDigests failed: %v", err)
+	}
+
+	digest, ok := sender.sent[reader.Email]
+	if !ok {
+		t.Fatal("reader should get a digest")
+	}
+	if digest.DisplayName != "Reader" || digest.City != "台北" || digest.AppLink != "http://localhost:3000" {
+		t.Errorf("digest header = %q/%q/%q", digest.DisplayName, digest.City, digest.AppLink)
+	}
+	if digest.UnreadCount != 6 || len(digest.Notifications) != 5 {
+		t.Errorf("unread = %d with %d listed, want 6 with 5", digest.UnreadCount, len(digest.Notifications))
+	}
+	if len(digest.Works) != 1 || digest.Works[0].Title != "Fresh" {
+		t.Errorf("works = %+v, want only the fresh followed work", digest.Works)
+	}
+	if len(digest.Activities) != 1 || digest.Activities[0].Title != "Night Shoot" {
+		t.Errorf("activities = %+v, want only the other host's activity", digest.Activities)
+	}
+
+	pref := notifs.prefs[reader.ID]
+	if !strings.HasSuffix(digest.UnsubscribeLink, "/notifications/unsubscribe?token="+pref.UnsubscribeToken) {
+		t.Errorf("unsubscribe link = %q", digest.UnsubscribeLink)
+	}
+	if pref.LastDigestSentAt == nil {
+		t.Error("send time should be recorded")
+	}
+
+	// The author has nothing to report, so gets no email
+	if _, ok := sender.sent[author.Email]; ok {
+		t.Error("user with an empty digest should be skipped")
+	}
+}
+
+func TestSendWeeklyDigests_Skips(t *testing.T) {
+	users := newMockUserRepo()
+	notifs := newMockNotificationRepo()
+	sender := &recordingSender{sent: make(map[string]email.WeeklyDigest)}
+	svc := service.NewDigestService(users, notifs, newMockWorkRepo(), newMockActivityRepo(), "http://localhost:8080", "http://localhost:3000/", sender.send)
+
+	actor := createDigestUser(users, "actor", false)
+	notify := func(u *model.User) {
+		_ = notifs.Create(&model.Notification{UserID: u.ID, ActorID: actor.ID, Content: "hello"})
+	}
+
+	optedOut := createDigestUser(users, "opted-out", true)
+	notify(optedOut)
+	notifs.prefs[optedOut.ID] = &model.NotificationPreference{UserID: optedOut.ID, EmailDigest: false, UnsubscribeToken: "t1"}
+
+	recent := createDigestUser(users, "recent", true)
+	notify(recent)
+	sentAt := time.Now().Add(-time.Hour)
+	notifs.prefs[recent.ID] = &model.NotificationPreference{UserID: recent.ID, EmailDigest: true, UnsubscribeToken: "t2", LastDigestSentAt: &sentAt}
+
+	unverified := createDigestUser(users, "unverified", false)
+	notify(unverified)
+
+	subscribed := createDigestUser(users, "subscribed", true)
+	notify(subscribed)
+
+	if err := svc.SendWeeklyDigests(); err != nil {
+		t.Fatalf("SendWeeklyDigests failed: %v", err)
+	}
+	for _, u := range []*model.User{optedOut, recent, unverified} {
+		if _, ok := sender.sent[u.Email]; ok {
+			t.Errorf("%s should not get a digest", u.UserName)
+		}
+	}
+	if _, ok := sender.sent[subscribed.Email]; !ok {
+		t.Error("subscribed user should get a digest")
+	}
+
+	// Running again right away sends nothing new
+	delete(sender.sent, subscribed.Email)
+	_ = svc.SendWeeklyDigests()
+	if _, ok := sender.sent[subscribed.Email]; ok {
+		t.Error("a second run within a day should not resend")
+	}
+}
+
+func TestSendWeeklyDigests_FailedSendIsRetried(t *testing.T) {
+	users := newMockUserRepo()
+	notifs := newMockNotificationRepo()
+	sender := &recordingSender{sent: make(map[string]email.WeeklyDigest)}
+	svc := service.NewDigestService(users, notifs, newMockWorkRepo(), newMockActivityRepo(), "http://localhost:8080", "http://localhost:3000/", sender.send)
+
+	actor := createDigestUser(users, "actor", false)
+	user := createDigestUser(users, "user", true)
+	_ = notifs.Create(&model.Notification{UserID: user.ID, ActorID: actor.ID, Content: "hello"})
+
+	sender.fail = true
+	if err := svc.SendWeeklyDigests(); err != nil {
+		t.Fatalf("per-user failures should not fail the job: %v", err)
+	}
+	if notifs.prefs[user.ID].LastDigestSentAt != nil {
+		t.Fatal("a failed send should not be recorded")
+	}
+
+	sender.fail = false
+	_ = svc.SendWeeklyDigests()
+	if _, ok := sender.sent[user.Email]; !ok {
+		t.Error("digest should go out on the next run")
+	}
+}
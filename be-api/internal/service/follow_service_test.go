@@ -66,6 +66,16 @@ func (r *mockFollowRepo) GetFollowing(userID uint) ([]*model.User, error) {
 	return []*model.User{}, nil
 }
 
+func (r *mockFollowRepo) FollowersAmong(userID uint, candidateIDs []uint) (map[uint]bool, error) {
+	result := make(map[uint]bool)
+	for _, id := range candidateIDs {
+		if r.follows[followKey(id, userID)] {
+			result[id] = true
+		}
+	}
+	return result, nil
+}
+
 // mockRatingRepo is a minimal mock for RatingRepository.
 type mockRatingRepo struct {
 	ratings []model.Rating
@@ -181,6 +191,15 @@ func (s *mockFollowNotificationService) MarkAsRead(notificationID uint) error {
 func (s *mockFollowNotificationService) GetUnreadCount(userID uint) (int64, error) {
 	return 0, nil
 }
+func (s *mockFollowNotificationService) GetPreferences(userID uint) (*model.NotificationPreference, error) {
+	return &model.NotificationPreference{UserID: userID, EmailDigest: true}, nil
+}
+func (s *mockFollowNotificationService) UpdatePreferences(userID uint, _ service.UpdateNotificationPreferencesInput) (*model.NotificationPreference, error) {
+	return &model.NotificationPreference{UserID: userID}, nil
+}
+func (s *mockFollowNotificationService) Unsubscribe(_ string) error {
+	return nil
+}
 
 // --- Follow Service Tests ---
 
@@ -0,0 +1,355 @@
+package service
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"time"
+
+	"azure-magnetar/internal/model"
+	"azure-magnetar/internal/repository"
+	"azure-magnetar/pkg/apperror"
+	"azure-magnetar/pkg/logger"
+)
+
+// Insight ranges and retention.
+const (
+	defaultInsightDays = 30
+	maxInsightDays     = 90
+	insightTopWorks    = 5
+	viewRetentionDays  = 35 // Raw view events are pruned after this; the rollups are kept
+	insightDayLayout   = "2006-01-02"
+)
+
+// InsightService defines the interface for view tracking and creator insights.
+type InsightService interface {
+	RecordProfileView(profileUserID, viewerID uint, client string)
+	RecordWorkView(work *model.Post, viewerID uint, client string)
+	RecordImpressions(works []model.Post, viewerID uint, client string)
+
+	Rollup() error
+	GetInsights(userID uint, days int) (*Insights, error)
+}
+
+// Insights is a creator's dashboard over the last Days days, today included.
+type Insights struct {
+	From     string              `json:"from"` // YYYY-MM-DD
+	To       string              `json:"to"`
+	TimeZone string              `json:"timeZone"`
+	Totals   model.InsightCounts `json:"totals"`
+	Series   []InsightPoint      `json:"series"` // One point per day, oldest first
+	TopWorks []WorkInsight       `json:"topWorks"`
+}
+
+// InsightPoint is one day of a creator's insights.
+type InsightPoint struct {
+	Date string `json:"date"`
+	model.InsightCounts
+}
+
+// WorkInsight is a work with its totals over the insights range.
+type WorkInsight struct {
+	Work           model.Post `json:"work"`
+	Views          int64      `json:"views"`
+	AnonymousViews int64      `json:"anonymousViews"`
+	Impressions    int64      `json:"impressions"`
+	Likes          int64      `json:"likes"`
+}
+
+type insightService struct {
+	repo     repository.InsightRepository
+	workRepo repository.WorkRepository
+	loc      *time.Location
+}
+
+// NewInsightService creates a new InsightService. Days are counted in loc.
+func NewInsightService(repo repository.InsightRepository, workRepo repository.WorkRepository, loc *time.Location) InsightService {
+	return &insightService{repo: repo, workRepo: workRepo, loc: loc}
+}
+
+// --- Tracking ---
+
+// RecordProfileView counts viewerID (0 = anonymous, identified by client)
+// viewing a profile. Views of one's own profile are not counted.
+func (s *insightService) RecordProfileView(profileUserID, viewerID uint, client string) {
+	if viewerID == profileUserID {
+		return
+	}
+	s.record(model.ViewProfile, viewerID, client, []viewTarget{{id: profileUserID, ownerID: profileUserID}})
+}
+
+// RecordWorkView counts viewerID opening a work.
+func (s *insightService) RecordWorkView(work *model.Post, viewerID uint, client string) {
+	if viewerID == work.UserID {
+		return
+	}
+	s.record(model.ViewWork, viewerID, client, []viewTarget{{id: work.ID, ownerID: work.UserID}})
+}
+
+// RecordImpressions counts works being shown to viewerID in a list.
+func (s *insightService) RecordImpressions(works []model.Post, viewerID uint, client string) {
+	targets := make([]viewTarget, 0, len(works))
+	for _, w := range works {
+		if w.UserID != viewerID {
+			targets = append(targets, viewTarget{id: w.ID, ownerID: w.UserID})
+		}
+	}
+	s.record(model.ViewWorkImpression, viewerID, client, targets)
+}
+
+type viewTarget struct {
+	id      uint
+	ownerID uint
+}
+
+// record stores one view event per target for today. Tracking is best
+// effort: failures are logged and never reach the caller.
+func (s *insightService) record(kind string, viewerID uint, client string, targets []viewTarget) {
+	if len(targets) == 0 || (viewerID == 0 && client == "") {
+		return
+	}
+
+	day := time.Now().In(s.loc).Format(insightDayLayout)
+	viewerKey := "u:" + strconv.FormatUint(uint64(viewerID), 10)
+	if viewerID == 0 {
+		// Hash with the day so anonymous viewers cannot be followed across days
+		sum := sha256.Sum256([]byte(day + "|" + client))
+		viewerKey = "a:" + hex.EncodeToString(sum[:16])
+	}
+
+	events := make([]model.ViewEvent, len(targets))
+	for i, t := range targets {
+		events[i] = model.ViewEvent{
+			Kind:      kind,
+			TargetID:  t.id,
+			OwnerID:   t.ownerID,
+			ViewerKey: viewerKey,
+			Day:       day,
+			Anonymous: viewerID == 0,
+		}
+	}
+	if err := s.repo.RecordViews(events); err != nil {
+		logger.Warn("failed to record views", "kind", kind, "count", len(events), "error", err)
+	}
+}
+
+// --- Rollups ---
+
+// Rollup recomputes today's and yesterday's rollups from the raw events and
+// prunes events past the retention period. Yesterday is redone so views
+// recorded just before midnight are not lost. Every day between the last
+// rolled-up day and yesterday (e.g. the job was down) is backfilled first,
+// before pruning could drop its events; backfill never reaches past the
+// retention period.
+func (s *insightService) Rollup() error {
+	today := startOfDay(time.Now(), s.loc)
+	yesterday := today.AddDate(0, 0, -1)
+	oldest := today.AddDate(0, 0, -viewRetentionDays)
+	cutoff := oldest.Format(insightDayLayout)
+
+	var errs []error
+	var days []time.Time
+	from := oldest
+	last, err := s.repo.LastRolledUpDay()
+	if err != nil {
+		errs = append(errs, fmt.Errorf("find last rolled-up day: %w", err))
+		from = yesterday
+	} else if last != "" {
+		if day, err := time.ParseInLocation(insightDayLayout, last, s.loc); err == nil && !day.Before(oldest) {
+			from = day.AddDate(0, 0, 1)
+		}
+	}
+	for day := from; day.Before(yesterday); day = day.AddDate(0, 0, 1) {
+		days = append(days, day)
+	}
+	if len(days) > 0 {
+		logger.Info("backfilling insight rollups", "days", len(days), "from", days[0].Format(insightDayLayout))
+	}
+	days = append(days, yesterday, today)
+
+	for _, day := range days {
+		if err := s.rollupDay(day); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", day.Format(insightDayLayout), err))
+		}
+	}
+
+	if pruned, err := s.repo.PruneViews(cutoff); err != nil {
+		errs = append(errs, fmt.Errorf("prune views: %w", err))
+	} else if pruned > 0 {
+		logger.Info("pruned view events", "before", cutoff, "count", pruned)
+	}
+	return errors.Join(errs...)
+}
+
+// rollupDay aggregates the views, likes, follows and applications of the
+// local day starting at start into per-creator and per-work rows.
+func (s *insightService) rollupDay(start time.Time) error {
+	end := start.AddDate(0, 0, 1)
+	day := start.Format(insightDayLayout)
+
+	owners := make(map[uint]*model.DailyInsight)
+	ownerRow := func(ownerID uint) *model.InsightCounts {
+		row, ok := owners[ownerID]
+		if !ok {
+			row = &model.DailyInsight{OwnerID: ownerID, Day: day}
+			owners[ownerID] = row
+		}
+		return &row.InsightCounts
+	}
+	works := make(map[uint]*model.WorkDailyInsight)
+	workRow := func(workID, ownerID uint) *model.WorkDailyInsight {
+		row, ok := works[workID]
+		if !ok {
+			row = &model.WorkDailyInsight{WorkID: workID, OwnerID: ownerID, Day: day}
+			works[workID] = row
+		}
+		return row
+	}
+
+	views, err := s.repo.CountViews(day)
+	if err != nil {
+		return fmt.Errorf("count views: %w", err)
+	}
+	for _, v := range views {
+		counts := ownerRow(v.OwnerID)
+		switch v.Kind {
+		case model.ViewProfile:
+			if v.Anonymous {
+				counts.AnonymousProfileViews += v.Total
+			} else {
+				counts.ProfileViews += v.Total
+			}
+		case model.ViewWork:
+			work := workRow(v.TargetID, v.OwnerID)
+			if v.Anonymous {
+				counts.AnonymousWorkViews += v.Total
+				work.AnonymousViews += v.Total
+			} else {
+				counts.WorkViews += v.Total
+				work.Views += v.Total
+			}
+		case model.ViewWorkImpression:
+			counts.Impressions += v.Total
+			workRow(v.TargetID, v.OwnerID).Impressions += v.Total
+		}
+	}
+
+	likes, err := s.repo.CountLikes(start, end)
+	if err != nil {
+		return fmt.Errorf("count likes: %w", err)
+	}
+	for _, l := range likes {
+		ownerRow(l.OwnerID).Likes += l.Total
+		workRow(l.TargetID, l.OwnerID).Likes += l.Total
+	}
+
+	follows, err := s.repo.CountNewFollowers(start, end)
+	if err != nil {
+		return fmt.Errorf("count followers: %w", err)
+	}
+	for _, f := range follows {
+		ownerRow(f.OwnerID).NewFollowers += f.Total
+	}
+
+	applications, err := s.repo.CountApplications(start, end)
+	if err != nil {
+		return fmt.Errorf("count applications: %w", err)
+	}
+	for _, a := range applications {
+		ownerRow(a.OwnerID).Applications += a.Total
+	}
+
+	ownerRows := make([]model.DailyInsight, 0, len(owners))
+	for _, row := range owners {
+		ownerRows = append(ownerRows, *row)
+	}
+	sort.Slice(ownerRows, func(i, j int) bool { return ownerRows[i].OwnerID < ownerRows[j].OwnerID })
+	workRows := make([]model.WorkDailyInsight, 0, len(works))
+	for _, row := range works {
+		workRows = append(workRows, *row)
+	}
+	sort.Slice(workRows, func(i, j int) bool { return workRows[i].WorkID < workRows[j].WorkID })
+
+	return s.repo.ReplaceDay(day, ownerRows, workRows)
+}
+
+// --- Reading ---
+
+// GetInsights returns userID's daily series, totals and top works over the
+// last days days (30 by default, at most 90). Today's numbers are as of the
+// latest rollup.
+func (s *insightService) GetInsights(userID uint, days int) (*Insights, error) {
+	if days == 0 {
+		days = defaultInsightDays
+	}
+	if days < 1 || days > maxInsightDays {
+		return nil, apperror.Newf(apperror.CodeValidation, "days must be between 1 and %d", maxInsightDays)
+	}
+
+	today := startOfDay(time.Now(), s.loc)
+	from := today.AddDate(0, 0, -(days - 1))
+	result := &Insights{
+		From:     from.Format(insightDayLayout),
+		To:       today.Format(insightDayLayout),
+		TimeZone: s.loc.String(),
+		Series:   make([]InsightPoint, 0, days),
+		TopWorks: []WorkInsight{},
+	}
+
+	rows, err := s.repo.ListDaily(userID, result.From, result.To)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load insights: %w", err)
+	}
+	byDay := make(map[string]model.InsightCounts, len(rows))
+	for _, row := range rows {
+		byDay[row.Day] = row.InsightCounts
+	}
+	for d := from; !d.After(today); d = d.AddDate(0, 0, 1) {
+		point := InsightPoint{Date: d.Format(insightDayLayout), InsightCounts: byDay[d.Format(insightDayLayout)]}
+		result.Totals.Add(point.InsightCounts)
+		result.Series = append(result.Series, point)
+	}
+
+	top, err := s.repo.TopWorks(userID, result.From, result.To, insightTopWorks)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load top works: %w", err)
+	}
+	if len(top) == 0 {
+		return result, nil
+	}
+	ids := make([]uint, len(top))
+	for i, t := range top {
+		ids[i] = t.WorkID
+	}
+	posts, err := s.workRepo.GetByIDs(ids)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load top works: %w", err)
+	}
+	byID := make(map[uint]model.Post, len(posts))
+	for _, p := range posts {
+		byID[p.ID] = p
+	}
+	for _, t := range top {
+		work, ok := byID[t.WorkID]
+		if !ok {
+			continue // Deleted since
+		}
+		result.TopWorks = append(result.TopWorks, WorkInsight{
+			Work:           work,
+			Views:          t.Views,
+			AnonymousViews: t.AnonymousViews,
+			Impressions:    t.Impressions,
+			Likes:          t.Likes,
+		})
+	}
+	return result, nil
+}
+
+// startOfDay returns midnight of t's day in loc.
+func startOfDay(t time.Time, loc *time.Location) time.Time {
+	t = t.In(loc)
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, loc)
+}
@@ -0,0 +1,269 @@
+package service_test
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"azure-magnetar/internal/model"
+	"azure-magnetar/internal/repository"
+	"azure-magnetar/internal/service"
+)
+
+// --- Mock Insight Repository ---
+
+type mockInsightRepo struct {
+	events   map[string]model.ViewEvent // key: kind|target|viewer|day
+	likes    []repository.InsightCount
+	follows  []repository.InsightCount
+	applies  []repository.InsightCount
+	daily    map[string][]model.DailyInsight     // key: day
+	works    map[string][]model.WorkDailyInsight // key: day
+	topWorks []model.WorkDailyInsight
+	lastDay  string // Latest day ReplaceDay wrote
+}
+
+func newMockInsightRepo() *mockInsightRepo {
+	return &mockInsightRepo{
+		events: make(map[string]model.ViewEvent),
+		daily:  make(map[string][]model.DailyInsight),
+		works:  make(map[string][]model.WorkDailyInsight),
+	}
+}
+
+func (r *mockInsightRepo) RecordViews(events []model.ViewEvent) error {
+	for _, e := range events {
+		key := fmt.Sprintf("%s|%d|%s|%s", e.Kind, e.TargetID, e.ViewerKey, e.Day)
+		if _, ok := r.events[key]; !ok {
+			r.events[key] = e
+		}
+	}
+	return nil
+}
+
+func (r *mockInsightRepo) PruneViews(_ string) (int64, error) { return 0, nil }
+
+func (r *mockInsightRepo) CountViews(day string) ([]repository.InsightCount, error) {
+	var counts []repository.InsightCount
+	for _, e := range r.events {
+		if e.Day == day {
+			counts = append(counts, repository.InsightCount{
+				OwnerID: e.OwnerID, TargetID: e.TargetID, Kind: e.Kind, Anonymous: e.Anonymous, Total: 1,
+			})
+		}
+	}
+	return counts, nil
+}
+
+func (r *mockInsightRepo) CountLikes(_, _ time.Time) ([]repository.InsightCount, error) {
+	return r.likes, nil
+}
+
+func (r *mockInsightRepo) CountNewFollowers(_, _ time.Time) ([]repository.InsightCount, error) {
+	return r.follows, nil
+}
+
+func (r *mockInsightRepo) CountApplications(_, _ time.Time) ([]repository.InsightCount, error) {
+	return r.applies, nil
+}
+
+func (r *mockInsightRepo) ReplaceDay(day string, owners []model.DailyInsight, works []model.WorkDailyInsight) error {
+	r.daily[day] = owners
+	r.works[day] = works
+	if day > r.lastDay {
+		r.lastDay = day
+	}
+	return nil
+}
+
+func (r *mockInsightRepo) LastRolledUpDay() (string, error) {
+	return r.lastDay, nil
+}
+
+func (r *mockInsightRepo) ListDaily(ownerID uint, fromDay, toDay string) ([]model.DailyInsight, error) {
+	var result []model.DailyInsight
+	for day, rows := range r.daily {
+		if day < fromDay || day > toDay {
+			continue
+		}
+		for _, row := range rows {
+			if row.OwnerID == ownerID {
+				result = append(result, row)
+			}
+		}
+	}
+	return result, nil
+}
+
+func (r *mockInsightRepo) TopWorks(_ uint, _, _ string, _ int) ([]model.WorkDailyInsight, error) {
+	return r.topWorks, nil
+}
+
+func (r *mockInsightRepo) viewCount(kind string) int {
+	n := 0
+	for _, e := range r.events {
+		if e.Kind == kind {
+			n++
+		}
+	}
+	return n
+}
+
+// --- Insight Service Tests ---
+
+func TestRecordViews_DedupedPerViewerAndDay(t *testing.T) {
+	repo := newMockInsightRepo()
+	svc := service.NewInsightService(repo, newMockWorkRepo(), time.UTC)
+	work := &model.Post{ID: 7, UserID: 1}
+
+	svc.RecordWorkView(work, 2, "1.2.3.4|ua")
+	svc.RecordWorkView(work, 2, "5.6.7.8|ua") // Same signed-in viewer elsewhere
+	svc.RecordWorkView(work, 0, "1.2.3.4|ua")
+	svc.RecordWorkView(work, 0, "1.2.3.4|ua")
+	svc.RecordWorkView(work, 0, "9.9.9.9|ua")
+	svc.RecordWorkView(work, 1, "1.2.3.4|ua") // The author
+
+	if n := repo.viewCount(model.ViewWork); n != 3 {
+		t.Fatalf("expected 3 deduplicated views, got %d", n)
+	}
+
+	svc.RecordProfileView(1, 1, "1.2.3.4|ua")
+	if n := repo.viewCount(model.ViewProfile); n != 0 {
+		t.Errorf("own profile views should not count, got %d", n)
+	}
+
+	svc.RecordImpressions([]model.Post{{ID: 7, UserID: 1}, {ID: 8, UserID: 2}}, 2, "")
+	if n := repo.viewCount(model.ViewWorkImpression); n != 1 {
+		t.Errorf("expected 1 impression (own work skipped), got %d", n)
+	}
+}
+
+func TestInsightRollup(t *testing.T) {
+	repo := newMockInsightRepo()
+	svc := service.NewInsightService(repo, newMockWorkRepo(), time.UTC)
+	work := &model.Post{ID: 7, UserID: 1}
+
+	svc.RecordProfileView(1, 2, "")
+	svc.RecordProfileView(1, 0, "1.2.3.4|ua")
+	svc.RecordWorkView(work, 2, "")
+	svc.RecordWorkView(work, 0, "1.2.3.4|ua")
+	svc.RecordImpressions([]model.Post{*work}, 3, "")
+	repo.likes = []repository.InsightCount{{OwnerID: 1, TargetID: 7, Total: 2}}
+	repo.follows = []repository.InsightCount{{OwnerID: 1, Total: 4}}
+	repo.applies = []repository.InsightCount{{OwnerID: 1, Total: 1}}
+
+	if err := svc.Rollup(); err != nil {
+		t.Fatalf("Rollup failed: %v", err)
+	}
+
+	today := time.Now().UTC().Format("2006-01-02")
+	owners := repo.daily[today]
+	if len(owners) != 1 {
+		t.Fatalf("expected 1 creator row, got %d", len(owners))
+	}
+	want := model.InsightCounts{
+		ProfileViews: 1, AnonymousProfileViews: 1,
+		WorkViews: 1, AnonymousWorkViews: 1,
+		Impressions: 1, Likes: 2, NewFollowers: 4, Applications: 1,
+	}
+	if owners[0].InsightCounts != want {
+		t.Errorf("unexpected counts: %+v", owners[0].InsightCounts)
+	}
+
+	works := repo.works[today]
+	if len(works) != 1 || works[0].Views != 1 || works[0].AnonymousViews != 1 || works[0].Impressions != 1 || works[0].Likes != 2 {
+		t.Errorf("unexpected work rows: %+v", works)
+	}
+}
+
+func TestInsightRollup_BackfillsMissedDays(t *testing.T) {
+	repo := newMockInsightRepo()
+	svc := service.NewInsightService(repo, newMockWorkRepo(), time.UTC)
+
+	today := time.Now().UTC()
+	rolled := today.AddDate(0, 0, -6).Format("2006-01-02")
+	missed := today.AddDate(0, 0, -5).Format("2006-01-02")
+	quiet := today.AddDate(0, 0, -3).Format("2006-01-02") // No views, only a new follower
+	_ = repo.RecordViews([]model.ViewEvent{
+		{Kind: model.ViewWork, TargetID: 7, OwnerID: 1, ViewerKey: "u:2", Day: missed},
+		{Kind: model.ViewWork, TargetID: 7, OwnerID: 1, ViewerKey: "u:3", Day: missed},
+		{Kind: model.ViewWork, TargetID: 7, OwnerID: 1, ViewerKey: "u:2", Day: rolled},
+	})
+	repo.follows = []repository.InsightCount{{OwnerID: 1, Total: 1}}
+	_ = repo.ReplaceDay(rolled, []model.DailyInsight{{OwnerID: 1, Day: rolled, InsightCounts: model.InsightCounts{WorkViews: 9}}}, nil)
+
+	if err := svc.Rollup(); err != nil {
+		t.Fatalf("Rollup failed: %v", err)
+	}
+
+	if rows := repo.daily[missed]; len(rows) != 1 || rows[0].WorkViews != 2 {
+		t.Errorf("missed day rollup = %+v, want 2 work views", rows)
+	}
+	if rows := repo.daily[quiet]; len(rows) != 1 || rows[0].NewFollowers != 1 {
+		t.Errorf("day without views = %+v, want it backfilled with 1 new follower", rows)
+	}
+	if rows := repo.daily[rolled]; len(rows) != 1 || rows[0].WorkViews != 9 {
+		t.Errorf("already rolled day should be left alone, got %+v", rows)
+	}
+	if want := today.Format("2006-01-02"); repo.lastDay != want {
+		t.Errorf("last rolled-up day = %s, want %s", repo.lastDay, want)
+	}
+}
+
+func TestInsightRollup_FirstRunStopsAtRetention(t *testing.T) {
+	repo := newMockInsightRepo()
+	svc := service.NewInsightService(repo, newMockWorkRepo(), time.UTC)
+
+	if err := svc.Rollup(); err != nil {
+		t.Fatalf("Rollup failed: %v", err)
+	}
+
+	today := time.Now().UTC()
+	if _, ok := repo.daily[today.AddDate(0, 0, -35).Format("2006-01-02")]; !ok {
+		t.Error("the oldest retained day should be backfilled")
+	}
+	if _, ok := repo.daily[today.AddDate(0, 0, -36).Format("2006-01-02")]; ok {
+		t.Error("days past retention should not be backfilled")
+	}
+	if len(repo.daily) != 36 {
+		t.Errorf("rolled up %d days, want 36", len(repo.daily))
+	}
+}
+
+func TestGetInsights_SeriesAndTopWorks(t *testing.T) {
+	repo := newMockInsightRepo()
+	works := newMockWorkRepo()
+	_ = works.Create(&model.Post{UserID: 1, Title: "Kept"})
+	svc := service.NewInsightService(repo, works, time.UTC)
+
+	today := time.Now().UTC()
+	for _, d := range []time.Time{today, today.AddDate(0, 0, -2), today.AddDate(0, 0, -10)} {
+		day := d.Format("2006-01-02")
+		repo.daily[day] = []model.DailyInsight{{OwnerID: 1, Day: day, InsightCounts: model.InsightCounts{WorkViews: 3, Likes: 1}}}
+	}
+	repo.topWorks = []model.WorkDailyInsight{{WorkID: 99, Views: 10}, {WorkID: 1, Views: 6}} // 99 was deleted
+
+	insights, err := svc.GetInsights(1, 7)
+	if err != nil {
+		t.Fatalf("GetInsights failed: %v", err)
+	}
+	if len(insights.Series) != 7 {
+		t.Fatalf("expected 7 daily points, got %d", len(insights.Series))
+	}
+	if insights.Series[6].Date != today.Format("2006-01-02") || insights.Series[6].WorkViews != 3 {
+		t.Errorf("expected today last with 3 views, got %+v", insights.Series[6])
+	}
+	if insights.Series[5].WorkViews != 0 {
+		t.Errorf("expected an empty day to be zero, got %+v", insights.Series[5])
+	}
+	if insights.Totals.WorkViews != 6 || insights.Totals.Likes != 2 {
+		t.Errorf("expected totals over the window only, got %+v", insights.Totals)
+	}
+	if len(insights.TopWorks) != 1 || insights.TopWorks[0].Work.ID != 1 || insights.TopWorks[0].Views != 6 {
+		t.Errorf("unexpected top works: %+v", insights.TopWorks)
+	}
+
+	if _, err := svc.GetInsights(1, 365); err == nil {
+		t.Error("expected validation error for too many days")
+	}
+}
@@ -27,6 +27,11 @@ func NewLikeService(likeRepo repository.LikeRepository, workRepo repository.Work
 }
 
 func (s *likeService) LikeWork(userID, workID uint) error {
+	work, err := s.workRepo.GetByID(workID, 0)
+	if err != nil || !canViewWork(s.workRepo, work, userID) {
+		return apperror.New(apperror.CodeNotFound, "work not found")
+	}
+
 	isLiked, err := s.likeRepo.IsLiked(userID, workID)
 	if err != nil {
 		return err
@@ -49,8 +54,7 @@ func (s *likeService) LikeWork(userID, workID uint) error {
 	}
 
 	// Notify work author
-	work, err := s.workRepo.GetByID(workID, 0)
-	if err == nil && work.UserID != userID {
+	if work.UserID != userID {
 		workID64 := fmt.Sprintf("%d", workID)
 		if notifErr := s.notifService.SendNotification(work.UserID, userID, "work_like", workID64, "有人對您的作品按讚了！"); notifErr != nil {
 			logger.Warn("failed to send like notification", "error", notifErr)
@@ -61,6 +65,11 @@ func (s *likeService) LikeWork(userID, workID uint) error {
 }
 
 func (s *likeService) UnlikeWork(userID, workID uint) error {
+	work, err := s.workRepo.GetByID(workID, 0)
+	if err != nil || !canViewWork(s.workRepo, work, userID) {
+		return apperror.New(apperror.CodeNotFound, "work not found")
+	}
+
 	isLiked, err := s.likeRepo.IsLiked(userID, workID)
 	if err != nil {
 		return err
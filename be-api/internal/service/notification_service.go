@@ -5,6 +5,8 @@ import (
 
 	"azure-magnetar/internal/model"
 	"azure-magnetar/internal/repository"
+	"azure-magnetar/pkg/apperror"
+	"azure-magnetar/pkg/utils"
 )
 
 // NotificationService defines the interface for notification-related business logic.
@@ -13,6 +15,16 @@ type NotificationService interface {
 	GetByUserID(userID uint) ([]model.Notification, error)
 	MarkAsRead(notificationID uint) error
 	GetUnreadCount(userID uint) (int64, error)
+
+	// Preferences
+	GetPreferences(userID uint) (*model.NotificationPreference, error)
+	UpdatePreferences(userID uint, input UpdateNotificationPreferencesInput) (*model.NotificationPreference, error)
+	Unsubscribe(token string) error
+}
+
+// UpdateNotificationPreferencesInput represents the preference update body.
+type UpdateNotificationPreferencesInput struct {
+	EmailDigest *bool `json:"emailDigest"`
 }
 
 type notificationService struct {
@@ -57,3 +69,62 @@ func (s *notificationService) MarkAsRead(notificationID uint) error {
 func (s *notificationService) GetUnreadCount(userID uint) (int64, error) {
 	return s.repo.GetUnreadCount(userID)
 }
+
+// --- Preferences ---
+
+func (s *notificationService) GetPreferences(userID uint) (*model.NotificationPreference, error) {
+	return ensurePreference(s.repo, userID)
+}
+
+func (s *notificationService) UpdatePreferences(userID uint, input UpdateNotificationPreferencesInput) (*model.NotificationPreference, error) {
+	pref, err := ensurePreference(s.repo, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if input.EmailDigest != nil {
+		pref.EmailDigest = *input.EmailDigest
+	}
+
+	if err := s.repo.SavePreference(pref); err != nil {
+		return nil, fmt.Errorf("failed to update preferences: %w", err)
+	}
+	return pref, nil
+}
+
+func (s *notificationService) Unsubscribe(token string) error {
+	if token == "" {
+		return apperror.New(apperror.CodeValidation, "token is required")
+	}
+
+	pref, err := s.repo.GetPreferenceByToken(token)
+	if err != nil {
+		return apperror.New(apperror.CodeValidation, "無效的取消訂閱連結")
+	}
+
+	pref.EmailDigest = false
+	return s.repo.SavePreference(pref)
+}
+
+// ensurePreference loads a user's notification preferences, creating the
+// default record (all emails enabled) on first access.
+func ensurePreference(repo repository.NotificationRepository, userID uint) (*model.NotificationPreference, error) {
+	if pref, err := repo.GetPreference(userID); err == nil {
+		return pref, nil
+	}
+
+	token, err := utils.GenerateSecureToken(32)
+	if err != nil {
+		return nil, err
+	}
+
+	pref := &model.NotificationPreference{
+		UserID:           userID,
+		EmailDigest:      true,
+		UnsubscribeToken: token,
+	}
+	if err := repo.SavePreference(pref); err != nil {
+		return nil, fmt.Errorf("failed to create notification preferences: %w", err)
+	}
+	return pref, nil
+}
@@ -0,0 +1,155 @@
+package service_test
+
+import (
+	"errors"
+	"testing"
+
+	"azure-magnetar/internal/model"
+	"azure-magnetar/internal/service"
+)
+
+// --- Mock Notification Repository ---
+
+type mockNotificationRepo struct {
+	notifications []model.Notification
+	prefs         map[uint]*model.NotificationPreference
+	nextPrefID    uint
+}
+
+func newMockNotificationRepo() *mockNotificationRepo {
+	return &mockNotificationRepo{
+		prefs:      make(map[uint]*model.NotificationPreference),
+		nextPrefID: 1,
+	}
+}
+
+func (r *mockNotificationRepo) Create(n *model.Notification) error {
+	n.ID = uint(len(r.notifications) + 1)
+	r.notifications = append(r.notifications, *n)
+	return nil
+}
+
+func (r *mockNotificationRepo) GetByUserID(userID uint) ([]model.Notification, error) {
+	var result []model.Notification
+	for _, n := range r.notifications {
+		if n.UserID == userID {
+			result = append(result, n)
+		}
+	}
+	return result, nil
+}
+
+func (r *mockNotificationRepo) MarkAsRead(id uint) error {
+	for i := range r.notifications {
+		if r.notifications[i].ID == id {
+			r.notifications[i].IsRead = true
+		}
+	}
+	return nil
+}
+
+func (r *mockNotificationRepo) GetUnreadCount(userID uint) (int64, error) {
+	var count int64
+	for _, n := range r.notifications {
+		if n.UserID == userID && !n.IsRead {
+			count++
+		}
+	}
+	return count, nil
+}
+
+func (r *mockNotificationRepo) GetPreference(userID uint) (*model.NotificationPreference, error) {
+	p, ok := r.prefs[userID]
+	if !ok {
+		return nil, errors.New("not found")
+	}
+	return p, nil
+}
+
+func (r *mockNotificationRepo) GetPreferenceByToken(token string) (*model.NotificationPreference, error) {
+	for _, p := range r.prefs {
+		if p.UnsubscribeToken == token {
+			return p, nil
+		}
+	}
+	return nil, errors.New("not found")
+}
+
+func (r *mockNotificationRepo) SavePreference(pref *model.NotificationPreference) error {
+	if pref.ID == 0 {
+		pref.ID = r.nextPrefID
+		r.nextPrefID++
+	}
+	r.prefs[pref.UserID] = pref
+	return nil
+}
+
+// --- Notification Service Tests ---
+
+func TestSendNotification_SkipsSelf(t *testing.T) {
+	repo := newMockNotificationRepo()
+	svc := service.NewNotificationService(repo)
+
+	if err := svc.SendNotification(1, 1, "follow", "1", "self"); err != nil {
+		t.Fatalf("SendNotification failed: %v", err)
+	}
+	if len(repo.notifications) != 0 {
+		t.Errorf("notifications = %d, want 0", len(repo.notifications))
+	}
+}
+
+func TestGetPreferences_CreatesDefaults(t *testing.T) {
+	repo := newMockNotificationRepo()
+	svc := service.NewNotificationService(repo)
+
+	pref, err := svc.GetPreferences(1)
+	if err != nil {
+		t.Fatalf("GetPreferences failed: %v", err)
+	}
+	if !pref.EmailDigest {
+		t.Error("EmailDigest should default to true")
+	}
+	if pref.UnsubscribeToken == "" {
+		t.Error("UnsubscribeToken should be generated")
+	}
+
+	again, _ := svc.GetPreferences(1)
+	if again.UnsubscribeToken != pref.UnsubscribeToken {
+		t.Error("GetPreferences should reuse the existing record")
+	}
+}
+
+func TestUnsubscribe(t *testing.T) {
+	repo := newMockNotificationRepo()
+	svc := service.NewNotificationService(repo)
+
+	pref, _ := svc.GetPreferences(1)
+
+	if err := svc.Unsubscribe("wrong-token"); err == nil {
+		t.Fatal("unknown token should be rejected")
+	}
+
+	if err := svc.Unsubscribe(pref.UnsubscribeToken); err != nil {
+		t.Fatalf("Unsubscribe failed: %v", err)
+	}
+	if repo.prefs[1].EmailDigest {
+		t.Error("EmailDigest should be false after unsubscribe")
+	}
+}
+
+func TestUpdatePreferences_Resubscribe(t *testing.T) {
+	repo := newMockNotificationRepo()
+	svc := service.NewNotificationService(repo)
+
+	pref, _ := svc.GetPreferences(1)
+	_ = svc.Unsubscribe(pref.UnsubscribeToken)
+
+	enabled := true
+	updated, err := svc.UpdatePreferences(1, service.UpdateNotificationPreferencesInput{EmailDigest: &enabled})
+	if err != nil {
+		t.Fatalf("UpdatePreferences failed: %v", err)
+	}
+	if !updated.EmailDigest {
+		t.Error("EmailDigest should be true after re-subscribing")
+	}
+}
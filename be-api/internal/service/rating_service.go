@@ -68,23 +68,12 @@ func (s *ratingService) SubmitRating(activityID, raterID uint, input SubmitRatin
 		return apperror.New(apperror.CodeConflict, "you have already rated this user for this activity")
 	}
 
-	// 5. Verify both users are participants (or host)
-	isRaterParticipant := activity.HostID == raterID
-	if !isRaterParticipant {
-		p, _ := s.activityRepo.GetParticipant(activityID, raterID)
-		isRaterParticipant = p != nil && p.Status == "accepted"
+	// 5. Verify both users attended (the host always counts as present)
+	if !s.attended(activity, raterID) {
+		return apperror.New(apperror.CodeForbidden, "only participants who attended can submit ratings")
 	}
-	if !isRaterParticipant {
-		return apperror.New(apperror.CodeForbidden, "only participants can submit ratings")
-	}
-
-	isTargetParticipant := activity.HostID == input.TargetUserID
-	if !isTargetParticipant {
-		p, _ := s.activityRepo.GetParticipant(activityID, input.TargetUserID)
-		isTargetParticipant = p != nil && p.Status == "accepted"
-	}
-	if !isTargetParticipant {
-		return apperror.New(apperror.CodeValidation, "target user is not a participant of this activity")
+	if !s.attended(activity, input.TargetUserID) {
+		return apperror.New(apperror.CodeValidation, "target user did not attend this activity")
 	}
 
 	rating := &model.Rating{
@@ -98,6 +87,16 @@ func (s *ratingService) SubmitRating(activityID, raterID uint, input SubmitRatin
 	return s.ratingRepo.Create(rating)
 }
 
+// attended reports whether a user was at the activity: the host, or an
+// accepted participant who checked in.
+func (s *ratingService) attended(activity *model.Activity, userID uint) bool {
+	if activity.HostID == userID {
+		return true
+	}
+	p, err := s.activityRepo.GetParticipant(activity.ID, userID)
+	return err == nil && p.Status == "accepted" && p.CheckedInAt != nil
+}
+
 func (s *ratingService) GetActivityRatings(activityID, userID uint) (*ActivityRatingsResponse, error) {
 	given, err := s.ratingRepo.GetByActivityAndRater(activityID, userID)
 	if err != nil {
@@ -24,7 +24,7 @@ func TestSubmitRating_ActivityNotEnded(t *testing.T) {
 
 	// Create an open activity
 	input := service.CreateActivityInput{Title: "Open Activity"}
-	activitySvc := service.NewActivityService(activityRepo, newMockCommentRepo(), newMockRatingRepo(), "http://localhost:8080", "", newMockNotificationService())
+	activitySvc := service.NewActivityService(activityRepo, newMockCommentRepo(), newMockRatingRepo(), newMockUserRepo(), newMockChatRepo(activityRepo), newMockFollowRepo(), newMockWorkRepo(), "http://localhost:8080", "", newMockNotificationService(), "http://localhost:3000", testLinkSecret)
 	activity, _ := activitySvc.Create(1, input)
 
 	err := svc.SubmitRating(activity.ID, 2, service.SubmitRatingInput{
@@ -41,7 +41,7 @@ func TestSubmitRating_SelfRating(t *testing.T) {
 	svc, activityRepo, _ := setupRatingTest()
 
 	input := service.CreateActivityInput{Title: "Ended Activity"}
-	activitySvc := service.NewActivityService(activityRepo, newMockCommentRepo(), newMockRatingRepo(), "http://localhost:8080", "", newMockNotificationService())
+	activitySvc := service.NewActivityService(activityRepo, newMockCommentRepo(), newMockRatingRepo(), newMockUserRepo(), newMockChatRepo(activityRepo), newMockFollowRepo(), newMockWorkRepo(), "http://localhost:8080", "", newMockNotificationService(), "http://localhost:3000", testLinkSecret)
 	activity, _ := activitySvc.Create(1, input)
 	activity.Status = "ended"
 	_ = activityRepo.Update(activity)
@@ -59,7 +59,7 @@ func TestSubmitRating_InvalidScore(t *testing.T) {
 	svc, activityRepo, _ := setupRatingTest()
 
 	input := service.CreateActivityInput{Title: "Ended Activity"}
-	activitySvc := service.NewActivityService(activityRepo, newMockCommentRepo(), newMockRatingRepo(), "http://localhost:8080", "", newMockNotificationService())
+	activitySvc := service.NewActivityService(activityRepo, newMockCommentRepo(), newMockRatingRepo(), newMockUserRepo(), newMockChatRepo(activityRepo), newMockFollowRepo(), newMockWorkRepo(), "http://localhost:8080", "", newMockNotificationService(), "http://localhost:3000", testLinkSecret)
 	activity, _ := activitySvc.Create(1, input)
 	activity.Status = "ended"
 	_ = activityRepo.Update(activity)
@@ -86,16 +86,19 @@ func TestSubmitRating_Success(t *testing.T) {
 
 	// Create activity while open, apply user 2, accept, then end the activity
 	input := service.CreateActivityInput{Title: "Test Activity", MaxParticipants: 10}
-	activitySvc := service.NewActivityService(activityRepo, newMockCommentRepo(), newMockRatingRepo(), "http://localhost:8080", "", newMockNotificationService())
+	activitySvc := service.NewActivityService(activityRepo, newMockCommentRepo(), newMockRatingRepo(), newMockUserRepo(), newMockChatRepo(activityRepo), newMockFollowRepo(), newMockWorkRepo(), "http://localhost:8080", "", newMockNotificationService(), "http://localhost:3000", testLinkSecret)
 	activity, _ := activitySvc.Create(1, input)
 
 	// Apply while activity is still open
-	if err := activitySvc.Apply(activity.ID, 2, "join"); err != nil {
+	if err := activitySvc.Apply(activity.ID, 2, service.ApplyInput{Message: "join"}); err != nil {
 		t.Fatalf("Apply failed: %v", err)
 	}
 	if err := activitySvc.UpdateApplicantStatus(activity.ID, 1, 2, "accepted"); err != nil {
 		t.Fatalf("Accept failed: %v", err)
 	}
+	if _, err := activitySvc.CheckIn(activity.ID, 1, service.CheckInInput{UserID: 2}); err != nil {
+		t.Fatalf("CheckIn failed: %v", err)
+	}
 
 	// Now end the activity
 	activity.Status = "ended"
@@ -116,21 +119,24 @@ func TestSubmitRating_Duplicate(t *testing.T) {
 	svc, activityRepo, _ := setupRatingTest()
 
 	input := service.CreateActivityInput{Title: "Test Activity", MaxParticipants: 10}
-	activitySvc := service.NewActivityService(activityRepo, newMockCommentRepo(), newMockRatingRepo(), "http://localhost:8080", "", newMockNotificationService())
+	activitySvc := service.NewActivityService(activityRepo, newMockCommentRepo(), newMockRatingRepo(), newMockUserRepo(), newMockChatRepo(activityRepo), newMockFollowRepo(), newMockWorkRepo(), "http://localhost:8080", "", newMockNotificationService(), "http://localhost:3000", testLinkSecret)
 	activity, _ := activitySvc.Create(1, input)
 
 	// Apply while activity is still open
-	_ = activitySvc.Apply(activity.ID, 2, "join")
+	_ = activitySvc.Apply(activity.ID, 2, service.ApplyInput{Message: "join"})
 	_ = activitySvc.UpdateApplicantStatus(activity.ID, 1, 2, "accepted")
+	_, _ = activitySvc.CheckIn(activity.ID, 1, service.CheckInInput{UserID: 2})
 
 	// End the activity
 	activity.Status = "ended"
 	_ = activityRepo.Update(activity)
 
-	_ = svc.SubmitRating(activity.ID, 2, service.SubmitRatingInput{
+	if err := svc.SubmitRating(activity.ID, 2, service.SubmitRatingInput{
 		TargetUserID: 1,
 		Rating:       4,
-	})
+	}); err != nil {
+		t.Fatalf("first rating failed: %v", err)
+	}
 
 	err := svc.SubmitRating(activity.ID, 2, service.SubmitRatingInput{
 		TargetUserID: 1,
@@ -140,3 +146,36 @@ func TestSubmitRating_Duplicate(t *testing.T) {
 		t.Fatal("duplicate rating should fail")
 	}
 }
+
+func TestSubmitRating_RequiresAttendance(t *testing.T) {
+	svc, activityRepo, _ := setupRatingTest()
+
+	input := service.CreateActivityInput{Title: "Test Activity", MaxParticipants: 10}
+	activitySvc := service.NewActivityService(activityRepo, newMockCommentRepo(), newMockRatingRepo(), newMockUserRepo(), newMockChatRepo(activityRepo), newMockFollowRepo(), newMockWorkRepo(), "http://localhost:8080", "", newMockNotificationService(), "http://localhost:3000", testLinkSecret)
+	activity, _ := activitySvc.Create(1, input)
+
+	for _, uid := range []uint{2, 3} {
+		_ = activitySvc.Apply(activity.ID, uid, service.ApplyInput{Message: "join"})
+		_ = activitySvc.UpdateApplicantStatus(activity.ID, 1, uid, "accepted")
+	}
+	// Only user 2 checks in
+	if _, err := activitySvc.CheckIn(activity.ID, 1, service.CheckInInput{UserID: 2}); err != nil {
+		t.Fatalf("CheckIn failed: %v", err)
+	}
+
+	activity.Status = "ended"
+	_ = activityRepo.Update(activity)
+
+	// Accepted but absent participants cannot rate
+	if err := svc.SubmitRating(activity.ID, 3, service.SubmitRatingInput{TargetUserID: 1, Rating: 5}); err == nil {
+		t.Fatal("participant who did not check in should not be able to rate")
+	}
+	// ...nor be rated
+	if err := svc.SubmitRating(activity.ID, 1, service.SubmitRatingInput{TargetUserID: 3, Rating: 1}); err == nil {
+		t.Fatal("participant who did not check in should not be rateable")
+	}
+	// Host and attendees can rate each other
+	if err := svc.SubmitRating(activity.ID, 1, service.SubmitRatingInput{TargetUserID: 2, Rating: 5}); err != nil {
+		t.Fatalf("host rating attendee failed: %v", err)
+	}
+}
@@ -0,0 +1,219 @@
+package service
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"azure-magnetar/internal/model"
+	"azure-magnetar/internal/repository"
+	"azure-magnetar/pkg/apperror"
+)
+
+// Tag page sizes.
+const (
+	defaultTagWorksPageSize  = 20
+	maxTagWorksPageSize      = 50
+	defaultTagSuggestions    = 10
+	maxTagSuggestions        = 20
+	defaultTrendingTagsLimit = 10
+	maxTrendingTagsLimit     = 50
+	maxTagNameLength         = 50
+)
+
+// tagNamePattern matches the hashtags processTags extracts, without the '#'.
+var tagNamePattern = regexp.MustCompile(`^\p{L}+$`)
+
+// trendingWindows maps the supported ?window= values to their length.
+var trendingWindows = map[string]time.Duration{
+	"24h": 24 * time.Hour,
+	"7d":  7 * 24 * time.Hour,
+	"30d": 30 * 24 * time.Hour,
+}
+
+// TagService defines the interface for tag pages, trending tags and tag follows.
+type TagService interface {
+	Get(name string, viewerID uint) (*model.Tag, error)
+	ListWorks(name string, viewerID uint, query TagWorksQuery) (*TagWorksPage, error)
+	Autocomplete(prefix string, limit int) ([]model.Tag, error)
+	Trending(window string, limit int) ([]model.Tag, error)
+
+	Follow(userID uint, name string) (*model.Tag, error)
+	Unfollow(userID uint, name string) error
+	ListFollowed(userID uint) ([]model.Tag, error)
+}
+
+// TagWorksQuery selects a page of a tag's works.
+type TagWorksQuery struct {
+	BeforeID uint
+	Limit    int
+}
+
+// TagWorksPage is a page of a tag's public works, newest first. Pass
+// NextBefore as ?before= to load older works; it is 0 when there are none.
+type TagWorksPage struct {
+	Items      []model.Post `json:"items"`
+	NextBefore uint         `json:"nextBefore"`
+}
+
+type tagService struct {
+	repo     repository.TagRepository
+	workRepo repository.WorkRepository
+}
+
+// NewTagService creates a new TagService.
+func NewTagService(repo repository.TagRepository, workRepo repository.WorkRepository) TagService {
+	return &tagService{repo: repo, workRepo: workRepo}
+}
+
+// Get returns a tag with its public work count, follower count and whether
+// viewerID follows it.
+func (s *tagService) Get(name string, viewerID uint) (*model.Tag, error) {
+	tag, err := s.findTag(name)
+	if err != nil {
+		return nil, err
+	}
+
+	if tag.WorkCount, err = s.repo.CountWorks(tag.ID); err != nil {
+		return nil, fmt.Errorf("failed to count works: %w", err)
+	}
+	if tag.FollowerCount, err = s.repo.CountFollowers(tag.ID); err != nil {
+		return nil, fmt.Errorf("failed to count followers: %w", err)
+	}
+	if viewerID != 0 {
+		if tag.IsFollowing, err = s.repo.IsFollowing(viewerID, tag.ID); err != nil {
+			return nil, fmt.Errorf("failed to check tag follow: %w", err)
+		}
+	}
+	return tag, nil
+}
+
+func (s *tagService) ListWorks(name string, viewerID uint, query TagWorksQuery) (*TagWorksPage, error) {
+	tag, err := s.findTag(name)
+	if err != nil {
+		return nil, err
+	}
+
+	limit := clampLimit(query.Limit, defaultTagWorksPageSize, maxTagWorksPageSize)
+
+	// Fetch one extra to learn whether older works remain
+	posts, err := s.workRepo.ListByTag(tag.ID, query.BeforeID, limit+1, viewerID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list works: %w", err)
+	}
+
+	page := &TagWorksPage{Items: posts}
+	if len(posts) > limit {
+		page.Items = posts[:limit]
+		page.NextBefore = posts[limit-1].ID
+	}
+	if page.Items == nil {
+		page.Items = []model.Post{}
+	}
+	return page, nil
+}
+
+// Autocomplete suggests tags starting with prefix, most used first.
+func (s *tagService) Autocomplete(prefix string, limit int) ([]model.Tag, error) {
+	prefix, ok := normalizeTagName(prefix)
+	if !ok {
+		return nil, apperror.New(apperror.CodeValidation, "q must be letters only, optionally starting with #")
+	}
+	return s.repo.Search(prefix, clampLimit(limit, defaultTagSuggestions, maxTagSuggestions))
+}
+
+// Trending returns the tags attached to the most public works within window
+// (24h, 7d or 30d; 7d by default).
+func (s *tagService) Trending(window string, limit int) ([]model.Tag, error) {
+	if window == "" {
+		window = "7d"
+	}
+	d, ok := trendingWindows[window]
+	if !ok {
+		return nil, apperror.Newf(apperror.CodeValidation, "unsupported window %q; use 24h, 7d or 30d", window)
+	}
+	return s.repo.Trending(time.Now().Add(-d), clampLimit(limit, defaultTrendingTagsLimit, maxTrendingTagsLimit))
+}
+
+// --- Follows ---
+
+func (s *tagService) Follow(userID uint, name string) (*model.Tag, error) {
+	tag, err := s.findTag(name)
+	if err != nil {
+		return nil, err
+	}
+
+	created, err := s.repo.Follow(userID, tag.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to follow tag: %w", err)
+	}
+	if !created {
+		return nil, apperror.New(apperror.CodeConflict, "already following this tag")
+	}
+	tag.IsFollowing = true
+	return tag, nil
+}
+
+func (s *tagService) Unfollow(userID uint, name string) error {
+	tag, err := s.findTag(name)
+	if err != nil {
+		return err
+	}
+
+	removed, err := s.repo.Unfollow(userID, tag.ID)
+	if err != nil {
+		return fmt.Errorf("failed to unfollow tag: %w", err)
+	}
+	if !removed {
+		return apperror.New(apperror.CodeNotFound, "not following this tag")
+	}
+	return nil
+}
+
+func (s *tagService) ListFollowed(userID uint) ([]model.Tag, error) {
+	tags, err := s.repo.ListFollowed(userID)
+	if err != nil {
+		return nil, err
+	}
+	for i := range tags {
+		tags[i].IsFollowing = true
+	}
+	return tags, nil
+}
+
+// findTag looks a tag up by its name as written in a URL, with or without the
+// leading '#' and in any case.
+func (s *tagService) findTag(name string) (*model.Tag, error) {
+	name, ok := normalizeTagName(name)
+	if !ok {
+		return nil, apperror.New(apperror.CodeNotFound, "tag not found")
+	}
+	tag, err := s.repo.GetByName(name)
+	if err != nil {
+		return nil, apperror.New(apperror.CodeNotFound, "tag not found")
+	}
+	return tag, nil
+}
+
+// normalizeTagName strips a leading '#' and lowercases name the way
+// processTags stores it, reporting whether the result is a valid tag name.
+func normalizeTagName(name string) (string, bool) {
+	name = strings.ToLower(strings.TrimPrefix(strings.TrimSpace(name), "#"))
+	if !tagNamePattern.MatchString(name) || utf8.RuneCountInString(name) > maxTagNameLength {
+		return "", false
+	}
+	return name, true
+}
+
+// clampLimit applies def to a non-positive limit and caps it at maxLimit.
+func clampLimit(limit, def, maxLimit int) int {
+	if limit <= 0 {
+		return def
+	}
+	if limit > maxLimit {
+		return maxLimit
+	}
+	return limit
+}
@@ -0,0 +1,211 @@
+package service_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"azure-magnetar/internal/model"
+	"azure-magnetar/internal/service"
+)
+
+// --- Mock Tag Repository ---
+
+type mockTagRepo struct {
+	tags        []*model.Tag
+	follows     map[[2]uint]bool // key: {userID, tagID}
+	trendingCut time.Time        // since passed to the last Trending call
+}
+
+func newMockTagRepo(names ...string) *mockTagRepo {
+	r := &mockTagRepo{follows: make(map[[2]uint]bool)}
+	for i, name := range names {
+		r.tags = append(r.tags, &model.Tag{ID: uint(i + 1), Name: name})
+	}
+	return r
+}
+
+func (r *mockTagRepo) GetByName(name string) (*model.Tag, error) {
+	for _, t := range r.tags {
+		if t.Name == name {
+			tag := *t
+			return &tag, nil
+		}
+	}
+	return nil, errNotFound
+}
+
+func (r *mockTagRepo) Search(prefix string, limit int) ([]model.Tag, error) {
+	var result []model.Tag
+	for _, t := range r.tags {
+		if strings.HasPrefix(t.Name, prefix) && len(result) < limit {
+			result = append(result, *t)
+		}
+	}
+	return result, nil
+}
+
+func (r *mockTagRepo) Trending(since time.Time, _ int) ([]model.Tag, error) {
+	r.trendingCut = since
+	return nil, nil
+}
+
+func (r *mockTagRepo) CountWorks(_ uint) (int64, error) { return 0, nil }
+
+func (r *mockTagRepo) CountFollowers(tagID uint) (int64, error) {
+	var count int64
+	for key := range r.follows {
+		if key[1] == tagID {
+			count++
+		}
+	}
+	return count, nil
+}
+
+func (r *mockTagRepo) Follow(userID, tagID uint) (bool, error) {
+	key := [2]uint{userID, tagID}
+	if r.follows[key] {
+		return false, nil
+	}
+	r.follows[key] = true
+	return true, nil
+}
+
+func (r *mockTagRepo) Unfollow(userID, tagID uint) (bool, error) {
+	key := [2]uint{userID, tagID}
+	if !r.follows[key] {
+		return false, nil
+	}
+	delete(r.follows, key)
+	return true, nil
+}
+
+func (r *mockTagRepo) IsFollowing(userID, tagID uint) (bool, error) {
+	return r.follows[[2]uint{userID, tagID}], nil
+}
+
+func (r *mockTagRepo) ListFollowed(userID uint) ([]model.Tag, error) {
+	var result []model.Tag
+	for _, t := range r.tags {
+		if r.follows[[2]uint{userID, t.ID}] {
+			result = append(result, *t)
+		}
+	}
+	return result, nil
+}
+
+// --- Tag Service Tests ---
+
+func TestTagGet_NormalizesName(t *testing.T) {
+	svc := service.NewTagService(newMockTagRepo("portrait"), newMockWorkRepo())
+
+	tag, err := svc.Get("#Portrait", 0)
+	if err != nil {
+		t.Fatalf("Get failed: %v", err)
+	}
+	if tag.Name != "portrait" {
+		t.Errorf("expected portrait, got %q", tag.Name)
+	}
+
+	if _, err := svc.Get("street", 0); err == nil {
+		t.Error("expected not found for an unknown tag")
+	}
+}
+
+func TestTagListWorks_Pagination(t *testing.T) {
+	works := newMockWorkRepo()
+	portrait := model.Tag{ID: 1, Name: "portrait"}
+	for i := 0; i < 5; i++ {
+		_ = works.Create(&model.Post{UserID: 1, Visibility: "public", Tags: []model.Tag{portrait}})
+	}
+	_ = works.Create(&model.Post{UserID: 1, Visibility: "private", Tags: []model.Tag{portrait}})
+	_ = works.Create(&model.Post{UserID: 1, Visibility: "public"})
+	svc := service.NewTagService(newMockTagRepo("portrait"), works)
+
+	page, err := svc.ListWorks("portrait", 0, service.TagWorksQuery{Limit: 3})
+	if err != nil {
+		t.Fatalf("ListWorks failed: %v", err)
+	}
+	if len(page.Items) != 3 || page.Items[0].ID != 5 || page.NextBefore != 3 {
+		t.Fatalf("unexpected first page: %d items, first %d, nextBefore %d", len(page.Items), page.Items[0].ID, page.NextBefore)
+	}
+
+	page, err = svc.ListWorks("portrait", 0, service.TagWorksQuery{BeforeID: page.NextBefore, Limit: 3})
+	if err != nil {
+		t.Fatalf("ListWorks failed: %v", err)
+	}
+	if len(page.Items) != 2 || page.NextBefore != 0 {
+		t.Errorf("expected last page of 2, got %d items and nextBefore %d", len(page.Items), page.NextBefore)
+	}
+}
+
+func TestTagAutocomplete(t *testing.T) {
+	svc := service.NewTagService(newMockTagRepo("portrait", "portfolio", "street"), newMockWorkRepo())
+
+	tags, err := svc.Autocomplete("#Por", 0)
+	if err != nil {
+		t.Fatalf("Autocomplete failed: %v", err)
+	}
+	if len(tags) != 2 {
+		t.Errorf("expected 2 suggestions, got %d", len(tags))
+	}
+
+	if _, err := svc.Autocomplete("a%", 0); err == nil {
+		t.Error("expected validation error for a non-letter prefix")
+	}
+}
+
+func TestTagTrending_Windows(t *testing.T) {
+	repo := newMockTagRepo()
+	svc := service.NewTagService(repo, newMockWorkRepo())
+
+	if _, err := svc.Trending("24h", 0); err != nil {
+		t.Fatalf("Trending failed: %v", err)
+	}
+	if age := time.Since(repo.trendingCut); age < 23*time.Hour || age > 25*time.Hour {
+		t.Errorf("expected a 24h window, got %v", age)
+	}
+
+	if _, err := svc.Trending("", 0); err != nil {
+		t.Fatalf("Trending failed: %v", err)
+	}
+	if age := time.Since(repo.trendingCut); age < 6*24*time.Hour || age > 8*24*time.Hour {
+		t.Errorf("expected the default 7d window, got %v", age)
+	}
+
+	if _, err := svc.Trending("1y", 0); err == nil {
+		t.Error("expected validation error for an unsupported window")
+	}
+}
+
+func TestTagFollowAndUnfollow(t *testing.T) {
+	svc := service.NewTagService(newMockTagRepo("portrait"), newMockWorkRepo())
+
+	tag, err := svc.Follow(1, "portrait")
+	if err != nil {
+		t.Fatalf("Follow failed: %v", err)
+	}
+	if !tag.IsFollowing {
+		t.Error("expected IsFollowing after following")
+	}
+	if _, err := svc.Follow(1, "portrait"); err == nil {
+		t.Error("expected conflict when following twice")
+	}
+
+	tag, _ = svc.Get("portrait", 1)
+	if !tag.IsFollowing || tag.FollowerCount != 1 {
+		t.Errorf("expected followed tag with 1 follower, got %v/%d", tag.IsFollowing, tag.FollowerCount)
+	}
+
+	followed, _ := svc.ListFollowed(1)
+	if len(followed) != 1 {
+		t.Fatalf("expected 1 followed tag, got %d", len(followed))
+	}
+
+	if err := svc.Unfollow(1, "portrait"); err != nil {
+		t.Fatalf("Unfollow failed: %v", err)
+	}
+	if err := svc.Unfollow(1, "portrait"); err == nil {
+		t.Error("expected not found when unfollowing twice")
+	}
+}
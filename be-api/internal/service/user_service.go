@@ -48,26 +48,37 @@ type UserProfileResponse struct {
 	FollowerCount  int64              `json:"followerCount"`
 	FollowingCount int64              `json:"followingCount"`
 	AverageRating  float64            `json:"averageRating"`
+	Reliability    Reliability        `json:"reliability"`
+}
+
+// Reliability summarizes how often a user turns up to activities they joined.
+type Reliability struct {
+	Attended       int64    `json:"attended"`
+	NoShows        int64    `json:"noShows"`
+	LateCancels    int64    `json:"lateCancels"`
+	AttendanceRate *float64 `json:"attendanceRate"` // attended / (attended + noShows); null without history
 }
 
 type userService struct {
-	repo        repository.UserRepository
-	followRepo  repository.FollowRepository
-	ratingRepo  repository.RatingRepository
-	apiBaseURL  string
-	frontendURL string
-	gcsBucket   string
+	repo         repository.UserRepository
+	followRepo   repository.FollowRepository
+	ratingRepo   repository.RatingRepository
+	activityRepo repository.ActivityRepository
+	apiBaseURL   string
+	frontendURL  string
+	gcsBucket    string
 }
 
 // NewUserService creates a new UserService.
-func NewUserService(repo repository.UserRepository, followRepo repository.FollowRepository, ratingRepo repository.RatingRepository, apiBaseURL, frontendURL, gcsBucket string) UserService {
+func NewUserService(repo repository.UserRepository, followRepo repository.FollowRepository, ratingRepo repository.RatingRepository, activityRepo repository.ActivityRepository, apiBaseURL, frontendURL, gcsBucket string) UserService {
 	return &userService{
-		repo:        repo,
-		followRepo:  followRepo,
-		ratingRepo:  ratingRepo,
-		apiBaseURL:  apiBaseURL,
-		frontendURL: frontendURL,
-		gcsBucket:   gcsBucket,
+		repo:         repo,
+		followRepo:   followRepo,
+		ratingRepo:   ratingRepo,
+		activityRepo: activityRepo,
+		apiBaseURL:   apiBaseURL,
+		frontendURL:  frontendURL,
+		gcsBucket:    gcsBucket,
 	}
 }
 
@@ -164,6 +175,17 @@ func (s *userService) GetUserWithProfile(id uint) (*UserProfileResponse, error)
 	if avg, err := s.ratingRepo.GetAverageByUserID(id); err == nil {
 		averageRating = avg
 	}
+	var reliability Reliability
+	if attended, noShows, err := s.activityRepo.CountAttendance(id); err == nil {
+		reliability = newReliability(attended, noShows)
+	} else {
+		logger.Error("failed to count attendance", "userID", id, "error", err)
+	}
+	if lateCancels, err := s.activityRepo.CountLateCancels([]uint{id}); err == nil {
+		reliability.LateCancels = lateCancels[id]
+	} else {
+		logger.Error("failed to count late cancellations", "userID", id, "error", err)
+	}
 
 	return &UserProfileResponse{
 		ID:             user.ID,
@@ -173,9 +195,20 @@ func (s *userService) GetUserWithProfile(id uint) (*UserProfileResponse, error)
 		FollowerCount:  followerCount,
 		FollowingCount: followingCount,
 		AverageRating:  averageRating,
+		Reliability:    reliability,
 	}, nil
 }
 
+// newReliability computes the attendance rate from check-in history.
+func newReliability(attended, noShows int64) Reliability {
+	r := Reliability{Attended: attended, NoShows: noShows}
+	if total := attended + noShows; total > 0 {
+		rate := float64(attended) / float64(total)
+		r.AttendanceRate = &rate
+	}
+	return r
+}
+
 func (s *userService) ListUsers() ([]model.User, error) {
 	return s.repo.GetAll()
 }
@@ -3,6 +3,7 @@ package service_test
 import (
 	"errors"
 	"testing"
+	"time"
 
 	"azure-magnetar/internal/model"
 	"azure-magnetar/internal/service"
@@ -62,6 +63,15 @@ func (r *mockUserRepo) GetByResetToken(token string) (*model.User, error) {
 	return nil, errors.New("not found")
 }
 
+func (r *mockUserRepo) GetByCalendarToken(token string) (*model.User, error) {
+	for _, u := range r.users {
+		if u.CalendarToken != "" && u.CalendarToken == token {
+			return u, nil
+		}
+	}
+	return nil, errors.New("not found")
+}
+
 func (r *mockUserRepo) GetAll() ([]model.User, error) {
 	var result []model.User
 	for _, u := range r.users {
@@ -103,7 +113,7 @@ func TestGetUserWithProfile_IncludesAverageRating(t *testing.T) {
 	_ = ratingRepo.Create(&model.Rating{ActivityID: 1, RaterID: 2, TargetID: user.ID, Score: 4})
 	_ = ratingRepo.Create(&model.Rating{ActivityID: 2, RaterID: 3, TargetID: user.ID, Score: 5})
 
-	svc := service.NewUserService(userRepo, followRepo, ratingRepo, "http://localhost:8080", "http://localhost:5173", "")
+	svc := service.NewUserService(userRepo, followRepo, ratingRepo, newMockActivityRepo(), "http://localhost:8080", "http://localhost:5173", "")
 
 	result, err := svc.GetUserWithProfile(user.ID)
 	if err != nil {
@@ -124,7 +134,7 @@ func TestGetUserWithProfile_NoRatingsReturnsZero(t *testing.T) {
 	user := &model.User{UserName: "newuser", Email: "new@example.com", Password: "hashed"}
 	_ = userRepo.Create(user)
 
-	svc := service.NewUserService(userRepo, followRepo, ratingRepo, "http://localhost:8080", "http://localhost:5173", "")
+	svc := service.NewUserService(userRepo, followRepo, ratingRepo, newMockActivityRepo(), "http://localhost:8080", "http://localhost:5173", "")
 
 	result, err := svc.GetUserWithProfile(user.ID)
 	if err != nil {
@@ -148,7 +158,7 @@ func TestGetUserWithProfile_ReturnsFollowCounts(t *testing.T) {
 	_ = followRepo.Create(&model.Follow{FollowerID: 10, FollowingID: user.ID})
 	_ = followRepo.Create(&model.Follow{FollowerID: 11, FollowingID: user.ID})
 
-	svc := service.NewUserService(userRepo, followRepo, ratingRepo, "http://localhost:8080", "http://localhost:5173", "")
+	svc := service.NewUserService(userRepo, followRepo, ratingRepo, newMockActivityRepo(), "http://localhost:8080", "http://localhost:5173", "")
 
 	result, err := svc.GetUserWithProfile(user.ID)
 	if err != nil {
@@ -162,3 +172,38 @@ func TestGetUserWithProfile_ReturnsFollowCounts(t *testing.T) {
 		t.Errorf("FollowingCount = %d, want 0", result.FollowingCount)
 	}
 }
+
+func TestGetUserWithProfile_Reliability(t *testing.T) {
+	userRepo := newMockUserRepo()
+	activityRepo := newMockActivityRepo()
+
+	user := &model.User{UserName: "regular", Email: "regular@example.com", Password: "hashed"}
+	_ = userRepo.Create(user)
+
+	svc := service.NewUserService(userRepo, newMockFollowRepo(), newMockRatingRepo(), activityRepo, "http://localhost:8080", "http://localhost:5173", "")
+
+	// No history yet
+	result, err := svc.GetUserWithProfile(user.ID)
+	if err != nil {
+		t.Fatalf("GetUserWithProfile failed: %v", err)
+	}
+	if result.Reliability.AttendanceRate != nil {
+		t.Errorf("AttendanceRate = %v, want nil without history", *result.Reliability.AttendanceRate)
+	}
+
+	// Three attended, one no-show
+	now := time.Now()
+	for i := uint(1); i <= 3; i++ {
+		_ = activityRepo.CreateParticipant(&model.ActivityParticipant{ActivityID: i, UserID: user.ID, Status: "accepted", CheckedInAt: &now})
+	}
+	_ = activityRepo.CreateParticipant(&model.ActivityParticipant{ActivityID: 4, UserID: user.ID, Status: "no_show"})
+
+	result, err = svc.GetUserWithProfile(user.ID)
+	if err != nil {
+		t.Fatalf("GetUserWithProfile failed: %v", err)
+	}
+	r := result.Reliability
+	if r.Attended != 3 || r.NoShows != 1 || r.AttendanceRate == nil || *r.AttendanceRate != 0.75 {
+		t.Errorf("Reliability = %+v, want 3 attended, 1 no-show, rate 0.75", r)
+	}
+}
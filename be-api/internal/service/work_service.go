@@ -5,11 +5,13 @@ import (
 	"encoding/json"
 	"fmt"
 	"regexp"
+	"slices"
 	"strings"
 
 	"azure-magnetar/internal/model"
 	"azure-magnetar/internal/repository"
 	"azure-magnetar/pkg/apperror"
+	"azure-magnetar/pkg/logger"
 	"azure-magnetar/pkg/storage"
 )
 
@@ -20,7 +22,8 @@ type WorkService interface {
 	GetByID(id uint, currentUserID uint) (*model.Post, error)
 	Update(userID, workID uint, input UpdateWorkInput) (*model.Post, error)
 	Delete(userID, workID uint) error
-	GetByUserID(userID uint) ([]model.Post, error)
+	GetByUserID(userID, viewerID uint) ([]model.Post, error)
+	SetPinned(userID uint, input PinWorksInput) ([]model.Post, error)
 }
 
 // CreateWorkInput represents the data for uploading a new work.
@@ -29,12 +32,24 @@ type CreateWorkInput struct {
 	Description string   `json:"description"`
 	Title       string   `json:"title"`
 	AspectRatio float64  `json:"aspectRatio"`
+	ActivityID  *uint    `json:"activityId"` // Shoot the work came from; only its participants can link it
+	Visibility  string   `json:"visibility"` // public (default), followers, unlisted, private
 }
 
 // UpdateWorkInput represents the data for updating a work.
 type UpdateWorkInput struct {
-	Description string `json:"description"`
-	Title       string `json:"title"`
+	Description string   `json:"description"`
+	Title       string   `json:"title"`
+	ActivityID  *uint    `json:"activityId"`  // 0 removes the link
+	Visibility  string   `json:"visibility"`  // public, followers, unlisted, private
+	Images      []string `json:"images"`      // Full new image list in order; existing image URLs are kept, base64 entries are uploaded. The first is the cover
+	AspectRatio *float64 `json:"aspectRatio"` // Cover aspect ratio (width / height); required when the cover changes
+}
+
+// PinWorksInput lists the works to pin to the top of the caller's profile, in
+// order. An empty list unpins everything.
+type PinWorksInput struct {
+	WorkIDs []uint `json:"workIds"`
 }
 
 // WallResponse defines the structure for the wall API response.
@@ -56,17 +71,19 @@ type WallCursorParams struct {
 }
 
 type workService struct {
-	repo       repository.WorkRepository
-	apiBaseURL string
-	gcsBucket  string
+	repo         repository.WorkRepository
+	activityRepo repository.ActivityRepository
+	apiBaseURL   string
+	gcsBucket    string
 }
 
 // NewWorkService creates a new WorkService.
-func NewWorkService(repo repository.WorkRepository, apiBaseURL, gcsBucket string) WorkService {
+func NewWorkService(repo repository.WorkRepository, activityRepo repository.ActivityRepository, apiBaseURL, gcsBucket string) WorkService {
 	return &workService{
-		repo:       repo,
-		apiBaseURL: apiBaseURL,
-		gcsBucket:  gcsBucket,
+		repo:         repo,
+		activityRepo: activityRepo,
+		apiBaseURL:   apiBaseURL,
+		gcsBucket:    gcsBucket,
 	}
 }
 
@@ -116,6 +133,22 @@ func (s *workService) Create(userID uint, input CreateWorkInput) (*model.Post, e
 		return nil, apperror.New(apperror.CodeValidation, "at least one image is required")
 	}
 
+	visibility := input.Visibility
+	if visibility == "" {
+		visibility = "public"
+	}
+	if !workVisibilities[visibility] {
+		return nil, apperror.Newf(apperror.CodeValidation, "unsupported visibility %q", visibility)
+	}
+
+	var activityID *uint
+	if input.ActivityID != nil && *input.ActivityID != 0 {
+		if err := s.checkActivityLink(userID, *input.ActivityID); err != nil {
+			return nil, err
+		}
+		activityID = input.ActivityID
+	}
+
 	var imageURLs []string
 	for i, imgBase64 := range input.Images {
 		url, err := storage.SaveBase64Image(s.apiBaseURL, s.gcsBucket, "works", userID, imgBase64, i)
@@ -132,6 +165,8 @@ func (s *workService) Create(userID uint, input CreateWorkInput) (*model.Post, e
 		Description: input.Description,
 		Title:       input.Title,
 		AspectRatio: aspectRatio,
+		ActivityID:  activityID,
+		Visibility:  visibility,
 	}
 
 	// Process hashtags
@@ -148,7 +183,11 @@ func (s *workService) Create(userID uint, input CreateWorkInput) (*model.Post, e
 }
 
 func (s *workService) GetByID(id uint, currentUserID uint) (*model.Post, error) {
-	return s.repo.GetByID(id, currentUserID)
+	post, err := s.repo.GetByID(id, currentUserID)
+	if err != nil || !canViewWork(s.repo, post, currentUserID) {
+		return nil, apperror.New(apperror.CodeNotFound, "work not found")
+	}
+	return post, nil
 }
 
 func (s *workService) Update(userID, workID uint, input UpdateWorkInput) (*model.Post, error) {
@@ -167,6 +206,46 @@ func (s *workService) Update(userID, workID uint, input UpdateWorkInput) (*model
 	if input.Title != "" {
 		post.Title = input.Title
 	}
+	if input.Visibility != "" {
+		if !workVisibilities[input.Visibility] {
+			return nil, apperror.Newf(apperror.CodeValidation, "unsupported visibility %q", input.Visibility)
+		}
+		post.Visibility = input.Visibility
+	}
+	if input.ActivityID != nil {
+		if *input.ActivityID == 0 {
+			post.ActivityID = nil
+		} else {
+			if err := s.checkActivityLink(userID, *input.ActivityID); err != nil {
+				return nil, err
+			}
+			post.ActivityID = input.ActivityID
+		}
+		post.Activity = nil
+	}
+	if input.AspectRatio != nil {
+		if *input.AspectRatio <= 0 {
+			return nil, apperror.New(apperror.CodeValidation, "aspectRatio must be positive")
+		}
+		post.AspectRatio = *input.AspectRatio
+	}
+
+	// Images go last so nothing is uploaded for an update that fails validation
+	var uploaded, removed []string
+	if input.Images != nil {
+		// The feed lays out the cover by AspectRatio, so a new cover needs its own
+		if len(input.Images) > 0 && input.Images[0] != post.ImageURL && input.AspectRatio == nil {
+			return nil, apperror.New(apperror.CodeValidation, "aspectRatio is required when the cover image changes")
+		}
+		images, added, err := s.updateImages(post, input.Images)
+		if err != nil {
+			return nil, err
+		}
+		uploaded = added
+		removed = missingImages(postImages(post), images)
+		post.Images = images
+		post.ImageURL = images[0]
+	}
 
 	// Process hashtags if description updated
 	if input.Description != "" {
@@ -177,12 +256,89 @@ func (s *workService) Update(userID, workID uint, input UpdateWorkInput) (*model
 	}
 
 	if err := s.repo.Update(post); err != nil {
+		s.deleteImages(uploaded)
 		return nil, fmt.Errorf("failed to update work: %w", err)
 	}
+	s.deleteImages(removed)
 
 	return post, nil
 }
 
+// updateImages builds a work's new image list from entries that are either
+// one of its current image URLs or base64 data to upload. It returns the list
+// and the URLs it uploaded.
+func (s *workService) updateImages(post *model.Post, entries []string) ([]string, []string, error) {
+	if len(entries) == 0 {
+		return nil, nil, apperror.New(apperror.CodeValidation, "at least one image is required")
+	}
+
+	current := make(map[string]bool)
+	for _, url := range postImages(post) {
+		current[url] = true
+	}
+	seen := make(map[string]bool, len(entries))
+	for i, entry := range entries {
+		if !strings.HasPrefix(entry, "http") {
+			continue
+		}
+		if !current[entry] {
+			return nil, nil, apperror.Newf(apperror.CodeValidation, "image %d is not one of this work's images", i)
+		}
+		if seen[entry] {
+			return nil, nil, apperror.Newf(apperror.CodeValidation, "image %d is listed twice", i)
+		}
+		seen[entry] = true
+	}
+
+	images := make([]string, 0, len(entries))
+	var uploaded []string
+	for i, entry := range entries {
+		if strings.HasPrefix(entry, "http") {
+			images = append(images, entry)
+			continue
+		}
+		url, err := storage.SaveBase64Image(s.apiBaseURL, s.gcsBucket, "works", post.UserID, entry, i)
+		if err != nil {
+			s.deleteImages(uploaded)
+			return nil, nil, fmt.Errorf("failed to upload image %d: %w", i, err)
+		}
+		images = append(images, url)
+		uploaded = append(uploaded, url)
+	}
+	return images, uploaded, nil
+}
+
+// deleteImages removes image blobs from storage. Failures are logged; a stray
+// blob is not worth failing the request over.
+func (s *workService) deleteImages(urls []string) {
+	for _, url := range urls {
+		if err := storage.DeleteImage(s.apiBaseURL, s.gcsBucket, url); err != nil {
+			logger.Warn("failed to delete work image", "url", url, "error", err)
+		}
+	}
+}
+
+// postImages returns all image URLs of a work, including a cover that older
+// works may only have in ImageURL.
+func postImages(post *model.Post) []string {
+	images := append([]string(nil), post.Images...)
+	if post.ImageURL != "" && !slices.Contains(images, post.ImageURL) {
+		images = append(images, post.ImageURL)
+	}
+	return images
+}
+
+// missingImages returns the URLs in before that are not in after.
+func missingImages(before, after []string) []string {
+	var missing []string
+	for _, url := range before {
+		if !slices.Contains(after, url) {
+			missing = append(missing, url)
+		}
+	}
+	return missing
+}
+
 func (s *workService) Delete(userID, workID uint) error {
 	post, err := s.repo.GetByID(workID, userID)
 	if err != nil {
@@ -196,8 +352,87 @@ func (s *workService) Delete(userID, workID uint) error {
 	return s.repo.Delete(workID)
 }
 
-func (s *workService) GetByUserID(userID uint) ([]model.Post, error) {
-	return s.repo.GetByUserID(userID)
+// GetByUserID returns the works of userID that viewerID may see listed on
+// their profile.
+func (s *workService) GetByUserID(userID, viewerID uint) ([]model.Post, error) {
+	return s.repo.GetByUserID(userID, listedVisibilities(s.repo, userID, viewerID))
+}
+
+// checkActivityLink ensures userID took part in the activity (as host, co-host
+// or accepted participant) before a work is linked to it.
+func (s *workService) checkActivityLink(userID, activityID uint) error {
+	activity, err := s.activityRepo.GetByID(activityID)
+	if err != nil || activity.Status == "draft" {
+		return apperror.New(apperror.CodeNotFound, "activity not found")
+	}
+	if activity.Status == "cancelled" {
+		return apperror.New(apperror.CodeValidation, "works cannot be linked to a cancelled activity")
+	}
+	if !isActivityCrew(s.activityRepo, activity, userID) {
+		return apperror.New(apperror.CodeForbidden, "only participants can link works to this activity")
+	}
+	return nil
+}
+
+// SetPinned replaces the caller's pinned works and returns their portfolio in
+// profile order.
+func (s *workService) SetPinned(userID uint, input PinWorksInput) ([]model.Post, error) {
+	workIDs, err := ownWorkIDs(s.repo, userID, input.WorkIDs, maxPinnedWorks)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.SetPinnedWorks(userID, workIDs); err != nil {
+		return nil, fmt.Errorf("failed to pin works: %w", err)
+	}
+	return s.repo.GetByUserID(userID, nil)
+}
+
+// --- Visibility ---
+
+var workVisibilities = map[string]bool{
+	"public":    true,
+	"followers": true,
+	"unlisted":  true,
+	"private":   true,
+}
+
+// canViewWork reports whether viewerID (0 = anonymous) may open a work.
+// Public and unlisted works are visible to anyone who has the ID,
+// followers-only works to the author's followers, and private works to the
+// author alone.
+func canViewWork(repo repository.WorkRepository, work *model.Post, viewerID uint) bool {
+	if viewerID != 0 && viewerID == work.UserID {
+		return true
+	}
+	switch work.Visibility {
+	case "public", "unlisted", "":
+		return true
+	case "followers":
+		return isFollower(repo, viewerID, work.UserID)
+	}
+	return false
+}
+
+// listedVisibilities returns the visibility levels of ownerID's works that
+// appear in lists viewerID sees, such as their profile or albums. Unlisted and
+// private works are only listed for the owner; nil means no restriction.
+func listedVisibilities(repo repository.WorkRepository, ownerID, viewerID uint) []string {
+	if viewerID != 0 && viewerID == ownerID {
+		return nil
+	}
+	if isFollower(repo, viewerID, ownerID) {
+		return []string{"public", "followers"}
+	}
+	return []string{"public"}
+}
+
+func isFollower(repo repository.WorkRepository, viewerID, authorID uint) bool {
+	if viewerID == 0 {
+		return false
+	}
+	following, err := repo.IsFollowing(viewerID, authorID)
+	return err == nil && following
 }
 
 func (s *workService) processTags(description string) ([]model.Tag, error) {
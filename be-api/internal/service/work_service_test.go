@@ -1,7 +1,11 @@
 package service_test
 
 import (
+	"os"
+	"sort"
+	"strings"
 	"testing"
+	"time"
 
 	"azure-magnetar/internal/model"
 	"azure-magnetar/internal/service"
@@ -10,14 +14,16 @@ import (
 // --- Mock Work Repository ---
 
 type mockWorkRepo struct {
-	works  map[uint]*model.Post
-	nextID uint
+	works   map[uint]*model.Post
+	nextID  uint
+	follows map[[2]uint]bool // key: {followerID, followingID}
 }
 
 func newMockWorkRepo() *mockWorkRepo {
 	return &mockWorkRepo{
-		works:  make(map[uint]*model.Post),
-		nextID: 1,
+		works:   make(map[uint]*model.Post),
+		nextID:  1,
+		follows: make(map[[2]uint]bool),
 	}
 }
 
@@ -46,14 +52,102 @@ func (r *mockWorkRepo) Delete(id uint) error {
 	return nil
 }
 
-func (r *mockWorkRepo) GetByUserID(_ uint) ([]model.Post, error) {
-	return nil, nil
+func (r *mockWorkRepo) GetByUserID(userID uint, visibilities []string) ([]model.Post, error) {
+	var result []model.Post
+	for _, p := range r.works {
+		if p.UserID == userID && visibilityIn(p.Visibility, visibilities) {
+			result = append(result, *p)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool {
+		a, b := result[i], result[j]
+		if (a.PinOrder == 0) != (b.PinOrder == 0) {
+			return a.PinOrder != 0
+		}
+		if a.PinOrder != b.PinOrder {
+			return a.PinOrder < b.PinOrder
+		}
+		return a.ID > b.ID
+	})
+	return result, nil
+}
+
+func visibilityIn(visibility string, allowed []string) bool {
+	if allowed == nil {
+		return true
+	}
+	if visibility == "" {
+		visibility = "public"
+	}
+	for _, v := range allowed {
+		if v == visibility {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *mockWorkRepo) IsFollowing(followerID, followingID uint) (bool, error) {
+	return r.follows[[2]uint{followerID, followingID}], nil
 }
 
 func (r *mockWorkRepo) GetPosts(_, _ int, _ int64, _ string, _ uint) ([]model.Post, int64, error) {
 	return nil, 0, nil
 }
 
+func (r *mockWorkRepo) GetFollowingSince(userID uint, since time.Time, limit int) ([]model.Post, error) {
+	var result []model.Post
+	for _, p := range r.works {
+		if r.follows[[2]uint{userID, p.UserID}] && !p.CreatedAt.Before(since) && len(result) < limit {
+			result = append(result, *p)
+		}
+	}
+	return result, nil
+}
+
+func (r *mockWorkRepo) ListByTag(tagID, beforeID uint, limit int, _ uint) ([]model.Post, error) {
+	var result []model.Post
+	for _, p := range r.works {
+		if (p.Visibility != "" && p.Visibility != "public") || (beforeID > 0 && p.ID >= beforeID) {
+			continue
+		}
+		for _, t := range p.Tags {
+			if t.ID == tagID {
+				result = append(result, *p)
+				break
+			}
+		}
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].ID > result[j].ID })
+	if len(result) > limit {
+		result = result[:limit]
+	}
+	return result, nil
+}
+
+func (r *mockWorkRepo) ListByActivity(activityID, beforeID uint, limit int, _ uint) ([]model.Post, error) {
+	var result []model.Post
+	for _, p := range r.works {
+		if p.ActivityID == nil || *p.ActivityID != activityID || (p.Visibility != "" && p.Visibility != "public") {
+			continue
+		}
+		if beforeID > 0 && p.ID >= beforeID {
+			continue
+		}
+		result = append(result, *p)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].ID > result[j].ID })
+	if len(result) > limit {
+		result = result[:limit]
+	}
+	return result, nil
+}
+
+func (r *mockWorkRepo) CountByActivity(activityID uint) (int64, error) {
+	works, _ := r.ListByActivity(activityID, 0, len(r.works), 0)
+	return int64(len(works)), nil
+}
+
 func (r *mockWorkRepo) IncrementLikeCount(_ uint) error    { return nil }
 func (r *mockWorkRepo) DecrementLikeCount(_ uint) error    { return nil }
 func (r *mockWorkRepo) IncrementCommentCount(_ uint) error { return nil }
@@ -63,6 +157,30 @@ func (r *mockWorkRepo) GetTagsByNames(_ []string) ([]model.Tag, error) {
 	return nil, nil
 }
 
+func (r *mockWorkRepo) GetByIDs(ids []uint) ([]model.Post, error) {
+	var result []model.Post
+	for _, id := range ids {
+		if p, ok := r.works[id]; ok {
+			result = append(result, *p)
+		}
+	}
+	return result, nil
+}
+
+func (r *mockWorkRepo) SetPinnedWorks(userID uint, workIDs []uint) error {
+	for _, p := range r.works {
+		if p.UserID == userID {
+			p.PinOrder = 0
+		}
+	}
+	for i, id := range workIDs {
+		if p, ok := r.works[id]; ok && p.UserID == userID {
+			p.PinOrder = i + 1
+		}
+	}
+	return nil
+}
+
 // --- Mock Like Repository ---
 
 type mockLikeRepo struct {
@@ -95,7 +213,7 @@ func (r *mockLikeRepo) IsLiked(userID, workID uint) (bool, error) {
 
 func TestCreateWork(t *testing.T) {
 	repo := newMockWorkRepo()
-	svc := service.NewWorkService(repo, "http://localhost:8080", "")
+	svc := service.NewWorkService(repo, newMockActivityRepo(), "http://localhost:8080", "")
 
 	input := service.CreateWorkInput{
 		Images:      []string{"R0lGODlhAQABAIAAAAAAAP///yH5BAEAAAAALAAAAAABAAEAAAIBRAA7"},
@@ -118,7 +236,7 @@ func TestCreateWork(t *testing.T) {
 
 func TestUpdateWork_OnlyAuthor(t *testing.T) {
 	repo := newMockWorkRepo()
-	svc := service.NewWorkService(repo, "http://localhost:8080", "")
+	svc := service.NewWorkService(repo, newMockActivityRepo(), "http://localhost:8080", "")
 
 	input := service.CreateWorkInput{Images: []string{"R0lGODlhAQABAIAAAAAAAP///yH5BAEAAAAALAAAAAABAAEAAAIBRAA7"}}
 	work, _ := svc.Create(1, input)
@@ -141,7 +259,7 @@ func TestUpdateWork_OnlyAuthor(t *testing.T) {
 
 func TestDeleteWork_OnlyAuthor(t *testing.T) {
 	repo := newMockWorkRepo()
-	svc := service.NewWorkService(repo, "http://localhost:8080", "")
+	svc := service.NewWorkService(repo, newMockActivityRepo(), "http://localhost:8080", "")
 
 	input := service.CreateWorkInput{Images: []string{"R0lGODlhAQABAIAAAAAAAP///yH5BAEAAAAALAAAAAABAAEAAAIBRAA7"}}
 	work, _ := svc.Create(1, input)
@@ -159,8 +277,16 @@ func TestDeleteWork_OnlyAuthor(t *testing.T) {
 
 // --- Like Service Tests ---
 
+// newLikeService returns a LikeService over a repo holding work 1, authored by
+// user 9.
+func newLikeService() service.LikeService {
+	repo := newMockWorkRepo()
+	_ = repo.Create(&model.Post{UserID: 9, Visibility: "public"})
+	return service.NewLikeService(newMockLikeRepo(), repo, newMockNotificationService())
+}
+
 func TestLikeWork_Success(t *testing.T) {
-	svc := service.NewLikeService(newMockLikeRepo(), newMockWorkRepo(), nil)
+	svc := newLikeService()
 
 	err := svc.LikeWork(1, 1)
 	if err != nil {
@@ -169,7 +295,7 @@ func TestLikeWork_Success(t *testing.T) {
 }
 
 func TestLikeWork_Duplicate(t *testing.T) {
-	svc := service.NewLikeService(newMockLikeRepo(), newMockWorkRepo(), nil)
+	svc := newLikeService()
 
 	_ = svc.LikeWork(1, 1)
 	err := svc.LikeWork(1, 1)
@@ -179,7 +305,7 @@ func TestLikeWork_Duplicate(t *testing.T) {
 }
 
 func TestUnlikeWork_NotLiked(t *testing.T) {
-	svc := service.NewLikeService(newMockLikeRepo(), newMockWorkRepo(), nil)
+	svc := newLikeService()
 
 	err := svc.UnlikeWork(1, 1)
 	if err == nil {
@@ -188,7 +314,7 @@ func TestUnlikeWork_NotLiked(t *testing.T) {
 }
 
 func TestLikeAndUnlike(t *testing.T) {
-	svc := service.NewLikeService(newMockLikeRepo(), newMockWorkRepo(), nil)
+	svc := newLikeService()
 
 	_ = svc.LikeWork(1, 1)
 	err := svc.UnlikeWork(1, 1)
@@ -202,3 +328,211 @@ func TestLikeAndUnlike(t *testing.T) {
 		t.Fatalf("re-LikeWork failed: %v", err)
 	}
 }
+
+// --- Visibility Tests ---
+
+func TestWorkVisibility_GetByID(t *testing.T) {
+	repo := newMockWorkRepo()
+	svc := service.NewWorkService(repo, newMockActivityRepo(), "http://localhost:8080", "")
+
+	followersOnly := &model.Post{UserID: 1, Visibility: "followers"}
+	private := &model.Post{UserID: 1, Visibility: "private"}
+	unlisted := &model.Post{UserID: 1, Visibility: "unlisted"}
+	_ = repo.Create(followersOnly)
+	_ = repo.Create(private)
+	_ = repo.Create(unlisted)
+	repo.follows[[2]uint{2, 1}] = true
+
+	cases := []struct {
+		name    string
+		workID  uint
+		viewer  uint
+		visible bool
+	}{
+		{"follower sees followers-only", followersOnly.ID, 2, true},
+		{"stranger cannot see followers-only", followersOnly.ID, 3, false},
+		{"anonymous cannot see followers-only", followersOnly.ID, 0, false},
+		{"follower cannot see private", private.ID, 2, false},
+		{"author sees private", private.ID, 1, true},
+		{"anonymous sees unlisted by ID", unlisted.ID, 0, true},
+	}
+	for _, tc := range cases {
+		_, err := svc.GetByID(tc.workID, tc.viewer)
+		if tc.visible && err != nil {
+			t.Errorf("%s: unexpected error %v", tc.name, err)
+		}
+		if !tc.visible && err == nil {
+			t.Errorf("%s: expected not found", tc.name)
+		}
+	}
+}
+
+func TestWorkVisibility_ProfileListing(t *testing.T) {
+	repo := newMockWorkRepo()
+	svc := service.NewWorkService(repo, newMockActivityRepo(), "http://localhost:8080", "")
+
+	for _, v := range []string{"public", "followers", "unlisted", "private"} {
+		_ = repo.Create(&model.Post{UserID: 1, Visibility: v})
+	}
+	repo.follows[[2]uint{2, 1}] = true
+
+	counts := map[uint]int{0: 1, 3: 1, 2: 2, 1: 4}
+	for viewer, want := range counts {
+		works, err := svc.GetByUserID(1, viewer)
+		if err != nil {
+			t.Fatalf("GetByUserID failed: %v", err)
+		}
+		if len(works) != want {
+			t.Errorf("viewer %d: expected %d works, got %d", viewer, want, len(works))
+		}
+	}
+}
+
+func TestWorkVisibility_InvalidValue(t *testing.T) {
+	svc := service.NewWorkService(newMockWorkRepo(), newMockActivityRepo(), "http://localhost:8080", "")
+
+	input := service.CreateWorkInput{
+		Images:     []string{"R0lGODlhAQABAIAAAAAAAP///yH5BAEAAAAALAAAAAABAAEAAAIBRAA7"},
+		Visibility: "friends",
+	}
+	if _, err := svc.Create(1, input); err == nil {
+		t.Fatal("expected error for unsupported visibility")
+	}
+}
+
+func TestWorkVisibility_LikesAndComments(t *testing.T) {
+	repo := newMockWorkRepo()
+	work := &model.Post{UserID: 1, Visibility: "private"}
+	_ = repo.Create(work)
+	likes := service.NewLikeService(newMockLikeRepo(), repo, newMockNotificationService())
+	comments := service.NewCommentService(newMockCommentRepo(), repo, newMockActivityRepo(), newMockRatingRepo(), newMockNotificationService())
+
+	if err := likes.LikeWork(2, work.ID); err == nil {
+		t.Fatal("stranger should not be able to like a private work")
+	}
+	if _, err := comments.CreateForWork(work.ID, 2, "nice"); err == nil {
+		t.Fatal("stranger should not be able to comment on a private work")
+	}
+	if _, err := comments.GetByWorkID(work.ID, 0); err == nil {
+		t.Fatal("anonymous viewer should not see comments on a private work")
+	}
+
+	work.Visibility = "followers"
+	repo.follows[[2]uint{2, 1}] = true
+	if err := likes.LikeWork(2, work.ID); err != nil {
+		t.Fatalf("follower like failed: %v", err)
+	}
+	if _, err := comments.CreateForWork(work.ID, 2, "nice"); err != nil {
+		t.Fatalf("follower comment failed: %v", err)
+	}
+}
+
+func TestUpdateWork_Images(t *testing.T) {
+	t.Chdir(t.TempDir())
+	repo := newMockWorkRepo()
+	svc := service.NewWorkService(repo, newMockActivityRepo(), "http://localhost:8080", "")
+
+	gif := "R0lGODlhAQABAIAAAAAAAP///yH5BAEAAAAALAAAAAABAAEAAAIBRAA7"
+	work, err := svc.Create(1, service.CreateWorkInput{Images: []string{gif, gif}})
+	if err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+	first, second := work.Images[0], work.Images[1]
+
+	// Drop the first image, make the second the cover and add a new one
+	ratio := 0.75
+	updated, err := svc.Update(1, work.ID, service.UpdateWorkInput{Images: []string{second, gif}, AspectRatio: &ratio})
+	if err != nil {
+		t.Fatalf("Update failed: %v", err)
+	}
+	if len(updated.Images) != 2 || updated.Images[0] != second || updated.ImageURL != second {
+		t.Fatalf("expected the second image as cover, got %v (cover %s)", updated.Images, updated.ImageURL)
+	}
+	if updated.Images[1] == first || updated.Images[1] == second {
+		t.Errorf("expected a newly uploaded image, got %s", updated.Images[1])
+	}
+	if updated.AspectRatio != 0.75 {
+		t.Errorf("expected aspect ratio 0.75, got %v", updated.AspectRatio)
+	}
+
+	localPath := func(url string) string {
+		return strings.TrimPrefix(url, "http://localhost:8080/")
+	}
+	if _, err := os.Stat(localPath(first)); !os.IsNotExist(err) {
+		t.Errorf("expected the removed image to be deleted from storage, got %v", err)
+	}
+	for _, url := range updated.Images {
+		if _, err := os.Stat(localPath(url)); err != nil {
+			t.Errorf("expected %s to stay in storage: %v", url, err)
+		}
+	}
+}
+
+func TestUpdateWork_ImagesValidation(t *testing.T) {
+	repo := newMockWorkRepo()
+	svc := service.NewWorkService(repo, newMockActivityRepo(), "http://localhost:8080", "")
+	work := &model.Post{UserID: 1, ImageURL: "http://localhost:8080/uploads/works/a.jpg", Images: []string{"http://localhost:8080/uploads/works/a.jpg"}}
+	_ = repo.Create(work)
+
+	negative := -1.0
+	cases := map[string]service.UpdateWorkInput{
+		"empty list":          {Images: []string{}},
+		"foreign URL":         {Images: []string{"https://example.com/other.jpg"}},
+		"duplicate image":     {Images: []string{work.ImageURL, work.ImageURL}},
+		"bad aspect ratio":    {AspectRatio: &negative},
+		"other author's URL":  {Images: []string{"http://localhost:8080/uploads/works/b.jpg"}},
+		"new cover, no ratio": {Images: []string{"R0lGODlhAQABAIAAAAAAAP///yH5BAEAAAAALAAAAAABAAEAAAIBRAA7"}},
+	}
+	for name, input := range cases {
+		if _, err := svc.Update(1, work.ID, input); err == nil {
+			t.Errorf("%s: expected validation error", name)
+		}
+	}
+	if work.ImageURL != "http://localhost:8080/uploads/works/a.jpg" || len(work.Images) != 1 {
+		t.Errorf("failed updates should leave images unchanged, got %v", work.Images)
+	}
+
+	// Keeping the cover needs no new aspect ratio
+	if _, err := svc.Update(1, work.ID, service.UpdateWorkInput{Images: []string{work.ImageURL}}); err != nil {
+		t.Errorf("update keeping the cover failed: %v", err)
+	}
+}
+
+func TestWorkActivityLink_ParticipantsOnly(t *testing.T) {
+	repo := newMockWorkRepo()
+	activities := newMockActivityRepo()
+	svc := service.NewWorkService(repo, activities, "http://localhost:8080", "")
+
+	activity := &model.Activity{HostID: 1, Title: "Rooftop shoot", Status: "ended"}
+	_ = activities.Create(activity)
+	_ = activities.CreateParticipant(&model.ActivityParticipant{ActivityID: activity.ID, UserID: 2, Status: "accepted"})
+	_ = activities.CreateParticipant(&model.ActivityParticipant{ActivityID: activity.ID, UserID: 3, Status: "rejected"})
+
+	image := []string{"R0lGODlhAQABAIAAAAAAAP///yH5BAEAAAAALAAAAAABAAEAAAIBRAA7"}
+	if _, err := svc.Create(3, service.CreateWorkInput{Images: image, ActivityID: &activity.ID}); err == nil {
+		t.Fatal("non-participants should not link works to the activity")
+	}
+
+	work, err := svc.Create(2, service.CreateWorkInput{Images: image, ActivityID: &activity.ID})
+	if err != nil {
+		t.Fatalf("participant Create failed: %v", err)
+	}
+	if work.ActivityID == nil || *work.ActivityID != activity.ID {
+		t.Errorf("ActivityID = %v, want %d", work.ActivityID, activity.ID)
+	}
+
+	unlink := uint(0)
+	updated, err := svc.Update(2, work.ID, service.UpdateWorkInput{ActivityID: &unlink})
+	if err != nil {
+		t.Fatalf("unlink failed: %v", err)
+	}
+	if updated.ActivityID != nil {
+		t.Errorf("ActivityID = %v, want nil after unlinking", *updated.ActivityID)
+	}
+
+	// The host may link their own work too
+	hostWork, _ := svc.Create(1, service.CreateWorkInput{Images: image})
+	if _, err := svc.Update(1, hostWork.ID, service.UpdateWorkInput{ActivityID: &activity.ID}); err != nil {
+		t.Fatalf("host link failed: %v", err)
+	}
+}
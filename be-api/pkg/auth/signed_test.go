@@ -0,0 +1,59 @@
+package auth_test
+
+import (
+	"testing"
+
+	"azure-magnetar/pkg/auth"
+)
+
+func TestSignAndVerifyValue(t *testing.T) {
+	token := auth.SignValue("12.abcdef", testSecret)
+
+	value, err := auth.VerifySignedValue(token, testSecret)
+	if err != nil {
+		t.Fatalf("VerifySignedValue failed: %v", err)
+	}
+	if value != "12.abcdef" {
+		t.Errorf("value = %q, want %q", value, "12.abcdef")
+	}
+}
+
+func TestVerifySignedValue_Rejects(t *testing.T) {
+	token := auth.SignValue("12.abcdef", testSecret)
+
+	cases := map[string]string{
+		"wrong secret": token,
+		"tampered":     "13.abcdef" + token[len("12.abcdef"):],
+		"no signature": "12.abcdef.",
+		"empty":        "",
+	}
+	for name, tok := range cases {
+		t.Run(name, func(t *testing.T) {
+			secret := testSecret
+			if name == "wrong secret" {
+				secret = "another-secret"
+			}
+			if _, err := auth.VerifySignedValue(tok, secret); err != auth.ErrInvalidToken {
+				t.Errorf("err = %v, want ErrInvalidToken", err)
+			}
+		})
+	}
+}
+
+func TestDeriveSecret(t *testing.T) {
+	links := auth.DeriveSecret(testSecret, "activity-links")
+	if links == testSecret || links == "" {
+		t.Fatalf("derived secret = %q, want a distinct key", links)
+	}
+	if again := auth.DeriveSecret(testSecret, "activity-links"); again != links {
+		t.Error("derivation should be deterministic")
+	}
+	if other := auth.DeriveSecret(testSecret, "other"); other == links {
+		t.Error("different purposes should get different keys")
+	}
+
+	token := auth.SignValue("12.abcdef", links)
+	if _, err := auth.VerifySignedValue(token, testSecret); err != auth.ErrInvalidToken {
+		t.Errorf("token signed with the derived key should not verify with the base secret, got %v", err)
+	}
+}
@@ -0,0 +1,41 @@
+package email
+
+import (
+	"bytes"
+	"fmt"
+	"html/template"
+)
+
+// ActivityAnnouncement holds everything rendered into an announcement email.
+type ActivityAnnouncement struct {
+	ActivityTitle string
+	AuthorName    string
+	EventTime     string
+	Message       string
+	Link          string
+}
+
+// announcementTemplate uses html/template so the host's message is escaped.
+var announcementTemplate = template.Must(template.New("activity_announcement").Parse(`
+			<html>
+				<body>
+					<h2>「{{.ActivityTitle}}」活動公告</h2>
+					<p>{{.AuthorName}} 發布了一則公告{{if .EventTime}}（活動時間：{{.EventTime}}）{{end}}：</p>
+					<p style="white-space:pre-wrap;">{{.Message}}</p>
+					<p><a href="{{.Link}}">前往活動頁面</a></p>
+					<br>
+					<p>拍揪團隊敬上</p>
+				</body>
+			</html>
+		`))
+
+// SendActivityAnnouncementEmail renders and sends an activity announcement.
+func SendActivityAnnouncementEmail(toEmail string, announcement ActivityAnnouncement) error {
+	var buf bytes.Buffer
+	if err := announcementTemplate.Execute(&buf, announcement); err != nil {
+		return fmt.Errorf("failed to render announcement email: %w", err)
+	}
+
+	subject := fmt.Sprintf("拍揪-「%s」活動公告", announcement.ActivityTitle)
+	return sendEmail(toEmail, subject, buf.String())
+}
@@ -0,0 +1,68 @@
+package email
+
+import (
+	"bytes"
+	"fmt"
+	"html/template"
+)
+
+// DigestItem is a single linked entry in a digest email section.
+type DigestItem struct {
+	Title    string
+	Subtitle string
+	Link     string
+}
+
+// WeeklyDigest holds everything rendered into a user's weekly digest email.
+type WeeklyDigest struct {
+	DisplayName     string
+	City            string
+	UnreadCount     int64
+	Notifications   []DigestItem
+	Works           []DigestItem
+	Activities      []DigestItem
+	AppLink         string
+	UnsubscribeLink string
+}
+
+// weeklyDigestTemplate uses html/template so user-generated titles are escaped.
+var weeklyDigestTemplate = template.Must(template.New("weekly_digest").Parse(`
+			<html>
+				<body>
+					<h2>{{.DisplayName}}，這是你本週的拍揪動態</h2>
+					{{if .UnreadCount}}
+					<h3>你有 {{.UnreadCount}} 則未讀通知</h3>
+					<ul>
+						{{range .Notifications}}<li>{{.Title}}{{if .Subtitle}} — {{.Subtitle}}{{end}}</li>{{end}}
+					</ul>
+					{{end}}
+					{{if .Works}}
+					<h3>你追蹤的創作者發表了新作品</h3>
+					<ul>
+						{{range .Works}}<li><a href="{{.Link}}">{{.Title}}</a>{{if .Subtitle}} — {{.Subtitle}}{{end}}</li>{{end}}
+					</ul>
+					{{end}}
+					{{if .Activities}}
+					<h3>{{.City}}近期的活動</h3>
+					<ul>
+						{{range .Activities}}<li><a href="{{.Link}}">{{.Title}}</a>{{if .Subtitle}} — {{.Subtitle}}{{end}}</li>{{end}}
+					</ul>
+					{{end}}
+					<p><a href="{{.AppLink}}">前往拍揪查看更多</a></p>
+					<br>
+					<p>拍揪團隊敬上</p>
+					<p style="font-size:12px;color:#888;">不想再收到每週摘要？<a href="{{.UnsubscribeLink}}">取消訂閱</a></p>
+				</body>
+			</html>
+		`))
+
+// SendWeeklyDigestEmail renders and sends the weekly digest email.
+func SendWeeklyDigestEmail(toEmail string, digest WeeklyDigest) error {
+	var buf bytes.Buffer
+	if err := weeklyDigestTemplate.Execute(&buf, digest); err != nil {
+		return fmt.Errorf("failed to render digest email: %w", err)
+	}
+
+	subject := "拍揪-本週動態摘要"
+	return sendEmail(toEmail, subject, buf.String())
+}
@@ -0,0 +1,122 @@
+// Package ical renders iCalendar (RFC 5545) documents for calendar export and
+// subscription feeds.
+package ical
+
+import (
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Event statuses.
+const (
+	StatusConfirmed = "CONFIRMED"
+	StatusCancelled = "CANCELLED"
+)
+
+// maxLineOctets is the RFC 5545 line length limit, excluding the CRLF.
+const maxLineOctets = 75
+
+// Event is a single VEVENT. Times are written in UTC.
+type Event struct {
+	UID          string
+	Sequence     int // Incremented on every change so clients replace stale copies
+	Start        time.Time
+	End          time.Time
+	Summary      string
+	Description  string
+	Location     string
+	URL          string
+	Status       string // StatusConfirmed or StatusCancelled
+	LastModified time.Time
+}
+
+// Calendar is a VCALENDAR holding events.
+type Calendar struct {
+	Name   string // Shown by clients as the subscription title
+	Events []Event
+}
+
+// Marshal renders the calendar with CRLF line endings and folded long lines.
+func Marshal(cal Calendar) []byte {
+	var b strings.Builder
+	w := func(line string) { writeFolded(&b, line) }
+
+	w("BEGIN:VCALENDAR")
+	w("VERSION:2.0")
+	w("PRODID:-//picchu//Activities//EN")
+	w("CALSCALE:GREGORIAN")
+	w("METHOD:PUBLISH")
+	if cal.Name != "" {
+		w("X-WR-CALNAME:" + escape(cal.Name))
+	}
+
+	now := time.Now()
+	for _, e := range cal.Events {
+		w("BEGIN:VEVENT")
+		w("UID:" + e.UID)
+		w("DTSTAMP:" + formatTime(now))
+		w("SEQUENCE:" + strconv.Itoa(e.Sequence))
+		w("DTSTART:" + formatTime(e.Start))
+		if !e.End.IsZero() {
+			w("DTEND:" + formatTime(e.End))
+		}
+		w("SUMMARY:" + escape(e.Summary))
+		if e.Description != "" {
+			w("DESCRIPTION:" + escape(e.Description))
+		}
+		if e.Location != "" {
+			w("LOCATION:" + escape(e.Location))
+		}
+		if e.URL != "" {
+			w("URL:" + e.URL)
+		}
+		if e.Status != "" {
+			w("STATUS:" + e.Status)
+		}
+		if !e.LastModified.IsZero() {
+			w("LAST-MODIFIED:" + formatTime(e.LastModified))
+		}
+		w("END:VEVENT")
+	}
+
+	w("END:VCALENDAR")
+	return []byte(b.String())
+}
+
+func formatTime(t time.Time) string {
+	return t.UTC().Format("20060102T150405Z")
+}
+
+// escape encodes TEXT values as required by RFC 5545 section 3.3.11.
+func escape(s string) string {
+	return strings.NewReplacer(
+		`\`, `\\`,
+		";", `\;`,
+		",", `\,`,
+		"\r\n", `\n`,
+		"\n", `\n`,
+	).Replace(s)
+}
+
+// writeFolded writes a content line, folding it at 75 octets without splitting
+// multi-byte characters. Continuation lines start with a single space.
+func writeFolded(b *strings.Builder, line string) {
+	limit := maxLineOctets
+	for len(line) > limit {
+		cut := limit
+		for cut > 0 && !isRuneStart(line[cut]) {
+			cut--
+		}
+		b.WriteString(line[:cut])
+		b.WriteString("\r\n ")
+		line = line[cut:]
+		limit = maxLineOctets - 1 // The leading space counts toward the limit
+	}
+	b.WriteString(line)
+	b.WriteString("\r\n")
+}
+
+func isRuneStart(c byte) bool {
+	return c&0xC0 != 0x80
+}
@@ -0,0 +1,65 @@
+package ical_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"azure-magnetar/pkg/ical"
+)
+
+func TestMarshal_Event(t *testing.T) {
+	taipei := time.FixedZone("UTC+8", 8*60*60)
+	out := string(ical.Marshal(ical.Calendar{
+		Name: "My Shoots",
+		Events: []ical.Event{{
+			UID:      "activity-7@azure-magnetar",
+			Sequence: 3,
+			Start:    time.Date(2026, 11, 2, 19, 0, 0, 0, taipei),
+			End:      time.Date(2026, 11, 2, 21, 0, 0, 0, taipei),
+			Summary:  "Studio; portraits, film",
+			Location: "Taipei",
+			Status:   ical.StatusCancelled,
+		}},
+	}))
+
+	for _, want := range []string{
+		"BEGIN:VCALENDAR\r\n",
+		"X-WR-CALNAME:My Shoots\r\n",
+		"UID:activity-7@azure-magnetar\r\n",
+		"SEQUENCE:3\r\n",
+		"DTSTART:20261102T110000Z\r\n", // Converted to UTC
+		"DTEND:20261102T130000Z\r\n",
+		`SUMMARY:Studio\; portraits\, film` + "\r\n",
+		"STATUS:CANCELLED\r\n",
+		"END:VCALENDAR\r\n",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q\n%s", want, out)
+		}
+	}
+}
+
+func TestMarshal_FoldsLongLines(t *testing.T) {
+	out := string(ical.Marshal(ical.Calendar{
+		Events: []ical.Event{{
+			UID:         "x",
+			Start:       time.Now(),
+			Summary:     "s",
+			Description: strings.Repeat("攝影", 40) + "\nsecond line",
+		}},
+	}))
+
+	for _, line := range strings.Split(out, "\r\n") {
+		if len(line) > 75 {
+			t.Errorf("line exceeds 75 octets (%d): %q", len(line), line)
+		}
+	}
+	if !strings.Contains(out, "\r\n ") {
+		t.Error("expected folded continuation lines")
+	}
+	unfolded := strings.ReplaceAll(out, "\r\n ", "")
+	if !strings.Contains(unfolded, strings.Repeat("攝影", 40)+`\nsecond line`) {
+		t.Error("unfolded description does not round-trip")
+	}
+}
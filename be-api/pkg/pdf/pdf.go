@@ -0,0 +1,214 @@
+// Package pdf renders simple text-only PDF documents, such as signed
+// agreements. Text is set in the MSung-Light CJK font that PDF viewers provide
+// without embedding, so Chinese and Latin text both render.
+package pdf
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// Page geometry in points (A4).
+const (
+	pageWidth    = 595
+	pageHeight   = 842
+	margin       = 56
+	contentWidth = pageWidth - 2*margin
+)
+
+// Font sizes in points.
+const (
+	headingSize = 16
+	bodySize    = 11
+	smallSize   = 9
+)
+
+// lineSpacing is the leading as a multiple of the font size.
+const lineSpacing = 1.6
+
+type line struct {
+	text string
+	size float64
+}
+
+// Document is a sequence of wrapped text lines laid out over A4 pages.
+type Document struct {
+	lines []line
+}
+
+// New creates an empty Document.
+func New() *Document {
+	return &Document{}
+}
+
+// Heading adds a large title line.
+func (d *Document) Heading(text string) {
+	d.add(text, headingSize)
+}
+
+// Paragraph adds body text. Newlines start new lines; long lines are wrapped.
+func (d *Document) Paragraph(text string) {
+	d.add(text, bodySize)
+}
+
+// Note adds small print, e.g. signature metadata.
+func (d *Document) Note(text string) {
+	d.add(text, smallSize)
+}
+
+// Space adds an empty line.
+func (d *Document) Space() {
+	d.lines = append(d.lines, line{size: bodySize})
+}
+
+func (d *Document) add(text string, size float64) {
+	for _, para := range strings.Split(strings.ReplaceAll(text, "\r\n", "\n"), "\n") {
+		for _, l := range wrap(para, float64(contentWidth)/size) {
+			d.lines = append(d.lines, line{text: l, size: size})
+		}
+	}
+}
+
+// Bytes renders the document as a PDF file.
+func (d *Document) Bytes() []byte {
+	pages := d.paginate()
+
+	var objects []string
+	// 1: catalog, 2: pages, 3: font, 4: descendant font, 5: font descriptor,
+	// then a page object and a content stream per page.
+	kids := make([]string, len(pages))
+	for i := range pages {
+		kids[i] = fmt.Sprintf("%d 0 R", 6+2*i)
+	}
+	objects = append(objects,
+		"<< /Type /Catalog /Pages 2 0 R >>",
+		fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d >>", strings.Join(kids, " "), len(pages)),
+		"<< /Type /Font /Subtype /Type0 /BaseFont /MSung-Light /Encoding /UniCNS-UCS2-H /DescendantFonts [4 0 R] >>",
+		"<< /Type /Font /Subtype /CIDFontType0 /BaseFont /MSung-Light /CIDSystemInfo << /Registry (Adobe) /Ordering (CNS1) /Supplement 4 >> /FontDescriptor 5 0 R /DW 1000 >>",
+		"<< /Type /FontDescriptor /FontName /MSung-Light /Flags 6 /FontBBox [-160 -249 1015 1071] /ItalicAngle 0 /Ascent 880 /Descent -120 /CapHeight 880 /StemV 93 >>",
+	)
+	for i, page := range pages {
+		stream := renderPage(page)
+		objects = append(objects,
+			fmt.Sprintf("<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %d %d] /Resources << /Font << /F1 3 0 R >> >> /Contents %d 0 R >>", pageWidth, pageHeight, 7+2*i),
+			fmt.Sprintf("<< /Length %d >>\nstream\n%s\nendstream", len(stream), stream),
+		)
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString("%PDF-1.4\n%\xe2\xe3\xcf\xd3\n")
+	offsets := make([]int, len(objects))
+	for i, obj := range objects {
+		offsets[i] = buf.Len()
+		fmt.Fprintf(&buf, "%d 0 obj\n%s\nendobj\n", i+1, obj)
+	}
+
+	xref := buf.Len()
+	fmt.Fprintf(&buf, "xref\n0 %d\n0000000000 65535 f \n", len(objects)+1)
+	for _, off := range offsets {
+		fmt.Fprintf(&buf, "%010d 00000 n \n", off)
+	}
+	fmt.Fprintf(&buf, "trailer\n<< /Size %d /Root 1 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(objects)+1, xref)
+	return buf.Bytes()
+}
+
+// paginate splits the lines into pages. A document always has one page.
+func (d *Document) paginate() [][]line {
+	pages := [][]line{nil}
+	y := float64(pageHeight - margin)
+	for _, l := range d.lines {
+		step := l.size * lineSpacing
+		if y-step < margin && len(pages[len(pages)-1]) > 0 {
+			pages = append(pages, nil)
+			y = float64(pageHeight - margin)
+		}
+		pages[len(pages)-1] = append(pages[len(pages)-1], l)
+		y -= step
+	}
+	return pages
+}
+
+// renderPage writes the content stream for one page.
+func renderPage(lines []line) string {
+	var b strings.Builder
+	y := float64(pageHeight - margin)
+	for _, l := range lines {
+		y -= l.size * lineSpacing
+		if l.text == "" {
+			continue
+		}
+		fmt.Fprintf(&b, "BT /F1 %g Tf %d %.2f Td <%s> Tj ET\n", l.size, margin, y, encodeUCS2(l.text))
+	}
+	return b.String()
+}
+
+// encodeUCS2 hex-encodes text as big-endian UCS-2. Characters outside the
+// Basic Multilingual Plane become "?".
+func encodeUCS2(text string) string {
+	var b strings.Builder
+	for _, r := range text {
+		if r > 0xFFFF {
+			r = '?'
+		}
+		fmt.Fprintf(&b, "%04X", r)
+	}
+	return b.String()
+}
+
+// wrap breaks text into lines no wider than maxEm font sizes, preferring to
+// break Latin text at spaces.
+func wrap(text string, maxEm float64) []string {
+	if text == "" {
+		return []string{""}
+	}
+
+	var lines []string
+	var current []rune
+	width := 0.0
+	for _, r := range text {
+		w := runeWidth(r)
+		if width+w > maxEm && len(current) > 0 {
+			cut := len(current)
+			if r != ' ' && !isWide(r) {
+				if i := lastSpace(current); i > 0 {
+					cut = i
+				}
+			}
+			lines = append(lines, strings.TrimRight(string(current[:cut]), " "))
+			current = []rune(strings.TrimLeft(string(current[cut:]), " "))
+			width = 0
+			for _, c := range current {
+				width += runeWidth(c)
+			}
+			if r == ' ' && len(current) == 0 {
+				continue
+			}
+		}
+		current = append(current, r)
+		width += w
+	}
+	return append(lines, string(current))
+}
+
+func lastSpace(runes []rune) int {
+	for i := len(runes) - 1; i >= 0; i-- {
+		if runes[i] == ' ' {
+			return i
+		}
+	}
+	return -1
+}
+
+// runeWidth estimates a glyph's advance in font sizes.
+func runeWidth(r rune) float64 {
+	if isWide(r) {
+		return 1
+	}
+	return 0.55
+}
+
+func isWide(r rune) bool {
+	return r > 0x2E7F || unicode.Is(unicode.Han, r)
+}
@@ -0,0 +1,48 @@
+package pdf_test
+
+import (
+	"bytes"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+
+	"azure-magnetar/pkg/pdf"
+)
+
+func TestBytes_Structure(t *testing.T) {
+	doc := pdf.New()
+	doc.Heading("拍攝合作協議")
+	doc.Paragraph("Model release for portfolio use only.")
+	doc.Note("SHA-256: abc123")
+
+	out := doc.Bytes()
+	if !bytes.HasPrefix(out, []byte("%PDF-1.4")) || !bytes.HasSuffix(out, []byte("%%EOF\n")) {
+		t.Fatal("missing PDF header or trailer")
+	}
+
+	// startxref must point at the xref table
+	m := regexp.MustCompile(`startxref\n(\d+)\n`).FindSubmatch(out)
+	if m == nil {
+		t.Fatal("missing startxref")
+	}
+	off, _ := strconv.Atoi(string(m[1]))
+	if !bytes.HasPrefix(out[off:], []byte("xref\n")) {
+		t.Errorf("startxref %d does not point at the xref table", off)
+	}
+
+	// 拍 is U+62CD
+	if !bytes.Contains(out, []byte("<62CD")) {
+		t.Error("heading not UCS-2 encoded")
+	}
+}
+
+func TestBytes_Paginates(t *testing.T) {
+	doc := pdf.New()
+	doc.Paragraph(strings.Repeat("line\n", 200))
+
+	out := string(doc.Bytes())
+	if got := strings.Count(out, "/Type /Page "); got < 2 {
+		t.Errorf("pages = %d, want at least 2", got)
+	}
+}
@@ -0,0 +1,61 @@
+// Package realtime fans out events to in-process subscribers, e.g. the
+// Server-Sent Events streams of a chat room.
+package realtime
+
+import "sync"
+
+// subscriberBuffer is how many undelivered events a subscriber may queue
+// before further events are dropped for it.
+const subscriberBuffer = 32
+
+// Hub delivers published events to every current subscriber of a topic.
+// It is safe for concurrent use.
+type Hub struct {
+	mu     sync.RWMutex
+	topics map[string]map[chan any]struct{}
+}
+
+// NewHub creates an empty Hub.
+func NewHub() *Hub {
+	return &Hub{topics: make(map[string]map[chan any]struct{})}
+}
+
+// Subscribe registers a subscriber for topic. The returned cancel function
+// unregisters it and closes the channel; it is safe to call more than once.
+func (h *Hub) Subscribe(topic string) (<-chan any, func()) {
+	ch := make(chan any, subscriberBuffer)
+
+	h.mu.Lock()
+	if h.topics[topic] == nil {
+		h.topics[topic] = make(map[chan any]struct{})
+	}
+	h.topics[topic][ch] = struct{}{}
+	h.mu.Unlock()
+
+	var once sync.Once
+	cancel := func() {
+		once.Do(func() {
+			h.mu.Lock()
+			delete(h.topics[topic], ch)
+			if len(h.topics[topic]) == 0 {
+				delete(h.topics, topic)
+			}
+			h.mu.Unlock()
+			close(ch)
+		})
+	}
+	return ch, cancel
+}
+
+// Publish sends event to every subscriber of topic without blocking. A
+// subscriber whose buffer is full misses the event.
+func (h *Hub) Publish(topic string, event any) {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	for ch := range h.topics[topic] {
+		select {
+		case ch <- event:
+		default:
+		}
+	}
+}
@@ -0,0 +1,53 @@
+package realtime_test
+
+import (
+	"testing"
+
+	"azure-magnetar/pkg/realtime"
+)
+
+func TestHub_PublishToTopicSubscribers(t *testing.T) {
+	hub := realtime.NewHub()
+	a, cancelA := hub.Subscribe("room-1")
+	defer cancelA()
+	b, cancelB := hub.Subscribe("room-2")
+	defer cancelB()
+
+	hub.Publish("room-1", "hello")
+
+	select {
+	case got := <-a:
+		if got != "hello" {
+			t.Errorf("got %v, want hello", got)
+		}
+	default:
+		t.Fatal("subscriber of room-1 got nothing")
+	}
+	select {
+	case got := <-b:
+		t.Errorf("subscriber of room-2 got %v", got)
+	default:
+	}
+}
+
+func TestHub_CancelClosesAndStopsDelivery(t *testing.T) {
+	hub := realtime.NewHub()
+	ch, cancel := hub.Subscribe("room")
+	cancel()
+	cancel() // Safe to call twice
+
+	hub.Publish("room", "late")
+	if _, ok := <-ch; ok {
+		t.Error("channel should be closed after cancel")
+	}
+}
+
+func TestHub_SlowSubscriberDoesNotBlock(t *testing.T) {
+	hub := realtime.NewHub()
+	_, cancel := hub.Subscribe("room")
+	defer cancel()
+
+	for i := 0; i < 100; i++ {
+		hub.Publish("room", i) // Must not block once the buffer is full
+	}
+}
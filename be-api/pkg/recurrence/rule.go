@@ -0,0 +1,154 @@
+// Package recurrence implements the subset of iCalendar recurrence rules
+// (RFC 5545 RRULE) used for activity series: weekly or monthly repetition
+// with an interval and an optional UNTIL or COUNT bound.
+package recurrence
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Supported frequencies.
+const (
+	Weekly  = "WEEKLY"
+	Monthly = "MONTHLY"
+)
+
+// maxIterations bounds expansion so a malformed rule can never loop forever.
+const maxIterations = 10000
+
+// ErrInvalidRule is returned when a rule cannot be parsed or is unsupported.
+var ErrInvalidRule = errors.New("invalid recurrence rule")
+
+// Rule is a parsed recurrence rule.
+type Rule struct {
+	Frequency string
+	Interval  int
+	Until     *time.Time // Inclusive upper bound, in UTC
+	Count     int        // Total number of occurrences including the first; 0 = unbounded
+}
+
+// Parse reads a rule such as "FREQ=WEEKLY;INTERVAL=2;COUNT=10". The optional
+// "RRULE:" prefix is accepted. UNTIL may be a date (20261231) or a UTC
+// date-time (20261231T235959Z).
+func Parse(s string) (Rule, error) {
+	s = strings.TrimPrefix(strings.TrimSpace(s), "RRULE:")
+	if s == "" {
+		return Rule{}, fmt.Errorf("%w: empty rule", ErrInvalidRule)
+	}
+
+	rule := Rule{Interval: 1}
+	for _, part := range strings.Split(s, ";") {
+		key, value, ok := strings.Cut(part, "=")
+		if !ok {
+			return Rule{}, fmt.Errorf("%w: malformed part %q", ErrInvalidRule, part)
+		}
+
+		switch strings.ToUpper(key) {
+		case "FREQ":
+			rule.Frequency = strings.ToUpper(value)
+		case "INTERVAL":
+			n, err := strconv.Atoi(value)
+			if err != nil || n < 1 {
+				return Rule{}, fmt.Errorf("%w: INTERVAL must be a positive integer", ErrInvalidRule)
+			}
+			rule.Interval = n
+		case "COUNT":
+			n, err := strconv.Atoi(value)
+			if err != nil || n < 1 {
+				return Rule{}, fmt.Errorf("%w: COUNT must be a positive integer", ErrInvalidRule)
+			}
+			rule.Count = n
+		case "UNTIL":
+			t, err := parseUntil(value)
+			if err != nil {
+				return Rule{}, err
+			}
+			rule.Until = &t
+		default:
+			return Rule{}, fmt.Errorf("%w: unsupported part %q", ErrInvalidRule, key)
+		}
+	}
+
+	if rule.Frequency != Weekly && rule.Frequency != Monthly {
+		return Rule{}, fmt.Errorf("%w: FREQ must be WEEKLY or MONTHLY", ErrInvalidRule)
+	}
+	if rule.Until != nil && rule.Count > 0 {
+		return Rule{}, fmt.Errorf("%w: UNTIL and COUNT cannot both be set", ErrInvalidRule)
+	}
+	return rule, nil
+}
+
+func parseUntil(value string) (time.Time, error) {
+	if t, err := time.Parse("20060102T150405Z", value); err == nil {
+		return t, nil
+	}
+	if t, err := time.Parse("20060102", value); err == nil {
+		// A bare date includes the whole day
+		return t.Add(24*time.Hour - time.Second), nil
+	}
+	return time.Time{}, fmt.Errorf("%w: UNTIL must look like 20261231 or 20261231T235959Z", ErrInvalidRule)
+}
+
+// String formats the rule in RRULE syntax (without the "RRULE:" prefix).
+func (r Rule) String() string {
+	parts := []string{"FREQ=" + r.Frequency}
+	if r.Interval > 1 {
+		parts = append(parts, "INTERVAL="+strconv.Itoa(r.Interval))
+	}
+	if r.Count > 0 {
+		parts = append(parts, "COUNT="+strconv.Itoa(r.Count))
+	}
+	if r.Until != nil {
+		parts = append(parts, "UNTIL="+r.Until.UTC().Format("20060102T150405Z"))
+	}
+	return strings.Join(parts, ";")
+}
+
+// Expand returns the occurrences of a series starting at start that fall in
+// (after, before], in order. The first occurrence is start itself. Monthly
+// occurrences skip months that lack start's day (e.g. the 31st), as RFC 5545
+// does. Occurrences keep start's location and wall-clock time.
+func (r Rule) Expand(start, after, before time.Time) []time.Time {
+	var result []time.Time
+	count := 0
+	for i := 0; i < maxIterations; i++ {
+		t, ok := r.candidate(start, i)
+		if t.After(before) {
+			break
+		}
+		if r.Until != nil && t.After(*r.Until) {
+			break
+		}
+		if !ok {
+			continue
+		}
+
+		count++
+		if r.Count > 0 && count > r.Count {
+			break
+		}
+		if t.After(after) {
+			result = append(result, t)
+		}
+	}
+	return result
+}
+
+// candidate returns the i-th candidate date and whether it is a real occurrence.
+func (r Rule) candidate(start time.Time, i int) (time.Time, bool) {
+	interval := r.Interval
+	if interval < 1 {
+		interval = 1
+	}
+
+	if r.Frequency == Monthly {
+		t := start.AddDate(0, i*interval, 0)
+		// AddDate normalizes Jan 31 + 1 month to Mar 3; such dates are skipped
+		return t, t.Day() == start.Day()
+	}
+	return start.AddDate(0, 0, 7*i*interval), true
+}
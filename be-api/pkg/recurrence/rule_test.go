@@ -0,0 +1,94 @@
+package recurrence_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"azure-magnetar/pkg/recurrence"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{"FREQ=WEEKLY", "FREQ=WEEKLY"},
+		{"RRULE:FREQ=weekly;INTERVAL=2;COUNT=5", "FREQ=WEEKLY;INTERVAL=2;COUNT=5"},
+		{"FREQ=MONTHLY;UNTIL=20261231", "FREQ=MONTHLY;UNTIL=20261231T235959Z"},
+		{"FREQ=MONTHLY;UNTIL=20261231T120000Z", "FREQ=MONTHLY;UNTIL=20261231T120000Z"},
+	}
+	for _, tt := range tests {
+		rule, err := recurrence.Parse(tt.in)
+		if err != nil {
+			t.Errorf("Parse(%q) error: %v", tt.in, err)
+			continue
+		}
+		if got := rule.String(); got != tt.want {
+			t.Errorf("Parse(%q).String() = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestParse_Invalid(t *testing.T) {
+	for _, in := range []string{
+		"",
+		"FREQ=DAILY",
+		"FREQ=WEEKLY;INTERVAL=0",
+		"FREQ=WEEKLY;COUNT=3;UNTIL=20261231",
+		"FREQ=WEEKLY;BYDAY=MO",
+		"FREQ=WEEKLY;UNTIL=tomorrow",
+	} {
+		if _, err := recurrence.Parse(in); !errors.Is(err, recurrence.ErrInvalidRule) {
+			t.Errorf("Parse(%q) err = %v, want ErrInvalidRule", in, err)
+		}
+	}
+}
+
+func TestExpand_WeeklyCount(t *testing.T) {
+	rule, _ := recurrence.Parse("FREQ=WEEKLY;INTERVAL=2;COUNT=3")
+	start := time.Date(2026, 11, 2, 19, 0, 0, 0, time.UTC)
+
+	got := rule.Expand(start, start.Add(-time.Second), start.AddDate(1, 0, 0))
+	want := []time.Time{start, start.AddDate(0, 0, 14), start.AddDate(0, 0, 28)}
+	if len(got) != len(want) {
+		t.Fatalf("Expand returned %d occurrences, want %d", len(got), len(want))
+	}
+	for i := range want {
+		if !got[i].Equal(want[i]) {
+			t.Errorf("occurrence %d = %v, want %v", i, got[i], want[i])
+		}
+	}
+
+	// Continuing after the second occurrence only yields the third
+	rest := rule.Expand(start, want[1], start.AddDate(1, 0, 0))
+	if len(rest) != 1 || !rest[0].Equal(want[2]) {
+		t.Errorf("Expand after second = %v, want [%v]", rest, want[2])
+	}
+}
+
+func TestExpand_MonthlySkipsShortMonths(t *testing.T) {
+	rule, _ := recurrence.Parse("FREQ=MONTHLY;UNTIL=20270501")
+	start := time.Date(2027, 1, 31, 10, 0, 0, 0, time.UTC)
+
+	got := rule.Expand(start, start.Add(-time.Second), start.AddDate(2, 0, 0))
+	wantMonths := []time.Month{time.January, time.March}
+	if len(got) != len(wantMonths) {
+		t.Fatalf("Expand returned %v, want occurrences in %v", got, wantMonths)
+	}
+	for i, m := range wantMonths {
+		if got[i].Month() != m || got[i].Day() != 31 {
+			t.Errorf("occurrence %d = %v, want %s 31", i, got[i], m)
+		}
+	}
+}
+
+func TestExpand_StopsAtWindow(t *testing.T) {
+	rule, _ := recurrence.Parse("FREQ=WEEKLY")
+	start := time.Date(2026, 11, 2, 19, 0, 0, 0, time.UTC)
+
+	got := rule.Expand(start, start, start.AddDate(0, 0, 21))
+	if len(got) != 3 {
+		t.Errorf("Expand returned %d occurrences within 3 weeks after start, want 3", len(got))
+	}
+}
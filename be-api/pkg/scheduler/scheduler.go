@@ -0,0 +1,77 @@
+package scheduler
+
+import (
+	"context"
+	"fmt"
+	"runtime/debug"
+	"time"
+
+	"azure-magnetar/pkg/logger"
+)
+
+// Job is a unit of background work. A returned error is logged and the job
+// is retried on its next scheduled run.
+type Job func() error
+
+// Every runs job repeatedly with the given interval until ctx is cancelled.
+// The first run happens after one interval has elapsed.
+func Every(ctx context.Context, name string, interval time.Duration, job Job) {
+	start(ctx, name, job, func(now time.Time) time.Time {
+		return now.Add(interval)
+	})
+}
+
+// Weekly runs job once a week at the given weekday and hour in loc until ctx
+// is cancelled.
+func Weekly(ctx context.Context, name string, weekday time.Weekday, hour int, loc *time.Location, job Job) {
+	start(ctx, name, job, func(now time.Time) time.Time {
+		return NextWeekly(now, weekday, hour, loc)
+	})
+}
+
+// NextWeekly returns the first time strictly after now that falls on weekday
+// at hour:00 in loc.
+func NextWeekly(now time.Time, weekday time.Weekday, hour int, loc *time.Location) time.Time {
+	local := now.In(loc)
+	next := time.Date(local.Year(), local.Month(), local.Day(), hour, 0, 0, 0, loc)
+	next = next.AddDate(0, 0, (int(weekday)-int(local.Weekday())+7)%7)
+	if !next.After(local) {
+		next = next.AddDate(0, 0, 7)
+	}
+	return next
+}
+
+// start launches the scheduling loop in its own goroutine.
+func start(ctx context.Context, name string, job Job, next func(time.Time) time.Time) {
+	go func() {
+		logger.Info("scheduler job registered", "job", name)
+		for {
+			runAt := next(time.Now())
+			timer := time.NewTimer(time.Until(runAt))
+			select {
+			case <-ctx.Done():
+				timer.Stop()
+				return
+			case <-timer.C:
+				run(name, job)
+			}
+		}
+	}()
+}
+
+// run executes a single job invocation, recovering from panics so that one
+// failing run does not stop the schedule.
+func run(name string, job Job) {
+	defer func() {
+		if err := recover(); err != nil {
+			logger.Error("scheduler job panicked", "job", name, "error", fmt.Sprintf("%v", err), "stack", string(debug.Stack()))
+		}
+	}()
+
+	started := time.Now()
+	if err := job(); err != nil {
+		logger.Error("scheduler job failed", "job", name, "error", err)
+		return
+	}
+	logger.Info("scheduler job finished", "job", name, "duration", time.Since(started).String())
+}
@@ -0,0 +1,48 @@
+package scheduler_test
+
+import (
+	"testing"
+	"time"
+
+	"azure-magnetar/pkg/scheduler"
+)
+
+func TestNextWeekly(t *testing.T) {
+	loc := time.FixedZone("UTC+8", 8*60*60)
+
+	tests := []struct {
+		name string
+		now  time.Time
+		want time.Time
+	}{
+		{
+			name: "later the same week",
+			now:  time.Date(2026, 5, 4, 8, 0, 0, 0, loc), // Monday 08:00
+			want: time.Date(2026, 5, 4, 9, 0, 0, 0, loc),
+		},
+		{
+			name: "exactly at run time rolls to next week",
+			now:  time.Date(2026, 5, 4, 9, 0, 0, 0, loc),
+			want: time.Date(2026, 5, 11, 9, 0, 0, 0, loc),
+		},
+		{
+			name: "mid-week",
+			now:  time.Date(2026, 5, 7, 15, 30, 0, 0, loc), // Thursday
+			want: time.Date(2026, 5, 11, 9, 0, 0, 0, loc),
+		},
+		{
+			name: "input in another zone",
+			now:  time.Date(2026, 5, 3, 23, 0, 0, 0, time.UTC), // Monday 07:00 in loc
+			want: time.Date(2026, 5, 4, 9, 0, 0, 0, loc),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := scheduler.NextWeekly(tt.now, time.Monday, 9, loc)
+			if !got.Equal(tt.want) {
+				t.Errorf("NextWeekly() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
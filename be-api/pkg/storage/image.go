@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 	"time"
 
 	"cloud.google.com/go/storage"
@@ -32,7 +33,8 @@ func SaveBase64Image(baseURL, bucketName, category string, ownerID uint, base64D
 		return "", errors.New("invalid base64 image data")
 	}
 
-	fileName := fmt.Sprintf("%s_%d_%d_%d.jpg", category, ownerID, time.Now().Unix(), index)
+	// Nanoseconds keep names unique when images are replaced soon after upload
+	fileName := fmt.Sprintf("%s_%d_%d_%d.jpg", category, ownerID, time.Now().UnixNano(), index)
 
 	// Local Storage Fallback
 	if bucketName == "" {
@@ -75,3 +77,39 @@ func SaveBase64Image(baseURL, bucketName, category string, ownerID uint, base64D
 
 	return fmt.Sprintf("https://storage.googleapis.com/%s/%s", bucketName, gcsPath), nil
 }
+
+// DeleteImage removes an image saved by SaveBase64Image, given the URL it
+// returned. URLs that point elsewhere are ignored, as are images that are
+// already gone.
+func DeleteImage(baseURL, bucketName, imageURL string) error {
+	// Local Storage Fallback
+	if bucketName == "" {
+		rel, ok := strings.CutPrefix(imageURL, baseURL+"/uploads/")
+		if !ok || rel == "" || strings.Contains(rel, "..") {
+			return nil
+		}
+		err := os.Remove(filepath.Join("uploads", filepath.FromSlash(rel)))
+		if err != nil && !errors.Is(err, os.ErrNotExist) {
+			return fmt.Errorf("failed to delete image: %w", err)
+		}
+		return nil
+	}
+
+	gcsPath, ok := strings.CutPrefix(imageURL, fmt.Sprintf("https://storage.googleapis.com/%s/", bucketName))
+	if !ok || gcsPath == "" {
+		return nil
+	}
+
+	ctx := context.Background()
+	client, err := storage.NewClient(ctx)
+	if err != nil {
+		return fmt.Errorf("storage.NewClient: %w", err)
+	}
+	defer client.Close()
+
+	err = client.Bucket(bucketName).Object(gcsPath).Delete(ctx)
+	if err != nil && !errors.Is(err, storage.ErrObjectNotExist) {
+		return fmt.Errorf("failed to delete from GCS: %w", err)
+	}
+	return nil
+}
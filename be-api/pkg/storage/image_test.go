@@ -0,0 +1,43 @@
+package storage_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"azure-magnetar/pkg/storage"
+)
+
+// 1x1 GIF
+const testImage = "R0lGODlhAQABAIAAAAAAAP///yH5BAEAAAAALAAAAAABAAEAAAIBRAA7"
+
+func TestDeleteImage_Local(t *testing.T) {
+	t.Chdir(t.TempDir())
+
+	url, err := storage.SaveBase64Image("http://localhost:8080", "", "works", 1, testImage, 0)
+	if err != nil {
+		t.Fatalf("SaveBase64Image failed: %v", err)
+	}
+	files, _ := filepath.Glob(filepath.Join("uploads", "works", "*"))
+	if len(files) != 1 {
+		t.Fatalf("expected 1 saved file, got %d", len(files))
+	}
+
+	if err := storage.DeleteImage("http://localhost:8080", "", url); err != nil {
+		t.Fatalf("DeleteImage failed: %v", err)
+	}
+	if _, err := os.Stat(files[0]); !os.IsNotExist(err) {
+		t.Errorf("expected the file to be removed, got %v", err)
+	}
+
+	// Deleting again, or a URL this storage did not produce, is a no-op
+	if err := storage.DeleteImage("http://localhost:8080", "", url); err != nil {
+		t.Errorf("deleting a missing image should not fail: %v", err)
+	}
+	if err := storage.DeleteImage("http://localhost:8080", "", "https://example.com/uploads/works/x.jpg"); err != nil {
+		t.Errorf("foreign URL should be ignored: %v", err)
+	}
+	if err := storage.DeleteImage("http://localhost:8080", "", "http://localhost:8080/uploads/../go.mod"); err != nil {
+		t.Errorf("path traversal should be ignored: %v", err)
+	}
+}